@@ -0,0 +1,134 @@
+package barcode
+
+import (
+	"fmt"
+	"strings"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+)
+
+/* Code 128, code set B - printable ASCII. Every symbol is 3 bars and 3 spaces, 11 modules wide:
+   start B, one symbol per character, the checksum symbol and the stop symbol (4 bars, 13 modules).
+   The checksum is the start value plus every symbol value multiplied by its position, modulo 103.
+*/
+
+// Bar and space widths of the symbols, bar first. The index is the symbol value.
+var code128Patterns = []string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232",
+}
+
+var code128StartB = 104
+var code128Stop = "2331112"
+
+// Code128 - the bar and space widths (in modules) of a Code 128 barcode, bar first
+type Code128 struct {
+	Text   string
+	Widths []int
+}
+
+// EncodeCode128 - encodes printable ASCII text
+func EncodeCode128(text string) (*Code128, error) {
+	values := []int{code128StartB}
+	checksum := code128StartB
+	for i, c := range []byte(text) {
+		if c < 32 || c > 126 {
+			return nil, fmt.Errorf("character %q is not printable ASCII", c)
+		}
+		values = append(values, int(c)-32)
+		checksum += (i + 1) * (int(c) - 32)
+	}
+	values = append(values, checksum%103)
+
+	code := &Code128{Text: text}
+	for _, value := range values {
+		code.Widths = append(code.Widths, patternWidths(code128Patterns[value])...)
+	}
+	code.Widths = append(code.Widths, patternWidths(code128Stop)...)
+
+	return code, nil
+}
+
+// Modules - the width of the barcode in modules
+func (c *Code128) Modules() int {
+	total := 0
+	for _, width := range c.Widths {
+		total += width
+	}
+	return total
+}
+
+// DecodeCode128 - reads the text from the bar and space widths, bar first
+func DecodeCode128(widths []int) (string, error) {
+	if len(widths) < 6*3+7 || (len(widths)-7)%6 != 0 {
+		return "", fmt.Errorf("%w: unexpected number of bars", myerrors.ErrInvalidCode)
+	}
+
+	if patternString(widths[len(widths)-7:]) != code128Stop {
+		return "", fmt.Errorf("%w: missing stop symbol", myerrors.ErrInvalidCode)
+	}
+
+	var values []int
+	for i := 0; i < len(widths)-7; i += 6 {
+		pattern := patternString(widths[i : i+6])
+		value := -1
+		for v, p := range code128Patterns {
+			if p == pattern {
+				value = v
+				break
+			}
+		}
+		if value < 0 {
+			return "", fmt.Errorf("%w: unknown symbol %s", myerrors.ErrInvalidCode, pattern)
+		}
+		values = append(values, value)
+	}
+
+	if values[0] != code128StartB {
+		return "", fmt.Errorf("%w: only code set B is supported", myerrors.ErrInvalidCode)
+	}
+
+	data := values[1 : len(values)-1]
+	checksum := code128StartB
+	var text strings.Builder
+	for i, value := range data {
+		if value > 94 {
+			return "", fmt.Errorf("%w: unsupported symbol %d", myerrors.ErrInvalidCode, value)
+		}
+		checksum += (i + 1) * value
+		text.WriteByte(byte(value + 32))
+	}
+	if checksum%103 != values[len(values)-1] {
+		return "", fmt.Errorf("%w: wrong checksum", myerrors.ErrInvalidCode)
+	}
+
+	return text.String(), nil
+}
+
+func patternWidths(pattern string) []int {
+	widths := make([]int, len(pattern))
+	for i, c := range pattern {
+		widths[i] = int(c - '0')
+	}
+	return widths
+}
+
+func patternString(widths []int) string {
+	var pattern strings.Builder
+	for _, width := range widths {
+		if width < 1 || width > 4 {
+			return ""
+		}
+		pattern.WriteByte(byte('0' + width))
+	}
+	return pattern.String()
+}
@@ -0,0 +1,69 @@
+package barcode
+
+import (
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	seen := make(map[string]bool)
+	for value, pattern := range code128Patterns {
+		width := 0
+		for _, module := range patternWidths(pattern) {
+			width += module
+		}
+		assert.Equal(t, 11, width, "symbol %d", value)
+		assert.False(t, seen[pattern], "symbol %d is duplicated", value)
+		seen[pattern] = true
+	}
+	assert.Len(t, code128Patterns, 106)
+}
+
+func TestEncodeCode128_RoundTrip(t *testing.T) {
+	// Act
+	code, err := EncodeCode128("sub=42;page=1/3")
+	require.NoError(t, err)
+	text, err := DecodeCode128(code.Widths)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "sub=42;page=1/3", text)
+	// Start, 15 characters and the checksum, then the stop symbol
+	assert.Equal(t, 17*11+13, code.Modules())
+}
+
+func TestEncodeCode128_KnownChecksum(t *testing.T) {
+	// Act - start B (104) + 1*'P'(48) + 2*'J'(42) + 3*'J'(42) + 4*'1'(17) = 430, 430 % 103 = 18
+	code, err := EncodeCode128("PJJ1")
+	require.NoError(t, err)
+
+	// Assert
+	checksum := code.Widths[len(code.Widths)-13 : len(code.Widths)-7]
+	assert.Equal(t, code128Patterns[18], patternString(checksum))
+}
+
+func TestEncodeCode128_NotPrintable(t *testing.T) {
+	// Act
+	_, err := EncodeCode128("tab\there")
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestDecodeCode128_WrongChecksum(t *testing.T) {
+	// Arrange
+	code, err := EncodeCode128("AB")
+	require.NoError(t, err)
+
+	// Replace the checksum symbol with another valid symbol
+	copy(code.Widths[len(code.Widths)-13:], patternWidths(code128Patterns[5]))
+
+	// Act
+	_, err = DecodeCode128(code.Widths)
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrInvalidCode)
+}
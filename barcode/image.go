@@ -0,0 +1,152 @@
+package barcode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+)
+
+// The light margin around a code that the scanners need, in modules
+var qrQuietZone, code128QuietZone = 4, 10
+
+/* The images are the helpers for the round-trip tests and for the scanning tools: a code drawn as an image
+   can be decoded back. The decoders expect what the encoders draw - an upright code on a light background,
+   not a photo of a page.
+*/
+
+// Image - the QR code with its quiet zone, every module scale x scale pixels
+func (q *QRCode) Image(scale int) *image.Gray {
+	side := (q.Size + 2*qrQuietZone) * scale
+	img := newWhiteImage(side, side)
+	for y, row := range q.Modules {
+		for x, dark := range row {
+			if dark {
+				fillRectangle(img, (x+qrQuietZone)*scale, (y+qrQuietZone)*scale, scale, scale)
+			}
+		}
+	}
+	return img
+}
+
+// DecodeQRImage - samples the modules of the QR code in the image and decodes them
+func DecodeQRImage(img image.Image) ([]byte, error) {
+	bounds, ok := darkBounds(img)
+	if !ok {
+		return nil, fmt.Errorf("%w: no code found", myerrors.ErrInvalidCode)
+	}
+
+	// The top row of the top left finder is 7 dark modules
+	run := 0
+	for x := bounds.Min.X; x < bounds.Max.X && isDark(img, x, bounds.Min.Y); x++ {
+		run++
+	}
+	moduleSize := float64(run) / 7
+	size := int(float64(bounds.Dx())/moduleSize + 0.5)
+	if run < 7 || size < 21 {
+		return nil, fmt.Errorf("%w: no finder pattern found", myerrors.ErrInvalidCode)
+	}
+
+	modules := make([][]bool, size)
+	for y := range modules {
+		modules[y] = make([]bool, size)
+		for x := range modules[y] {
+			modules[y][x] = isDark(img, bounds.Min.X+int((float64(x)+0.5)*moduleSize), bounds.Min.Y+int((float64(y)+0.5)*moduleSize))
+		}
+	}
+
+	return DecodeQR(modules)
+}
+
+// Image - the barcode with its quiet zone, every module scale pixels wide
+func (c *Code128) Image(scale, height int) *image.Gray {
+	img := newWhiteImage((c.Modules()+2*code128QuietZone)*scale, height)
+	x := code128QuietZone * scale
+	for i, width := range c.Widths {
+		// Even positions are bars
+		if i%2 == 0 {
+			fillRectangle(img, x, 0, width*scale, height)
+		}
+		x += width * scale
+	}
+	return img
+}
+
+// DecodeCode128Image - measures the bars across the middle of the image and decodes them
+func DecodeCode128Image(img image.Image) (string, error) {
+	bounds := img.Bounds()
+	y := bounds.Min.Y + bounds.Dy()/2
+
+	var runs []int
+	dark, run := false, 0
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		pixel := isDark(img, x, y)
+		if pixel == dark {
+			run++
+			continue
+		}
+		// The light run before the first bar is the quiet zone
+		if dark || len(runs) > 0 {
+			runs = append(runs, run)
+		}
+		dark, run = pixel, 1
+	}
+	if dark {
+		runs = append(runs, run)
+	}
+	if len(runs) < 6 {
+		return "", fmt.Errorf("%w: no barcode found", myerrors.ErrInvalidCode)
+	}
+
+	// The start symbol is 11 modules wide
+	start := 0
+	for _, width := range runs[:6] {
+		start += width
+	}
+	moduleSize := float64(start) / 11
+
+	widths := make([]int, len(runs))
+	for i, width := range runs {
+		widths[i] = int(float64(width)/moduleSize + 0.5)
+	}
+
+	return DecodeCode128(widths)
+}
+
+func newWhiteImage(width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	return img
+}
+
+func fillRectangle(img *image.Gray, x, y, width, height int) {
+	for dy := 0; dy < height; dy++ {
+		for dx := 0; dx < width; dx++ {
+			img.SetGray(x+dx, y+dy, color.Gray{})
+		}
+	}
+}
+
+func isDark(img image.Image, x, y int) bool {
+	return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y < 128
+}
+
+// darkBounds - the smallest rectangle with all the dark pixels
+func darkBounds(img image.Image) (image.Rectangle, bool) {
+	bounds := img.Bounds()
+	result := image.Rectangle{Min: bounds.Max, Max: bounds.Min}
+	found := false
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if isDark(img, x, y) {
+				found = true
+				result.Min.X, result.Min.Y = min(result.Min.X, x), min(result.Min.Y, y)
+				result.Max.X, result.Max.Y = max(result.Max.X, x+1), max(result.Max.Y, y+1)
+			}
+		}
+	}
+	return result, found
+}
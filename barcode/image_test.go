@@ -0,0 +1,52 @@
+package barcode
+
+import (
+	"image"
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRImage_RoundTrip(t *testing.T) {
+	// Arrange
+	code, err := EncodeQR([]byte("sub=42;form=intake;ver=1;page=2/3"))
+	require.NoError(t, err)
+
+	// Act
+	img := code.Image(3)
+	data, err := DecodeQRImage(img)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, (code.Size+8)*3, img.Bounds().Dx())
+	assert.Equal(t, "sub=42;form=intake;ver=1;page=2/3", string(data))
+}
+
+func TestCode128Image_RoundTrip(t *testing.T) {
+	// Arrange
+	code, err := EncodeCode128("sub=42;page=2/3")
+	require.NoError(t, err)
+
+	// Act
+	text, err := DecodeCode128Image(code.Image(2, 30))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "sub=42;page=2/3", text)
+}
+
+func TestDecodeImage_Blank(t *testing.T) {
+	// Arrange
+	img := newWhiteImage(50, 50)
+
+	// Act
+	_, qrErr := DecodeQRImage(img)
+	_, code128Err := DecodeCode128Image(img)
+
+	// Assert
+	assert.ErrorIs(t, qrErr, myerrors.ErrInvalidCode)
+	assert.ErrorIs(t, code128Err, myerrors.ErrInvalidCode)
+	assert.Equal(t, image.Rect(0, 0, 50, 50), img.Bounds())
+}
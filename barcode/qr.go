@@ -0,0 +1,456 @@
+package barcode
+
+import (
+	"fmt"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+)
+
+/* A small QR code encoder - byte mode, error correction level M (15% of the code can be damaged), versions 1 to 10.
+   That is up to 213 bytes, plenty for a tracking code.
+
+   The steps follow ISO/IEC 18004:
+   * the data is written as a bit stream (mode, length, bytes, terminator, padding)
+   * the codewords are split in blocks, every block gets its Reed-Solomon error correction codewords
+   * the blocks are interleaved and drawn in a zigzag over the modules not used by the function patterns
+   * the mask that gives the fewest confusing patterns is applied and the format information is written
+*/
+
+// QRCode - the modules of a QR code. Modules[y][x] is true for a dark module.
+type QRCode struct {
+	Version int
+	Size    int
+	Modules [][]bool
+
+	function [][]bool
+}
+
+// qrBlocks - error correction of a version at level M
+type qrBlocks struct {
+	ecCodewords int
+	// Data codewords of every block - the blocks of the second group have one more
+	group1Blocks, group1Data int
+	group2Blocks, group2Data int
+}
+
+var qrVersions = map[int]qrBlocks{
+	1:  {10, 1, 16, 0, 0},
+	2:  {16, 1, 28, 0, 0},
+	3:  {26, 1, 44, 0, 0},
+	4:  {18, 2, 32, 0, 0},
+	5:  {24, 2, 43, 0, 0},
+	6:  {16, 4, 27, 0, 0},
+	7:  {18, 4, 31, 0, 0},
+	8:  {22, 2, 38, 2, 39},
+	9:  {22, 3, 36, 2, 37},
+	10: {26, 4, 43, 1, 44},
+}
+
+var qrMaxVersion = 10
+
+var qrAlignmentPositions = map[int][]int{
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+// The error correction level bits of M in the format information
+var qrLevelM = 0
+
+var qrByteMode = 0x4
+
+func (b qrBlocks) dataCodewords() int {
+	return b.group1Blocks*b.group1Data + b.group2Blocks*b.group2Data
+}
+
+// EncodeQR - encodes the data in the smallest QR code that fits it
+func EncodeQR(data []byte) (*QRCode, error) {
+	version := 0
+	for v := 1; v <= qrMaxVersion; v++ {
+		if qrDataBits(v, len(data)) <= qrVersions[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%w: %d bytes", myerrors.ErrDataTooLong, len(data))
+	}
+
+	code := newQRCode(version)
+	code.drawFunctionPatterns()
+	code.drawCodewords(qrCodewords(version, data))
+
+	// Keep the mask with the lowest penalty
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		code.applyMask(mask)
+		code.drawFormat(mask)
+		if penalty := code.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		// The mask is its own inverse
+		code.applyMask(mask)
+	}
+	code.applyMask(bestMask)
+	code.drawFormat(bestMask)
+
+	return code, nil
+}
+
+func newQRCode(version int) *QRCode {
+	size := version*4 + 17
+	code := &QRCode{Version: version, Size: size, Modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range code.Modules {
+		code.Modules[y] = make([]bool, size)
+		code.function[y] = make([]bool, size)
+	}
+	return code
+}
+
+// qrDataBits - the bits needed for the data in byte mode
+func qrDataBits(version, length int) int {
+	return 4 + qrCountBits(version) + length*8
+}
+
+// qrCountBits - the length of the byte count grows with the version
+func qrCountBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// qrCodewords - the data and error correction codewords, interleaved
+func qrCodewords(version int, data []byte) []byte {
+	blocks := qrVersions[version]
+	capacity := blocks.dataCodewords()
+
+	bits := &bitBuffer{}
+	bits.append(qrByteMode, 4)
+	bits.append(len(data), qrCountBits(version))
+	for _, value := range data {
+		bits.append(int(value), 8)
+	}
+
+	// Terminator, then padding to a full byte and the pad codewords
+	bits.append(0, min(4, capacity*8-bits.length))
+	bits.append(0, (8-bits.length%8)%8)
+	for pad := 0; bits.length < capacity*8; pad++ {
+		bits.append([]int{0xEC, 0x11}[pad%2], 8)
+	}
+
+	dataBlocks, ecBlocks := splitBlocks(blocks, bits.bytes)
+
+	var result []byte
+	for i := 0; i < blocks.group1Data+1; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < blocks.ecCodewords; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// splitBlocks - splits the data codewords in blocks and computes the error correction of every block
+func splitBlocks(blocks qrBlocks, data []byte) (dataBlocks, ecBlocks [][]byte) {
+	offset := 0
+	for i := 0; i < blocks.group1Blocks+blocks.group2Blocks; i++ {
+		length := blocks.group1Data
+		if i >= blocks.group1Blocks {
+			length = blocks.group2Data
+		}
+		block := data[offset : offset+length]
+		offset += length
+
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsEncode(block, blocks.ecCodewords))
+	}
+	return dataBlocks, ecBlocks
+}
+
+// drawFunctionPatterns - finder, timing and alignment patterns, and the space for the format and version information
+func (q *QRCode) drawFunctionPatterns() {
+	// Timing patterns
+	for i := 0; i < q.Size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns in three corners - with their light separator
+	for _, corner := range [][2]int{{3, 3}, {q.Size - 4, 3}, {3, q.Size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+					continue
+				}
+				distance := max(abs(dx), abs(dy))
+				q.setFunction(x, y, distance != 2 && distance != 4)
+			}
+		}
+	}
+
+	// Alignment patterns, except where they would overlap the finder patterns
+	positions := qrAlignmentPositions[q.Version]
+	for i, cy := range positions {
+		for j, cx := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == len(positions)-1) || (i == len(positions)-1 && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format information - it is written once the mask is known
+	q.drawFormat(0)
+
+	if q.Version >= 7 {
+		q.drawVersion()
+	}
+}
+
+// drawFormat - the error correction level and the mask, twice, protected by a BCH code
+func (q *QRCode) drawFormat(mask int) {
+	bits := qrFormatBits(mask)
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	// Split between the other two finders
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.Size-15+i, bit(i))
+	}
+
+	// Always dark
+	q.setFunction(8, q.Size-8, true)
+}
+
+// qrFormatBits - the 15 format bits: level and mask, the BCH remainder and the fixed XOR pattern
+func qrFormatBits(mask int) int {
+	data := qrLevelM<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// drawVersion - the version, from version 7, in two 6x3 blocks near the top right and bottom left finders
+func (q *QRCode) drawVersion() {
+	remainder := q.Version
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	bits := q.Version<<12 | remainder
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := q.Size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords - places the bits in the zigzag order: two columns at a time, from the right, alternating up and down
+func (q *QRCode) drawCodewords(codewords []byte) {
+	i := 0
+	q.eachDataModule(func(x, y int) {
+		if i < len(codewords)*8 {
+			q.Modules[y][x] = (codewords[i/8]>>(7-i%8))&1 != 0
+			i++
+		}
+	})
+}
+
+// eachDataModule - visits the modules that are not part of a function pattern, in the zigzag order
+func (q *QRCode) eachDataModule(visit func(x, y int)) {
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		// The vertical timing pattern is skipped as a whole column
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vertical := 0; vertical < q.Size; vertical++ {
+			y := vertical
+			if upward {
+				y = q.Size - 1 - vertical
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if !q.function[y][x] {
+					visit(x, y)
+				}
+			}
+		}
+	}
+}
+
+// applyMask - inverts the data modules selected by the mask
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if !q.function[y][x] && qrMaskBit(mask, x, y) {
+				q.Modules[y][x] = !q.Modules[y][x]
+			}
+		}
+	}
+}
+
+func qrMaskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// penalty - scores the patterns that make a code hard to read: long runs, blocks, finder look-alikes and unbalanced color
+func (q *QRCode) penalty() int {
+	penalty := 0
+
+	// Runs of 5 or more modules of the same color, in rows and columns
+	for i := 0; i < q.Size; i++ {
+		rowRun, columnRun := 1, 1
+		for j := 1; j < q.Size; j++ {
+			rowRun = q.runPenalty(&penalty, rowRun, q.Modules[i][j] == q.Modules[i][j-1])
+			columnRun = q.runPenalty(&penalty, columnRun, q.Modules[j][i] == q.Modules[j-1][i])
+		}
+	}
+
+	// 2x2 blocks of the same color
+	for y := 0; y < q.Size-1; y++ {
+		for x := 0; x < q.Size-1; x++ {
+			color := q.Modules[y][x]
+			if color == q.Modules[y][x+1] && color == q.Modules[y+1][x] && color == q.Modules[y+1][x+1] {
+				penalty += 3
+			}
+		}
+	}
+
+	// Patterns that look like a finder: 1011101 with 4 light modules on one side
+	finder := []bool{true, false, true, true, true, false, true, false, false, false, false}
+	for i := 0; i < q.Size; i++ {
+		for j := 0; j+len(finder) <= q.Size; j++ {
+			for _, pattern := range [][]bool{finder, reversed(finder)} {
+				row, column := true, true
+				for k, dark := range pattern {
+					row = row && q.Modules[i][j+k] == dark
+					column = column && q.Modules[j+k][i] == dark
+				}
+				if row {
+					penalty += 40
+				}
+				if column {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	// Every 5% away from half dark modules
+	dark := 0
+	for _, row := range q.Modules {
+		for _, module := range row {
+			if module {
+				dark++
+			}
+		}
+	}
+	total := q.Size * q.Size
+	penalty += abs(dark*20-total*10) / total * 10
+
+	return penalty
+}
+
+// runPenalty - 3 points for a run of 5, one more for every extra module
+func (q *QRCode) runPenalty(penalty *int, run int, same bool) int {
+	if !same {
+		return 1
+	}
+	run++
+	if run == 5 {
+		*penalty += 3
+	} else if run > 5 {
+		*penalty++
+	}
+	return run
+}
+
+func (q *QRCode) setFunction(x, y int, dark bool) {
+	q.Modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// bitBuffer - bits appended most significant first
+type bitBuffer struct {
+	bytes  []byte
+	length int
+}
+
+func (b *bitBuffer) append(value, count int) {
+	for i := count - 1; i >= 0; i-- {
+		if b.length%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if (value>>i)&1 != 0 {
+			b.bytes[len(b.bytes)-1] |= 0x80 >> (b.length % 8)
+		}
+		b.length++
+	}
+}
+
+func reversed(values []bool) []bool {
+	result := make([]bool, len(values))
+	for i, value := range values {
+		result[len(values)-1-i] = value
+	}
+	return result
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
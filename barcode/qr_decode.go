@@ -0,0 +1,161 @@
+package barcode
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+)
+
+/* The decoder reads back what EncodeQR writes - it exists for round-trip tests and scanning tools, so it only knows
+   byte mode at level M and does not correct errors, a damaged code is reported instead.
+*/
+
+// DecodeQR - reads the data of a QR code from its modules. Modules[y][x] is true for a dark module.
+func DecodeQR(modules [][]bool) ([]byte, error) {
+	size := len(modules)
+	version := (size - 17) / 4
+	if version < 1 || version > qrMaxVersion || version*4+17 != size {
+		return nil, fmt.Errorf("%w: unsupported size", myerrors.ErrInvalidCode)
+	}
+
+	code := newQRCode(version)
+	code.drawFunctionPatterns()
+	for y := range modules {
+		if len(modules[y]) != size {
+			return nil, fmt.Errorf("%w: not a square", myerrors.ErrInvalidCode)
+		}
+		copy(code.Modules[y], modules[y])
+	}
+
+	mask, err := code.readFormat()
+	if err != nil {
+		return nil, err
+	}
+	code.applyMask(mask)
+
+	// Read the bits in the order they were drawn
+	blocks := qrVersions[version]
+	total := blocks.dataCodewords() + (blocks.group1Blocks+blocks.group2Blocks)*blocks.ecCodewords
+	codewords := &bitBuffer{}
+	code.eachDataModule(func(x, y int) {
+		if codewords.length < total*8 {
+			codewords.append(boolToInt(code.Modules[y][x]), 1)
+		}
+	})
+
+	data, err := deinterleave(blocks, codewords.bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return readByteSegment(version, data)
+}
+
+// readFormat - the mask from the format information. The closest valid format is used, as the BCH code allows.
+func (q *QRCode) readFormat() (int, error) {
+	var read int
+	bit := func(x, y, i int) {
+		if q.Modules[y][x] {
+			read |= 1 << i
+		}
+	}
+	for i := 0; i <= 5; i++ {
+		bit(8, i, i)
+	}
+	bit(8, 7, 6)
+	bit(8, 8, 7)
+	bit(7, 8, 8)
+	for i := 9; i < 15; i++ {
+		bit(14-i, 8, i)
+	}
+
+	bestMask, bestDistance := -1, 4
+	for mask := 0; mask < 8; mask++ {
+		if distance := bits.OnesCount(uint(read ^ qrFormatBits(mask))); distance < bestDistance {
+			bestMask, bestDistance = mask, distance
+		}
+	}
+	if bestMask < 0 {
+		return 0, fmt.Errorf("%w: unreadable format information", myerrors.ErrInvalidCode)
+	}
+	return bestMask, nil
+}
+
+// deinterleave - rebuilds the blocks and checks their error correction codewords
+func deinterleave(blocks qrBlocks, codewords []byte) ([]byte, error) {
+	count := blocks.group1Blocks + blocks.group2Blocks
+	dataBlocks := make([][]byte, count)
+
+	index := 0
+	for i := 0; i < blocks.group1Data+1; i++ {
+		for b := range dataBlocks {
+			length := blocks.group1Data
+			if b >= blocks.group1Blocks {
+				length = blocks.group2Data
+			}
+			if i < length {
+				dataBlocks[b] = append(dataBlocks[b], codewords[index])
+				index++
+			}
+		}
+	}
+
+	var data []byte
+	for b, block := range dataBlocks {
+		ec := rsEncode(block, blocks.ecCodewords)
+		for i := 0; i < blocks.ecCodewords; i++ {
+			if codewords[index+i*count+b] != ec[i] {
+				return nil, fmt.Errorf("%w: damaged code", myerrors.ErrInvalidCode)
+			}
+		}
+		data = append(data, block...)
+	}
+
+	return data, nil
+}
+
+// readByteSegment - the bytes of the byte mode segment
+func readByteSegment(version int, data []byte) ([]byte, error) {
+	reader := &bitReader{data: data}
+	if reader.read(4) != qrByteMode {
+		return nil, fmt.Errorf("%w: only byte mode is supported", myerrors.ErrInvalidCode)
+	}
+
+	length := reader.read(qrCountBits(version))
+	if 4+qrCountBits(version)+length*8 > len(data)*8 {
+		return nil, fmt.Errorf("%w: length out of range", myerrors.ErrInvalidCode)
+	}
+
+	var result bytes.Buffer
+	for i := 0; i < length; i++ {
+		result.WriteByte(byte(reader.read(8)))
+	}
+	return result.Bytes(), nil
+}
+
+// bitReader - reads bits most significant first
+type bitReader struct {
+	data     []byte
+	position int
+}
+
+func (r *bitReader) read(count int) int {
+	value := 0
+	for i := 0; i < count; i++ {
+		value <<= 1
+		if r.position < len(r.data)*8 && (r.data[r.position/8]>>(7-r.position%8))&1 != 0 {
+			value |= 1
+		}
+		r.position++
+	}
+	return value
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package barcode
+
+import (
+	"strings"
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeQR_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		version int
+	}{
+		{"Short", "hello", 1},
+		{"TrackingCode", "sub=c88eea39a8a2;form=job_application;ver=2;page=1/3", 4},
+		{"VersionInformation", strings.Repeat("x", 150), 8},
+		{"LongestSupported", strings.Repeat("x", 213), 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			code, err := EncodeQR([]byte(tt.data))
+			require.NoError(t, err)
+			decoded, err := DecodeQR(code.Modules)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, code.Version)
+			assert.Equal(t, tt.version*4+17, code.Size)
+			assert.Equal(t, tt.data, string(decoded))
+		})
+	}
+}
+
+func TestEncodeQR_TooLong(t *testing.T) {
+	// Act
+	_, err := EncodeQR([]byte(strings.Repeat("x", 214)))
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrDataTooLong)
+}
+
+func TestEncodeQR_FinderPatterns(t *testing.T) {
+	// Act
+	code, err := EncodeQR([]byte("hello"))
+	require.NoError(t, err)
+
+	// Assert - the corners are dark, the separators light
+	for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+		assert.True(t, code.Modules[corner[1]][corner[0]])
+		assert.True(t, code.Modules[corner[1]+3][corner[0]+3])
+		assert.False(t, code.Modules[corner[1]+1][corner[0]+1])
+	}
+	assert.False(t, code.Modules[7][7])
+}
+
+func TestDecodeQR_DamagedCode(t *testing.T) {
+	// Arrange
+	code, err := EncodeQR([]byte("hello"))
+	require.NoError(t, err)
+
+	// Flip a data module in the bottom right corner
+	code.Modules[code.Size-1][code.Size-1] = !code.Modules[code.Size-1][code.Size-1]
+
+	// Act
+	_, err = DecodeQR(code.Modules)
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrInvalidCode)
+}
+
+func TestDecodeQR_InvalidSize(t *testing.T) {
+	// Act
+	_, err := DecodeQR(make([][]bool, 20))
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrInvalidCode)
+}
+
+func TestRSEncode_KnownValue(t *testing.T) {
+	// The version 1-M example of ISO/IEC 18004 - "01234567"
+	data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+
+	// Act
+	ec := rsEncode(data, 10)
+
+	// Assert
+	assert.Equal(t, []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}, ec)
+}
@@ -0,0 +1,59 @@
+package barcode
+
+// Reed-Solomon error correction over GF(256) with the QR code polynomial x^8 + x^4 + x^3 + x^2 + 1
+var gfExp, gfLog = buildGaloisTables()
+
+func buildGaloisTables() (exp [512]byte, log [256]byte) {
+	value := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(value)
+		log[value] = byte(i)
+		value <<= 1
+		if value&0x100 != 0 {
+			value ^= 0x11D
+		}
+	}
+	// Doubled so the product of two logs can be looked up without the modulo
+	for i := 255; i < 512; i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}
+
+func gfMultiply(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// rsGenerator - the generator polynomial (x - a^0)(x - a^1)...(x - a^(degree-1)), highest power first
+func rsGenerator(degree int) []byte {
+	generator := []byte{1}
+	for i := 0; i < degree; i++ {
+		next := make([]byte, len(generator)+1)
+		for j, coefficient := range generator {
+			next[j] ^= coefficient
+			next[j+1] ^= gfMultiply(coefficient, gfExp[i])
+		}
+		generator = next
+	}
+	return generator
+}
+
+// rsEncode - the error correction codewords of the data: the remainder of data(x) * x^degree / generator(x)
+func rsEncode(data []byte, degree int) []byte {
+	generator := rsGenerator(degree)
+	remainder := make([]byte, degree)
+
+	for _, value := range data {
+		factor := value ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[degree-1] = 0
+		for i := range remainder {
+			remainder[i] ^= gfMultiply(generator[i+1], factor)
+		}
+	}
+
+	return remainder
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// LoadSettings will set the flags that are not on the command line from the environment and the settings file
+func LoadSettings(cmd *cobra.Command, _ []string) error {
+	configPath, err := cmd.Flags().GetString(config.ConfigFlag)
+	if err != nil {
+		return err
+	}
+
+	file, err := config.LoadSettingsFile(configPath, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	if err = file.CheckKeys(settingsFlags(cmd.Root())); err != nil {
+		return err
+	}
+
+	_, err = config.ApplySettings(cmd.Flags(), settingsName(cmd), file, os.LookupEnv)
+	return err
+}
+
+// ConfigShowCommand will print the effective settings of a command. E.g. parser config show serve
+func ConfigShowCommand(cmd *cobra.Command, args []string) {
+	target, _, err := cmd.Root().Find(args)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	configPath, err := cmd.Flags().GetString(config.ConfigFlag)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	handler := handlers.NewConfigShowCommandHandler(os.Stdout, &config.ConfigShowOptions{
+		Command:    settingsName(target),
+		Flags:      target.Flags(),
+		ConfigPath: configPath,
+	})
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// settingsName - the section of the command in the settings file. E.g. serve, diff-forms, parse for the root command
+func settingsName(cmd *cobra.Command) string {
+	if !cmd.HasParent() {
+		return config.ParseCommand
+	}
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// settingsFlags - the flags of the command and its sub commands, by settings section
+func settingsFlags(cmd *cobra.Command) map[string]*pflag.FlagSet {
+	flags := map[string]*pflag.FlagSet{settingsName(cmd): cmd.Flags()}
+	for _, child := range cmd.Commands() {
+		maps.Copy(flags, settingsFlags(child))
+	}
+	return flags
+}
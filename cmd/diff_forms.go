@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+	"github.com/spf13/cobra"
+)
+
+// DiffFormsCommand will report the structural changes between two versions of a form
+func DiffFormsCommand(cmd *cobra.Command, args []string) {
+	conf, err := readDiffFormsOptions(cmd, args)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	renderer, err := render.GetFormDiffRenderer(conf.ToType, conf.OldFileName, conf.NewFileName, conf.OutputDir, os.Stdout)
+	if err != nil {
+		logging.Log.Errorf("Error creating renderer: %v", err)
+		os.Exit(1)
+	}
+
+	handler := handlers.NewDiffFormsCommandHandler(&reader.FileReader{}, parse, renderer, conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// readDiffFormsOptions - gather the inputs of the command. The arguments are the old and the new form.
+func readDiffFormsOptions(cmd *cobra.Command, args []string) (*config.DiffFormsOptions, error) {
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	toFormat, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return nil, err
+	}
+
+	outputFolder, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.DiffFormsOptions{
+		OldFileName: args[0],
+		NewFileName: args[1],
+		OutputDir:   outputFolder,
+		FromType:    models.SafeReadFileFormat(fromFormat),
+		ToType:      models.SafeReadFileFormat(toFormat),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+	"github.com/spf13/cobra"
+)
+
+// DiffSubmissionsCommand will render the differences between two submissions of the same form
+func DiffSubmissionsCommand(cmd *cobra.Command, args []string) {
+	conf, err := readDiffSubmissionsOptions(cmd, args)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	renderer, err := render.GetDiffRenderer(conf.ToType, conf.Filename, conf.OutputDir, conf.Render)
+	if err != nil {
+		logging.Log.Errorf("Error creating renderer: %v", err)
+		os.Exit(1)
+	}
+
+	handler := handlers.NewDiffSubmissionsCommandHandler(&reader.FileReader{}, parse, renderer, conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// readDiffSubmissionsOptions - gather the inputs of the command. The arguments are the old and the new submission.
+func readDiffSubmissionsOptions(cmd *cobra.Command, args []string) (*config.DiffSubmissionsOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	toFormat, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return nil, err
+	}
+
+	outputFolder, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return nil, err
+	}
+
+	changedOnly, err := cmd.Flags().GetBool("changed-only")
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return nil, err
+	}
+
+	redaction, err := config.ReadRedactionProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.DiffSubmissionsOptions{
+		Filename:              filePath,
+		OldSubmissionFileName: args[0],
+		NewSubmissionFileName: args[1],
+		OutputDir:             outputFolder,
+		FromType:              models.SafeReadFileFormat(fromFormat),
+		ToType:                models.SafeReadFileFormat(toFormat),
+		Render: config.DiffRenderOptions{
+			ChangedOnly: changedOnly,
+			Redaction:   redaction,
+		},
+	}, nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+	"github.com/spf13/cobra"
+)
+
+// FillCommand will ask for the answers of a form in the terminal and save them to a submission file
+func FillCommand(cmd *cobra.Command, _ []string) {
+	conf, err := readFillOptions(cmd)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	var renderer render.Renderer
+	if conf.PDFDir != "" {
+		renderer, err = render.GetRenderer(models.PDFFileType, conf.Filename, conf.PDFDir, conf.Render)
+		if err != nil {
+			logging.Log.Errorf("Error creating renderer: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	handler := handlers.NewFillCommandHandler(&reader.FileReader{}, parse, renderer, os.Stdin, os.Stdout, conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// readFillOptions - gather the inputs of the command
+func readFillOptions(cmd *cobra.Command) (*config.FillOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return nil, err
+	}
+
+	resume, err := cmd.Flags().GetBool("resume")
+	if err != nil {
+		return nil, err
+	}
+
+	pdfDir, err := cmd.Flags().GetString("pdf")
+	if err != nil {
+		return nil, err
+	}
+
+	renderOptions, err := readRenderOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.FillOptions{
+		Filename: filePath,
+		OutPath:  outPath,
+		Resume:   resume,
+		PDFDir:   pdfDir,
+		FromType: models.SafeReadFileFormat(fromFormat),
+		Render:   renderOptions,
+	}, nil
+}
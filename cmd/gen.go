@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/alex-pricope/form-parser/codegen"
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/spf13/cobra"
+)
+
+// GenCommand will generate typed Go or TypeScript code for the submissions of a form
+func GenCommand(cmd *cobra.Command, _ []string) {
+	conf, err := readGenOptions(cmd)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	generator, err := codegen.GetGenerator(conf.Language)
+	if err != nil {
+		logging.Log.Errorf("Error creating generator: %v", err)
+		os.Exit(1)
+	}
+
+	var writer io.Writer = os.Stdout
+	if conf.OutPath != "" {
+		file, err := os.Create(conf.OutPath)
+		if err != nil {
+			logging.Log.Errorf("Error creating output file: %v", err)
+			os.Exit(1)
+		}
+		defer func() { _ = file.Close() }()
+		writer = file
+	}
+
+	handler := handlers.NewGenCommandHandler(&reader.FileReader{}, parse, generator, writer, conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// readGenOptions - gather the inputs of the command
+func readGenOptions(cmd *cobra.Command) (*config.GenOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	language, err := cmd.Flags().GetString("lang")
+	if err != nil {
+		return nil, err
+	}
+
+	packageName, err := cmd.Flags().GetString("package")
+	if err != nil {
+		return nil, err
+	}
+
+	typeName, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return nil, err
+	}
+
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.GenOptions{
+		Filename: filePath,
+		Language: models.SafeReadLanguage(language),
+		Package:  packageName,
+		TypeName: typeName,
+		OutPath:  outPath,
+		FromType: models.SafeReadFileFormat(fromFormat),
+	}, nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/spf13/cobra"
+)
+
+// InitSubmissionCommand will write a submission template with a value for every field of a form
+func InitSubmissionCommand(cmd *cobra.Command, _ []string) {
+	conf, err := readInitSubmissionOptions(cmd)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	handler := handlers.NewInitSubmissionCommandHandler(&reader.FileReader{}, parse, os.Stdout, conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// readInitSubmissionOptions - gather the inputs of the command. A --seed makes the submission random.
+func readInitSubmissionOptions(cmd *cobra.Command) (*config.InitSubmissionOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	requiredOnly, err := cmd.Flags().GetBool("required-only")
+	if err != nil {
+		return nil, err
+	}
+
+	random, err := cmd.Flags().GetBool("random")
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := cmd.Flags().GetInt64("seed")
+	if err != nil {
+		return nil, err
+	}
+
+	// The output goes to the standard output, so the seed is not picked at random - the fixtures stay reproducible
+	if cmd.Flags().Changed("seed") {
+		random = true
+	}
+
+	return &config.InitSubmissionOptions{
+		Filename:     filePath,
+		RequiredOnly: requiredOnly,
+		Random:       random,
+		Seed:         seed,
+		FromType:     models.SafeReadFileFormat(fromFormat),
+	}, nil
+}
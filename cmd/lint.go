@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+	"github.com/spf13/cobra"
+)
+
+// LintCommand will check a form file for the recurring mistakes of the rule catalogue
+func LintCommand(cmd *cobra.Command, args []string) {
+	conf, err := readLintOptions(cmd, args)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	renderer, err := render.GetLintRenderer(conf.ToType, conf.Filename, os.Stdout)
+	if err != nil {
+		logging.Log.Errorf("Error creating renderer: %v", err)
+		os.Exit(1)
+	}
+
+	// The problems are already rendered - the exit code lets the CI fail on lint errors
+	handler := handlers.NewLintCommandHandler(&reader.FileReader{}, renderer, conf)
+	err = handler.Handle()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// readLintOptions - gather the inputs of the command. The argument is the form file.
+func readLintOptions(cmd *cobra.Command, args []string) (*config.LintOptions, error) {
+	toFormat, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return nil, err
+	}
+
+	configPath, err := cmd.Flags().GetString("lint-config")
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.LintOptions{
+		Filename:       args[0],
+		ConfigFileName: configPath,
+		ToType:         models.SafeReadFileFormat(toFormat),
+	}, nil
+}
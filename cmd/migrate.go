@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/spf13/cobra"
+)
+
+// MigrateCommand will move submissions to a new version of their form
+func MigrateCommand(cmd *cobra.Command, args []string) {
+	conf, err := readMigrateOptions(cmd, args)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	// The exit code lets the batch scripts check that every submission was migrated
+	handler := handlers.NewMigrateCommandHandler(&reader.FileReader{}, parse, conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// readMigrateOptions - gather the inputs of the command. The arguments are submission files or folders.
+func readMigrateOptions(cmd *cobra.Command, args []string) (*config.MigrateOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	migrationPath, err := cmd.Flags().GetString("migration")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	outputFolder, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return nil, err
+	}
+
+	submissionFiles, err := reader.ListSubmissionFiles(args, handlers.MigratedFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.MigrateOptions{
+		Filename:            filePath,
+		MigrationFileName:   migrationPath,
+		SubmissionFileNames: submissionFiles,
+		OutputDir:           outputFolder,
+		FromType:            models.SafeReadFileFormat(fromFormat),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/alex-pricope/form-parser/config"
 	"github.com/alex-pricope/form-parser/handlers"
 	"github.com/alex-pricope/form-parser/logging"
@@ -9,6 +10,7 @@ import (
 	"github.com/alex-pricope/form-parser/reader"
 	"github.com/alex-pricope/form-parser/render"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 // ParseCommand will parse the file and generate the output
@@ -17,6 +19,7 @@ func ParseCommand(cmd *cobra.Command, _ []string) {
 	conf, err := readCommandOptions(cmd)
 	if err != nil {
 		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		return
 	}
 
 	parse, err := parsers.GetParser(conf.FromType)
@@ -25,7 +28,7 @@ func ParseCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
-	renderer, err := render.GetRenderer(conf.ToType, conf.Filename, conf.OutputDir)
+	renderer, err := render.GetRenderer(conf.ToType, conf.Filename, conf.OutputDir, conf.Render)
 	if err != nil {
 		logging.Log.Errorf("Error creating renderer: %v", err)
 		return
@@ -58,6 +61,11 @@ func readCommandOptions(cmd *cobra.Command) (*config.CommandOptions, error) {
 		return nil, err
 	}
 
+	blank, err := cmd.Flags().GetBool("blank")
+	if err != nil {
+		return nil, err
+	}
+
 	fromFormat, err := cmd.Flags().GetString("from")
 	if err != nil {
 		return nil, err
@@ -73,11 +81,298 @@ func readCommandOptions(cmd *cobra.Command) (*config.CommandOptions, error) {
 		return nil, err
 	}
 
+	attachmentsDir, err := cmd.Flags().GetString("attachments-dir")
+	if err != nil {
+		return nil, err
+	}
+
+	renderOptions, err := readRenderOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	renderOptions.Blank = blank
+	renderOptions.AttachmentsDir = attachmentsDir
+
 	return &config.CommandOptions{
 		Filename:           filePath,
 		SubmissionFileName: submissionFilePath,
 		OutputDir:          outputFolder,
 		FromType:           models.SafeReadFileFormat(fromFormat),
 		ToType:             models.SafeReadFileFormat(toFormat),
+		Render:             renderOptions,
+	}, nil
+}
+
+// readRenderOptions - gather the render inputs, shared by the commands that render a PDF
+func readRenderOptions(cmd *cobra.Command) (config.RenderOptions, error) {
+	summary, err := cmd.Flags().GetBool("summary")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	tableOfContents, err := cmd.Flags().GetBool("toc")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	numbering, err := cmd.Flags().GetString("numbering")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	sectionNumbering := models.SafeReadNumberingScheme(numbering)
+	if sectionNumbering == models.UnknownNumberingScheme {
+		return config.RenderOptions{}, fmt.Errorf("unknown numbering scheme: %s", numbering)
+	}
+
+	order, err := cmd.Flags().GetString("option-order")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	optionOrder := models.SafeReadOptionOrder(order)
+	if optionOrder == models.UnknownOptionOrder {
+		return config.RenderOptions{}, fmt.Errorf("unknown option order: %s", order)
+	}
+
+	numberFields, err := cmd.Flags().GetBool("number-fields")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	indent, err := cmd.Flags().GetFloat64("indent")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	fontStep, err := cmd.Flags().GetFloat64("font-step")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	protection, err := readProtectionOptions(cmd)
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	signing, err := readSigningOptions(cmd)
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	watermark, err := readWatermarkOptions(cmd)
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	metadata, err := readMetadataOptions(cmd)
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	stamp, err := readStampOptions(cmd)
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	profile, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	redaction, err := config.ReadRedactionProfile(profile)
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	reproducible, err := cmd.Flags().GetBool("reproducible")
+	if err != nil {
+		return config.RenderOptions{}, err
+	}
+
+	var sourceDate time.Time
+	if reproducible {
+		sourceDate, err = config.ReadSourceDate()
+		if err != nil {
+			return config.RenderOptions{}, err
+		}
+	}
+
+	return config.RenderOptions{
+		TableOfContents:  tableOfContents,
+		Summary:          summary,
+		SectionNumbering: sectionNumbering,
+		NumberFields:     numberFields,
+		OptionOrder:      optionOrder,
+		IndentPerDepth:   indent,
+		FontSizeStep:     fontStep,
+		Protection:       protection,
+		Signing:          signing,
+		Watermark:        watermark,
+		Metadata:         metadata,
+		Stamp:            stamp,
+		Redaction:        redaction,
+		Reproducible:     reproducible,
+		SourceDate:       sourceDate,
 	}, nil
 }
+
+// readProtectionOptions - gather the password protection inputs. The passwords can point to env:NAME or file:PATH
+func readProtectionOptions(cmd *cobra.Command) (config.ProtectionOptions, error) {
+	var protection config.ProtectionOptions
+
+	ownerPassword, err := cmd.Flags().GetString("owner-password")
+	if err != nil {
+		return protection, err
+	}
+
+	protection.OwnerPassword, err = config.ResolveSecret(ownerPassword)
+	if err != nil {
+		return protection, fmt.Errorf("owner password: %w", err)
+	}
+
+	userPassword, err := cmd.Flags().GetString("user-password")
+	if err != nil {
+		return protection, err
+	}
+
+	protection.UserPassword, err = config.ResolveSecret(userPassword)
+	if err != nil {
+		return protection, fmt.Errorf("user password: %w", err)
+	}
+
+	protection.AllowPrint, err = cmd.Flags().GetBool("allow-print")
+	if err != nil {
+		return protection, err
+	}
+
+	protection.AllowCopy, err = cmd.Flags().GetBool("allow-copy")
+	if err != nil {
+		return protection, err
+	}
+
+	protection.AllowModify, err = cmd.Flags().GetBool("allow-modify")
+	if err != nil {
+		return protection, err
+	}
+
+	return protection, nil
+}
+
+// readSigningOptions - gather the signing inputs. The password can point to env:NAME or file:PATH
+func readSigningOptions(cmd *cobra.Command) (config.SigningOptions, error) {
+	var signing config.SigningOptions
+
+	var err error
+	signing.CertificatePath, err = cmd.Flags().GetString("sign-cert")
+	if err != nil {
+		return signing, err
+	}
+
+	signing.KeyPath, err = cmd.Flags().GetString("sign-key")
+	if err != nil {
+		return signing, err
+	}
+
+	password, err := cmd.Flags().GetString("sign-password")
+	if err != nil {
+		return signing, err
+	}
+
+	signing.Password, err = config.ResolveSecret(password)
+	if err != nil {
+		return signing, fmt.Errorf("signing password: %w", err)
+	}
+
+	return signing, nil
+}
+
+// readWatermarkOptions - gather the watermark inputs
+func readWatermarkOptions(cmd *cobra.Command) (config.WatermarkOptions, error) {
+	var watermark config.WatermarkOptions
+
+	policy, err := cmd.Flags().GetString("watermark")
+	if err != nil {
+		return watermark, err
+	}
+
+	watermark.Policy = models.SafeReadWatermarkPolicy(policy)
+	if watermark.Policy == models.UnknownWatermarkPolicy {
+		return watermark, fmt.Errorf("unknown watermark policy: %s", policy)
+	}
+
+	watermark.Text, err = cmd.Flags().GetString("watermark-text")
+	if err != nil {
+		return watermark, err
+	}
+
+	watermark.Opacity, err = cmd.Flags().GetFloat64("watermark-opacity")
+	if err != nil {
+		return watermark, err
+	}
+
+	if watermark.Opacity <= 0 || watermark.Opacity > 1 {
+		return watermark, fmt.Errorf("watermark opacity must be between 0 and 1: %v", watermark.Opacity)
+	}
+
+	return watermark, nil
+}
+
+// readMetadataOptions - gather the document metadata inputs
+func readMetadataOptions(cmd *cobra.Command) (config.MetadataOptions, error) {
+	var metadata config.MetadataOptions
+
+	var err error
+	metadata.AuthorFields, err = cmd.Flags().GetStringSlice("author-fields")
+	if err != nil {
+		return metadata, err
+	}
+
+	metadata.Properties, err = cmd.Flags().GetStringToString("property")
+	if err != nil {
+		return metadata, err
+	}
+
+	return metadata, metadata.Validate()
+}
+
+// readStampOptions - gather the tracking code inputs
+func readStampOptions(cmd *cobra.Command) (config.StampOptions, error) {
+	var stamp config.StampOptions
+
+	stampType, err := cmd.Flags().GetString("stamp")
+	if err != nil {
+		return stamp, err
+	}
+
+	stamp.Type = models.SafeReadStampType(stampType)
+	if stamp.Type == models.UnknownStampType {
+		return stamp, fmt.Errorf("unknown stamp type: %s", stampType)
+	}
+
+	position, err := cmd.Flags().GetString("stamp-position")
+	if err != nil {
+		return stamp, err
+	}
+
+	stamp.Position = models.SafeReadStampPosition(position)
+	if stamp.Position == models.UnknownStampPosition {
+		return stamp, fmt.Errorf("unknown stamp position: %s", position)
+	}
+
+	stamp.Size, err = cmd.Flags().GetFloat64("stamp-size")
+	if err != nil {
+		return stamp, err
+	}
+
+	if stamp.Size <= 0 {
+		return stamp, fmt.Errorf("stamp size must be positive: %v", stamp.Size)
+	}
+
+	stamp.SubmissionIDField, err = cmd.Flags().GetString("stamp-id-field")
+	if err != nil {
+		return stamp, err
+	}
+
+	return stamp, nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/spf13/cobra"
+)
+
+// SchemaCommand will write the JSON Schema of the submissions of a form, or validate a submission against it
+func SchemaCommand(cmd *cobra.Command, _ []string) {
+	conf, err := readSchemaOptions(cmd)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	// The handler logs the details - the exit code lets scripts check the submission
+	handler := handlers.NewSchemaCommandHandler(&reader.FileReader{}, parse, os.Stdout, conf)
+	err = handler.Handle()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// readSchemaOptions - gather the inputs of the command
+func readSchemaOptions(cmd *cobra.Command) (*config.SchemaOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	layoutName, err := cmd.Flags().GetString("layout")
+	if err != nil {
+		return nil, err
+	}
+
+	layout := models.SafeReadSchemaLayout(layoutName)
+	if layout == models.UnknownSchemaLayout {
+		return nil, fmt.Errorf("unknown schema layout: %s", layoutName)
+	}
+
+	submissionPath, err := cmd.Flags().GetString("validate")
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.SchemaOptions{
+		Filename:           filePath,
+		SubmissionFileName: submissionPath,
+		Layout:             layout,
+		FromType:           models.SafeReadFileFormat(fromFormat),
+	}, nil
+}
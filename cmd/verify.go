@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// VerifyCommand will check the signature of a PDF file generated by the parser
+func VerifyCommand(cmd *cobra.Command, args []string) {
+	trustedCerts, _ := cmd.Flags().GetString("trusted-certs")
+	options := &config.VerifyOptions{Filename: args[0], TrustedCertsPath: trustedCerts}
+	handler := handlers.NewVerifySignatureCommandHandler(&reader.FileReader{}, options)
+
+	// The handler logs the details - the exit code lets scripts check the result
+	err := handler.Handle()
+	if err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/handlers"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+	"github.com/alex-pricope/form-parser/webform"
+	"github.com/spf13/cobra"
+)
+
+// WebformCommand will write a fillable HTML form of a form file
+func WebformCommand(cmd *cobra.Command, _ []string) {
+	conf, err := readWebformOptions(cmd)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	handler := handlers.NewWebformCommandHandler(&reader.FileReader{}, parse, os.Stdout, conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// ServeCommand will serve the HTML form of a form file and save the posted submissions with their PDF
+func ServeCommand(cmd *cobra.Command, _ []string) {
+	conf, err := readServeOptions(cmd)
+	if err != nil {
+		logging.Log.Errorf("Error while reading command parameter: %v", err)
+		os.Exit(1)
+	}
+
+	parse, err := parsers.GetParser(conf.FromType)
+	if err != nil {
+		logging.Log.Errorf("Error creating parser: %v", err)
+		os.Exit(1)
+	}
+
+	// Every submission gets its own PDF, with its uploaded files embedded
+	newRenderer := func(name, dir, attachmentsDir string) (render.Renderer, error) {
+		options := conf.Render
+		options.AttachmentsDir = attachmentsDir
+		return render.GetRenderer(models.PDFFileType, name, dir, options)
+	}
+
+	handler := handlers.NewServeCommandHandler(&reader.FileReader{}, parse, webform.RendererFactory(newRenderer), conf)
+	err = handler.Handle()
+	if err != nil {
+		logging.Log.Errorf("Error while executing command: %v", err)
+		os.Exit(1)
+	}
+}
+
+// readWebformOptions - gather the inputs of the webform command
+func readWebformOptions(cmd *cobra.Command) (*config.WebformOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := cmd.Flags().GetString("action")
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.WebformOptions{
+		Filename: filePath,
+		Action:   action,
+		FromType: models.SafeReadFileFormat(fromFormat),
+	}, nil
+}
+
+// readServeOptions - gather the inputs of the serve command
+func readServeOptions(cmd *cobra.Command) (*config.ServeOptions, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFormat, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := cmd.Flags().GetString("addr")
+	if err != nil {
+		return nil, err
+	}
+
+	outputFolder, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return nil, err
+	}
+
+	renderOptions, err := readRenderOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.ServeOptions{
+		Filename:  filePath,
+		Address:   address,
+		OutputDir: outputFolder,
+		Render:    renderOptions,
+		FromType:  models.SafeReadFileFormat(fromFormat),
+	}, nil
+}
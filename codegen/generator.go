@@ -0,0 +1,219 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// Generator - generic interface that the code generators of the languages implement
+type Generator interface {
+	// Generate - the code of the submissions of the form
+	Generate(form *models.ContentNode, options Options) ([]byte, error)
+}
+
+// GetGenerator - Factory method that creates the code generator based on the language
+func GetGenerator(language models.Language) (Generator, error) {
+	switch language {
+	case models.GoLanguage:
+		return &GoGenerator{}, nil
+	case models.TypeScriptLanguage:
+		return &TypeScriptGenerator{}, nil
+
+	default:
+		return nil, fmt.Errorf("unimplemented code generator language: %s", language)
+	}
+}
+
+var templateFunctions = template.FuncMap{
+	"quote":   func(value string) string { return fmt.Sprintf("%q", value) },
+	"comment": comment,
+	// docComment - a comment text that can not close the /** */ block
+	"docComment": func(value string) string { return strings.ReplaceAll(comment(value), "*/", "*\\/") },
+	"tsName": func(name string) string {
+		if tsIdentifierPattern.MatchString(name) {
+			return name
+		}
+		return fmt.Sprintf("%q", name)
+	},
+	"tsAccess": func(name string) string {
+		if tsIdentifierPattern.MatchString(name) {
+			return "s." + name
+		}
+		return fmt.Sprintf("s[%q]", name)
+	},
+	"usesFmt": func(model *submissionModel) bool {
+		for _, field := range model.Fields {
+			if field.Enum != nil || field.HasLength {
+				return true
+			}
+		}
+		return false
+	},
+	"usesUTF8": func(model *submissionModel) bool {
+		for _, field := range model.Fields {
+			if field.HasLength {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// comment - the text on one line
+func comment(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// GoGenerator - a struct with JSON tags, the enums as constants and a Validate method
+type GoGenerator struct{}
+
+var goTemplate = template.Must(template.New("go").Funcs(templateFunctions).Parse(`// Code generated by form-parser gen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"errors"
+{{- if usesFmt .}}
+	"fmt"
+{{- end}}
+{{- if usesUTF8 .}}
+	"unicode/utf8"
+{{- end}}
+)
+
+// {{.TypeName}} - a submission of the {{.Source}} form
+type {{.TypeName}} struct {
+{{- range .Fields}}
+{{- if .Caption}}
+	// {{.Ident}} - {{comment .Caption}}
+{{- end}}
+	{{.Ident}} {{if .Enum}}{{.Enum.TypeName}}{{else}}string{{end}} ` + "`" + `json:"{{.Name}},omitempty"` + "`" + `
+{{- end}}
+}
+{{range .Enums}}
+{{- $enum := .}}
+// {{.TypeName}} - the options of the field
+type {{.TypeName}} string
+
+const (
+{{- range .Members}}
+	{{.Ident}} {{$enum.TypeName}} = {{quote .Value}}{{if .Label}} // {{comment .Label}}{{end}}
+{{- end}}
+)
+{{end}}
+// Validate - checks the answers like the form: the required fields, the options and the lengths
+func (s *{{.TypeName}}) Validate() error {
+	var errs []error
+{{- range .Fields}}
+{{- if .Required}}
+	if s.{{.Ident}} == "" {
+		errs = append(errs, errors.New({{quote (print .Name " is required")}}))
+	}
+{{- end}}
+{{- if .Enum}}
+	switch s.{{.Ident}} {
+	case ""{{range .Enum.Members}}, {{.Ident}}{{end}}:
+	default:
+		errs = append(errs, fmt.Errorf("%s: unknown option %q", {{quote .Name}}, s.{{.Ident}}))
+	}
+{{- end}}
+{{- if .HasLength}}
+	if n := utf8.RuneCountInString(s.{{.Ident}}); s.{{.Ident}} != "" && {{if .MinLength}}(n < {{.MinLength}} || n > {{.MaxLength}}){{else}}n > {{.MaxLength}}{{end}} {
+		errs = append(errs, fmt.Errorf("%s: %d characters, expected %d to %d", {{quote .Name}}, n, {{.MinLength}}, {{.MaxLength}}))
+	}
+{{- end}}
+{{- end}}
+{{- range .Dependencies}}
+{{- $field := .Field}}
+	if s.{{.Field.Ident}} != "" {
+{{- range .Requires}}
+		if s.{{.Ident}} == "" {
+			errs = append(errs, errors.New({{quote (print .Name " is required when " $field.Name " is answered")}}))
+		}
+{{- end}}
+	}
+{{- end}}
+	return errors.Join(errs...)
+}
+`))
+
+func (g *GoGenerator) Generate(form *models.ContentNode, options Options) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := goTemplate.Execute(&buffer, newSubmissionModel(form, options)); err != nil {
+		return nil, err
+	}
+	return format.Source(buffer.Bytes())
+}
+
+// TypeScriptGenerator - an interface, the enums as const objects and a validate function
+type TypeScriptGenerator struct{}
+
+var typeScriptTemplate = template.Must(template.New("typescript").Funcs(templateFunctions).Parse(`// Code generated by form-parser gen from {{.Source}}. DO NOT EDIT.
+{{range .Enums}}
+/** The options of the field */
+export const {{.TypeName}} = {
+{{- range .Members}}
+  {{.Key}}: {{quote .Value}},{{if .Label}} // {{comment .Label}}{{end}}
+{{- end}}
+} as const;
+export type {{.TypeName}} = (typeof {{.TypeName}})[keyof typeof {{.TypeName}}];
+{{end}}
+/** A submission of the {{.Source}} form */
+export interface {{.TypeName}} {
+{{- range .Fields}}
+{{- if .Caption}}
+  /** {{docComment .Caption}} */
+{{- end}}
+  {{tsName .Name}}{{if not .Required}}?{{end}}: {{if .Enum}}{{.Enum.TypeName}}{{else}}string{{end}};
+{{- end}}
+}
+
+/** Checks the answers like the form: the required fields, the options and the lengths. Returns the problems. */
+export function validate{{.TypeName}}(s: Partial<{{.TypeName}}>): string[] {
+  const errors: string[] = [];
+{{- range .Fields}}
+{{- if .Required}}
+  if (!{{tsAccess .Name}}) {
+    errors.push({{quote (print .Name " is required")}});
+  }
+{{- end}}
+{{- if .Enum}}
+  if ({{tsAccess .Name}} && !(Object.values({{.Enum.TypeName}}) as string[]).includes({{tsAccess .Name}})) {
+    errors.push({{quote (print .Name ": unknown option ")}} + JSON.stringify({{tsAccess .Name}}));
+  }
+{{- end}}
+{{- if .HasLength}}
+  if ({{tsAccess .Name}} && {{if .MinLength}}([...{{tsAccess .Name}}].length < {{.MinLength}} || [...{{tsAccess .Name}}].length > {{.MaxLength}}){{else}}[...{{tsAccess .Name}}].length > {{.MaxLength}}{{end}}) {
+    errors.push({{quote (print .Name ": ")}} + [...{{tsAccess .Name}}].length + " characters, expected {{.MinLength}} to {{.MaxLength}}");
+  }
+{{- end}}
+{{- end}}
+{{- range .Dependencies}}
+{{- $field := .Field}}
+  if ({{tsAccess .Field.Name}}) {
+{{- range .Requires}}
+    if (!{{tsAccess .Name}}) {
+      errors.push({{quote (print .Name " is required when " $field.Name " is answered")}});
+    }
+{{- end}}
+  }
+{{- end}}
+  return errors;
+}
+`))
+
+func (g *TypeScriptGenerator) Generate(form *models.ContentNode, options Options) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := typeScriptTemplate.Execute(&buffer, newSubmissionModel(form, options)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,143 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readForm(t *testing.T, fileName string) *models.ContentNode {
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	form, err := (&parsers.XMLParser{}).Parse(content)
+	require.NoError(t, err)
+	return form
+}
+
+func TestGetGenerator(t *testing.T) {
+	tests := []struct {
+		language models.Language
+		expected Generator
+	}{
+		{models.GoLanguage, &GoGenerator{}},
+		{models.TypeScriptLanguage, &TypeScriptGenerator{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.language), func(t *testing.T) {
+			generator, err := GetGenerator(tt.language)
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, generator)
+		})
+	}
+
+	_, err := GetGenerator(models.UnknownLanguage)
+	assert.EqualError(t, err, "unimplemented code generator language: unknown")
+}
+
+func TestGoGenerator_Compiles(t *testing.T) {
+	// Arrange
+	form := readForm(t, "../tests/payload/complex_valid_xml")
+
+	// Act
+	code, err := (&GoGenerator{}).Generate(form, Options{Source: "complex_valid_xml", Package: "forms", TypeName: "Application"})
+	require.NoError(t, err)
+
+	// Assert - the generated code type checks
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "application_gen.go", code, parser.ParseComments)
+	require.NoError(t, err)
+	checker := types.Config{Importer: importer.ForCompiler(fileSet, "source", nil)}
+	pkg, err := checker.Check("forms", fileSet, []*ast.File{file}, nil)
+	require.NoError(t, err)
+
+	assert.NotNil(t, pkg.Scope().Lookup("Application"))
+	assert.NotNil(t, pkg.Scope().Lookup("GenderF"))
+	assert.Contains(t, string(code), "// Code generated by form-parser gen from complex_valid_xml. DO NOT EDIT.")
+	assert.Contains(t, string(code), "UserName string `json:\"user_name,omitempty\"`")
+	assert.Contains(t, string(code), "Gender Gender `json:\"gender,omitempty\"`")
+	assert.Contains(t, string(code), `errors.New("street is required when region is answered")`)
+	assert.Contains(t, string(code), "n > 100")
+	assert.Contains(t, string(code), `fmt.Errorf("%s: unknown option %q", "gender", s.Gender)`)
+}
+
+func TestGoGenerator_Deterministic(t *testing.T) {
+	// Arrange
+	form := readForm(t, "../tests/payload/complex_valid_xml")
+	options := Options{Source: "complex_valid_xml", Package: "forms", TypeName: "Submission"}
+
+	// Act
+	first, err := (&GoGenerator{}).Generate(form, options)
+	require.NoError(t, err)
+	second, err := (&GoGenerator{}).Generate(form, options)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first, second)
+}
+
+func TestTypeScriptGenerator(t *testing.T) {
+	// Arrange
+	form := readForm(t, "../tests/payload/complex_valid_xml")
+
+	// Act
+	code, err := (&TypeScriptGenerator{}).Generate(form, Options{Source: "complex_valid_xml", TypeName: "Submission"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "export const Gender = {\n  M: \"M\", // Male\n")
+	assert.Contains(t, string(code), "  user_name: string;\n")
+	assert.Contains(t, string(code), "  gender?: Gender;\n")
+	assert.Contains(t, string(code), "export function validateSubmission(s: Partial<Submission>): string[] {")
+	assert.Contains(t, string(code), `errors.push("country is required when region is answered");`)
+	assert.Contains(t, string(code), `errors.push("gender: unknown option " + JSON.stringify(s.gender));`)
+}
+
+func TestNewEnumModel_UniqueKeys(t *testing.T) {
+	// Act
+	enum := newEnumModel(newIdentifiers(), "Language", nil, []string{"C", "C#", "C++"})
+
+	// Assert
+	var keys, idents []string
+	for _, member := range enum.Members {
+		keys = append(keys, member.Key)
+		idents = append(idents, member.Ident)
+	}
+	assert.Equal(t, []string{"C", "C2", "C3"}, keys)
+	assert.Equal(t, []string{"LanguageC", "LanguageC2", "LanguageC3"}, idents)
+}
+
+func TestDocComment_EscapesTheEnd(t *testing.T) {
+	// Act
+	text := templateFunctions["docComment"].(func(string) string)("Select */ all\n that apply")
+
+	// Assert
+	assert.Equal(t, "Select *\\/ all that apply", text)
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"user_name", "UserName"},
+		{"country-region", "CountryRegion"},
+		{"1st_choice", "F1stChoice"},
+		{"C#", "C"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, identifier(tt.name))
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/schema"
+)
+
+// Options - the names used in the generated code
+type Options struct {
+	// Source is the form file, only its base name is written so the output does not depend on the folder
+	Source string
+	// Package of the Go code
+	Package string
+	// TypeName of the submission struct or interface
+	TypeName string
+}
+
+var validateMethod = "Validate"
+
+// submissionModel - what the templates need, in the order of the form
+type submissionModel struct {
+	Source       string
+	Package      string
+	TypeName     string
+	Fields       []*fieldModel
+	Enums        []*enumModel
+	Dependencies []dependencyModel
+}
+
+type fieldModel struct {
+	Name      string
+	Ident     string
+	Caption   string
+	Required  bool
+	Enum      *enumModel
+	MinLength int
+	MaxLength int
+	HasLength bool
+}
+
+type enumModel struct {
+	TypeName string
+	Members  []memberModel
+}
+
+type memberModel struct {
+	// Key of the member inside the enum, Ident is the package level name. E.g. A and ProgramLanguageA
+	Key   string
+	Ident string
+	Value string
+	Label string
+}
+
+// dependencyModel - the required fields of an optional section, once one of its fields is answered
+type dependencyModel struct {
+	Field    *fieldModel
+	Requires []*fieldModel
+}
+
+/* The rules come from the flat JSON Schema of the form, so the generated validation matches it:
+   the required fields, the dependent required fields of the optional sections, the enums and the lengths.
+   The names come from the form, in document order - the same form always gives the same code.
+*/
+
+func newSubmissionModel(form *models.ContentNode, options Options) *submissionModel {
+	model := &submissionModel{
+		Source:   filepath.Base(options.Source),
+		Package:  options.Package,
+		TypeName: options.TypeName,
+	}
+	rules := schema.Generate(form, "", models.FlatSchemaLayout)
+
+	// The struct fields and the package level names (types and constants) are separate, Validate is the method
+	fieldIdents := newIdentifiers(validateMethod)
+	typeIdents := newIdentifiers(options.TypeName)
+	fields := make(map[string]*fieldModel)
+	for _, name := range fieldNames(form) {
+		property := rules.Properties[name]
+		field := &fieldModel{Name: name, Ident: fieldIdents.unique(identifier(name)), Caption: property.Title}
+		field.Required = slices.Contains(rules.Required, name)
+		if property.MaxLength != nil {
+			field.HasLength = true
+			field.MaxLength = *property.MaxLength
+			if property.MinLength != nil {
+				field.MinLength = *property.MinLength
+			}
+		}
+
+		if len(property.Enum) > 0 {
+			field.Enum = newEnumModel(typeIdents, field.Ident, optionLabels(form, name), property.Enum)
+			model.Enums = append(model.Enums, field.Enum)
+		}
+
+		fields[name] = field
+		model.Fields = append(model.Fields, field)
+	}
+
+	for _, field := range model.Fields {
+		if requires := rules.DependentRequired[field.Name]; len(requires) > 0 {
+			dependency := dependencyModel{Field: field}
+			for _, name := range requires {
+				dependency.Requires = append(dependency.Requires, fields[name])
+			}
+			model.Dependencies = append(model.Dependencies, dependency)
+		}
+	}
+
+	return model
+}
+
+func newEnumModel(typeIdents identifiers, name string, labels map[string]string, values []string) *enumModel {
+	enum := &enumModel{TypeName: typeIdents.unique(name)}
+	// The keys are unique inside the enum, e.g. C, C# and C++ give C, C2 and C3
+	keys := newIdentifiers()
+	for _, value := range values {
+		ident := identifier(value)
+		if ident == "" {
+			ident = fmt.Sprintf("Option%d", len(enum.Members)+1)
+		}
+		enum.Members = append(enum.Members, memberModel{Key: keys.unique(ident), Ident: typeIdents.unique(enum.TypeName + ident), Value: value, Label: labels[value]})
+	}
+	return enum
+}
+
+// fieldNames - the named fields of the form in document order
+func fieldNames(form *models.ContentNode) []string {
+	var names []string
+	var visit func(node *models.ContentNode)
+	visit = func(node *models.ContentNode) {
+		if node.ElementType == models.FieldElementType {
+			if node.Name != "" && !slices.Contains(names, node.Name) {
+				names = append(names, node.Name)
+			}
+			return
+		}
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+
+	if form != nil {
+		visit(form)
+	}
+	return names
+}
+
+// optionLabels - the label texts of the field by label name
+func optionLabels(form *models.ContentNode, fieldName string) map[string]string {
+	labels := make(map[string]string)
+	var visit func(node *models.ContentNode)
+	visit = func(node *models.ContentNode) {
+		if node.ElementType == models.FieldElementType && node.Name == fieldName {
+			for _, option := range node.Options() {
+				labels[option.Name] = option.Text
+			}
+			return
+		}
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+	visit(form)
+	return labels
+}
+
+// identifier - an exported identifier from a name. E.g. program_language -> ProgramLanguage, 2nd-choice -> F2ndChoice
+func identifier(name string) string {
+	var ident strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		ident.WriteRune(r)
+	}
+
+	result := ident.String()
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		result = "F" + result
+	}
+	return result
+}
+
+// identifiers - keeps the generated identifiers unique by adding a number
+type identifiers map[string]bool
+
+func newIdentifiers(reserved ...string) identifiers {
+	idents := make(identifiers)
+	for _, ident := range reserved {
+		idents[ident] = true
+	}
+	return idents
+}
+
+func (i identifiers) unique(ident string) string {
+	if ident == "" {
+		ident = "Field"
+	}
+	result := ident
+	for n := 2; i[result]; n++ {
+		result = fmt.Sprintf("%s%d", ident, n)
+	}
+	i[result] = true
+	return result
+}
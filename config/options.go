@@ -1,6 +1,15 @@
 package config
 
-import "github.com/alex-pricope/form-parser/models"
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/spf13/pflag"
+)
 
 type CommandOptions struct {
 	Filename           string
@@ -9,4 +18,278 @@ type CommandOptions struct {
 
 	FromType models.FileType
 	ToType   models.FileType
+
+	Render RenderOptions
+}
+
+// RenderOptions - optional settings that change how the output file is rendered
+type RenderOptions struct {
+	TableOfContents bool
+
+	// Summary prepends a page with the completion of the submission
+	Summary bool
+
+	// Blank renders the form without a submission, with empty answer areas to fill by hand
+	Blank bool
+
+	// Section numbering (1. 1.2. or A.i.) and optional field numbering (Q1.2)
+	SectionNumbering models.NumberingScheme
+	NumberFields     bool
+
+	// Default order of the select options - the Order attribute on a field overrides it
+	OptionOrder models.OptionOrder
+
+	// Folder with the files uploaded for File fields - found files are embedded in the output
+	AttachmentsDir string
+
+	// Every level of section nesting indents the content and makes the font smaller
+	IndentPerDepth float64
+	FontSizeStep   float64
+
+	Protection ProtectionOptions
+	Signing    SigningOptions
+	Watermark  WatermarkOptions
+	Metadata   MetadataOptions
+	Stamp      StampOptions
+
+	// Redaction of the sensitive answers - the internal profile (nothing redacted) when not set
+	Redaction RedactionProfile
+
+	// Reproducible output - the same inputs give the same bytes. SourceDate replaces the current time.
+	Reproducible bool
+	SourceDate   time.Time
+}
+
+// DefaultRenderOptions - the defaults of the flags of the parse command, for the commands that render without them
+func DefaultRenderOptions() RenderOptions {
+	return RenderOptions{
+		SectionNumbering: models.DecimalNumberingScheme,
+		OptionOrder:      models.DeclaredOptionOrder,
+		IndentPerDepth:   5,
+		FontSizeStep:     1,
+		Watermark:        WatermarkOptions{Policy: models.OnInvalidWatermarkPolicy, Opacity: 0.15},
+	}
+}
+
+// WatermarkOptions - the diagonal text printed on every page of a draft or incomplete output
+type WatermarkOptions struct {
+	Policy models.WatermarkPolicy
+	// Text is DRAFT or INCOMPLETE (required answers missing) when empty
+	Text string
+	// Opacity between 0 (invisible) and 1
+	Opacity float64
+}
+
+// StampOptions - the tracking code stamped on every page, so scanned pages can be matched to the submission
+type StampOptions struct {
+	Type     models.StampType
+	Position models.StampPosition
+	// Size of the code in mm - the side of the QR code or the height of the barcode
+	Size float64
+	// SubmissionIDField is the submission field holding the ID - the submission hash is used when empty
+	SubmissionIDField string
+}
+
+// Enabled - the pages are stamped only when a code type is set
+func (o StampOptions) Enabled() bool {
+	return o.Type == models.QRStampType || o.Type == models.Code128StampType
+}
+
+// ProtectionOptions - password protection and permissions of the output file
+type ProtectionOptions struct {
+	// OwnerPassword gives full access, UserPassword is needed to open the file
+	OwnerPassword string
+	UserPassword  string
+
+	// What the user can do once the file is opened
+	AllowPrint  bool
+	AllowCopy   bool
+	AllowModify bool
+}
+
+// Enabled - the output is protected only when a password is set
+func (o ProtectionOptions) Enabled() bool {
+	return o.OwnerPassword != "" || o.UserPassword != ""
+}
+
+// SigningOptions - the certificate and key used to digitally sign the output file
+type SigningOptions struct {
+	// CertificatePath is a PKCS#12 file or a PEM certificate (chain)
+	CertificatePath string
+	// KeyPath is the PEM private key - empty when the key is inside the certificate file
+	KeyPath string
+	// Password of the PKCS#12 file
+	Password string
+}
+
+// Enabled - the output is signed only when a certificate is set
+func (o SigningOptions) Enabled() bool {
+	return o.CertificatePath != ""
+}
+
+// DiffSubmissionsOptions - the inputs of the diff-submissions command
+type DiffSubmissionsOptions struct {
+	Filename              string
+	OldSubmissionFileName string
+	NewSubmissionFileName string
+	OutputDir             string
+
+	FromType models.FileType
+	ToType   models.FileType
+
+	Render DiffRenderOptions
+}
+
+// DiffRenderOptions - optional settings that change how the differences are rendered
+type DiffRenderOptions struct {
+	// ChangedOnly leaves the unchanged fields out
+	ChangedOnly bool
+	// Redaction of the sensitive answers - the internal profile (nothing redacted) when not set
+	Redaction RedactionProfile
+}
+
+// DiffFormsOptions - the inputs of the diff-forms command
+type DiffFormsOptions struct {
+	OldFileName string
+	NewFileName string
+	// OutputDir of the redline PDF - next to the new form when not set. Text and JSON are written to the standard output.
+	OutputDir string
+
+	FromType models.FileType
+	ToType   models.FileType
+}
+
+// MigrateOptions - the inputs of the migrate command
+type MigrateOptions struct {
+	// Filename of the new version of the form, the migrated submissions are validated against it
+	Filename            string
+	MigrationFileName   string
+	SubmissionFileNames []string
+	// OutputDir of the migrated submissions - next to the submission when not set
+	OutputDir string
+
+	FromType models.FileType
+}
+
+// LintOptions - the inputs of the lint command
+type LintOptions struct {
+	Filename string
+	// ConfigFileName turns rules off or changes their severity - all the rules with their severity when not set
+	ConfigFileName string
+
+	ToType models.FileType
+}
+
+// SchemaOptions - the inputs of the schema command
+type SchemaOptions struct {
+	Filename string
+	// SubmissionFileName is validated against the schema instead of writing it, when set
+	SubmissionFileName string
+	Layout             models.SchemaLayout
+
+	FromType models.FileType
+}
+
+// InitSubmissionOptions - the inputs of the init-submission command
+type InitSubmissionOptions struct {
+	Filename string
+	// RequiredOnly leaves out the fields that a valid submission can skip
+	RequiredOnly bool
+	// Random values instead of the placeholders, from the Seed
+	Random bool
+	Seed   int64
+
+	FromType models.FileType
+}
+
+// GenOptions - the inputs of the gen command
+type GenOptions struct {
+	Filename string
+	Language models.Language
+	// Package of the generated Go code and TypeName of the generated struct or interface
+	Package  string
+	TypeName string
+	// OutPath is the file of the generated code, the standard output when empty
+	OutPath string
+
+	FromType models.FileType
+}
+
+// FillOptions - the inputs of the fill command
+type FillOptions struct {
+	Filename string
+	// OutPath is the answers file, saved after every answer
+	OutPath string
+	// Resume starts from the answers already saved in OutPath
+	Resume bool
+	// PDFDir renders the answers to a PDF in the folder when set
+	PDFDir string
+	// Render - the options of the PDF
+	Render RenderOptions
+
+	FromType models.FileType
+}
+
+// WebformOptions - the inputs of the webform command
+type WebformOptions struct {
+	Filename string
+	// Action is where the form is posted, the submit endpoint of the serve command when empty
+	Action string
+
+	FromType models.FileType
+}
+
+// ServeOptions - the inputs of the serve command
+type ServeOptions struct {
+	Filename string
+	Address  string
+	// OutputDir gets the submission files, the uploaded files and the PDFs
+	OutputDir string
+	// Render - the options of the PDFs, the attachments folder is set per submission
+	Render RenderOptions
+
+	FromType models.FileType
+}
+
+// ConfigShowOptions - the inputs of the config show command
+type ConfigShowOptions struct {
+	// Command whose settings are shown and its flags
+	Command string
+	Flags   *pflag.FlagSet
+	// ConfigPath is the --config flag, the settings file is searched for when empty
+	ConfigPath string
+}
+
+// VerifyOptions - the inputs of the verify command
+type VerifyOptions struct {
+	Filename string
+	// TrustedCertsPath is a PEM file with the roots the certificate must chain up to, the roots of the system when empty
+	TrustedCertsPath string
+}
+
+// Custom property names are used as XML element and PDF names, so they are kept simple
+var propertyNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// The standard fields of the document information, set by the renderer. A property with the same name would overwrite them.
+var standardInfoKeys = []string{"Title", "Author", "Subject", "Keywords", "Creator", "Producer", "CreationDate", "ModDate"}
+
+// MetadataOptions - what the output document metadata is filled with, on top of the form title
+type MetadataOptions struct {
+	// Submission fields joined into the author. E.g. first_name, last_name
+	AuthorFields []string
+	// Custom properties embedded in the document. E.g. department=hr
+	Properties map[string]string
+}
+
+// Validate - checks the names of the custom properties
+func (o MetadataOptions) Validate() error {
+	for name := range o.Properties {
+		if !propertyNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid property name: %q", name)
+		}
+		if slices.Contains(standardInfoKeys, name) {
+			return fmt.Errorf("%w: %q is a standard field of the document", myerrors.ErrReservedProperty, name)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataOptions_Validate(t *testing.T) {
+	assert.NoError(t, MetadataOptions{Properties: map[string]string{"FormID": "a", "form.version-2": "b", "_x": "c"}}.Validate())
+	assert.Error(t, MetadataOptions{Properties: map[string]string{"two words": "a"}}.Validate())
+	assert.Error(t, MetadataOptions{Properties: map[string]string{"1st": "a"}}.Validate())
+	assert.Error(t, MetadataOptions{Properties: map[string]string{"a/b": "a"}}.Validate())
+	assert.ErrorIs(t, MetadataOptions{Properties: map[string]string{"Title": "a"}}.Validate(), myerrors.ErrReservedProperty)
+	assert.ErrorIs(t, MetadataOptions{Properties: map[string]string{"ModDate": "a"}}.Validate(), myerrors.ErrReservedProperty)
+}
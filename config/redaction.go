@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var defaultRedactionProfile = "internal"
+
+// RedactionProfile - how the answers of the sensitive fields are shown
+type RedactionProfile struct {
+	Name   string
+	Action models.RedactionAction
+	// VisibleChars is the number of trailing characters a mask leaves visible
+	VisibleChars int
+	// Placeholder is the text shown instead of the answer
+	Placeholder string
+}
+
+/* The profiles match who the output is for:
+   * internal   - nothing is redacted
+   * external   - the sensitive answers are masked, only the last 4 characters stay visible (****1234)
+   * restricted - the sensitive answers are replaced with [REDACTED]
+   * public     - the sensitive fields are left out
+
+   Only the fields marked as sensitive are redacted - see models.ContentNode.IsSensitive.
+*/
+
+var redactionProfiles = map[string]RedactionProfile{
+	"internal":   {Name: "internal", Action: models.NoRedactionAction},
+	"external":   {Name: "external", Action: models.MaskRedactionAction, VisibleChars: 4},
+	"restricted": {Name: "restricted", Action: models.PlaceholderRedactionAction, Placeholder: "[REDACTED]"},
+	"public":     {Name: "public", Action: models.HideRedactionAction},
+}
+
+// ReadRedactionProfile - finds the profile by name. An empty name is the internal profile.
+func ReadRedactionProfile(name string) (RedactionProfile, error) {
+	if name == "" {
+		name = defaultRedactionProfile
+	}
+
+	profile, ok := redactionProfiles[strings.ToLower(name)]
+	if !ok {
+		return profile, fmt.Errorf("unknown redaction profile: %s (available: %s)", name, strings.Join(RedactionProfileNames(), ", "))
+	}
+	return profile, nil
+}
+
+// RedactionProfileNames - the names of the profiles, sorted
+func RedactionProfileNames() []string {
+	names := make([]string, 0, len(redactionProfiles))
+	for name := range redactionProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enabled - the profile changes the sensitive answers
+func (p RedactionProfile) Enabled() bool {
+	return p.Action != "" && p.Action != models.NoRedactionAction
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRedactionProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected models.RedactionAction
+		enabled  bool
+	}{
+		{"", models.NoRedactionAction, false},
+		{"internal", models.NoRedactionAction, false},
+		{"External", models.MaskRedactionAction, true},
+		{"restricted", models.PlaceholderRedactionAction, true},
+		{"public", models.HideRedactionAction, true},
+	}
+
+	for _, tt := range tests {
+		t.Run("Profile_"+tt.name, func(t *testing.T) {
+			profile, err := ReadRedactionProfile(tt.name)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, profile.Action)
+			assert.Equal(t, tt.enabled, profile.Enabled())
+		})
+	}
+}
+
+func TestReadRedactionProfile_Unknown(t *testing.T) {
+	// Act
+	_, err := ReadRedactionProfile("partners")
+
+	// Assert
+	assert.ErrorContains(t, err, "external, internal, public, restricted")
+}
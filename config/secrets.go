@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+var envSecretPrefix, fileSecretPrefix = "env:", "file:"
+
+/* Secrets (like the PDF passwords) should not be typed on the command line - they end up in the shell history.
+   The value of a secret option can point to where the secret is:
+   * env:PDF_OWNER_PASSWORD  - read from the environment variable
+   * file:/run/secrets/owner - read from the file (trailing new lines are removed)
+   * anything else is used as is
+*/
+
+// ResolveSecret - reads the secret from the environment or a file when the value points there
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, envSecretPrefix):
+		name := strings.TrimPrefix(value, envSecretPrefix)
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+
+	case strings.HasPrefix(value, fileSecretPrefix):
+		content, err := os.ReadFile(strings.TrimPrefix(value, fileSecretPrefix))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+
+	default:
+		return value, nil
+	}
+}
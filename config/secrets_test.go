@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecret_Plain(t *testing.T) {
+	// Act
+	secret, err := ResolveSecret("plain-password")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "plain-password", secret)
+}
+
+func TestResolveSecret_Environment(t *testing.T) {
+	// Arrange
+	t.Setenv("FORM_PARSER_TEST_SECRET", "from-env")
+
+	// Act
+	secret, err := ResolveSecret("env:FORM_PARSER_TEST_SECRET")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", secret)
+}
+
+func TestResolveSecret_MissingEnvironment(t *testing.T) {
+	// Act
+	_, err := ResolveSecret("env:FORM_PARSER_TEST_MISSING_SECRET")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FORM_PARSER_TEST_MISSING_SECRET")
+}
+
+func TestResolveSecret_File(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+
+	// Act
+	secret, err := ResolveSecret("file:" + path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", secret)
+}
+
+func TestResolveSecret_MissingFile(t *testing.T) {
+	// Act
+	_, err := ResolveSecret("file:non_existent_file")
+
+	// Assert
+	require.Error(t, err)
+}
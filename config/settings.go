@@ -0,0 +1,325 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+var SettingsFileName = ".formparser.yaml"
+var EnvPrefix = "FORMPARSER_"
+var ConfigFlag = "config"
+
+// ParseCommand - the section of the root command in the settings file
+var ParseCommand = "parse"
+
+// SharedAnnotation - marks the flags that mean the same for every command, e.g. the render flags
+var SharedAnnotation = "formparser_shared_setting"
+
+var commandsKey = "commands"
+var mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+
+/* The flags of a command are read from 4 layers, the first one that has a value wins:
+   * the flag on the command line
+   * the environment variable FORMPARSER_<FLAG>. E.g. FORMPARSER_OUT, FORMPARSER_WATERMARK_OPACITY
+   * the settings file: the section of the command under "commands", then the top level
+   * the default of the flag
+
+   The settings file is --config (or FORMPARSER_CONFIG), else .formparser.yaml in the working directory,
+   else .formparser.yaml in the home directory. Without one, only the environment and the defaults are used.
+
+   from: xml
+   to: pdf
+   out: ./output/
+   numbering: alpha-roman
+   commands:
+     serve:
+       out: ./submissions/
+
+   The keys are the names of the flags. The same name can mean something else for another command, e.g. --to is
+   the output format of lint and --out the answers file of fill. So the environment and the top level only set
+   the flags of the parse command and the shared ones (see SharedAnnotation) of the other commands, their other
+   flags are only read from their section.
+   A key that is not a flag (of the parse command or a shared one for the top level, of its command for a section)
+   is an error, so a typo does not silently fall back to the default.
+*/
+
+// SettingSource - where the value of a flag comes from
+type SettingSource string
+
+const (
+	FlagSettingSource    SettingSource = "flag"
+	EnvSettingSource     SettingSource = "env"
+	FileSettingSource    SettingSource = "file"
+	DefaultSettingSource SettingSource = "default"
+)
+
+// SettingsFile - the values of a settings file, as the text of the flags
+type SettingsFile struct {
+	Path     string
+	Values   map[string]string
+	Commands map[string]map[string]string
+}
+
+// Setting - the effective value of a flag of a command
+type Setting struct {
+	Name   string
+	Value  string
+	Source SettingSource
+	// Origin is the environment variable or the path of the file, for the env and file sources
+	Origin string
+}
+
+// FindSettingsFile - the path of the settings file, empty when there is none. An explicit path must exist.
+func FindSettingsFile(explicit string) (string, error) {
+	if explicit != "" {
+		if _, err := os.Stat(explicit); err != nil {
+			return "", err
+		}
+		return explicit, nil
+	}
+
+	folders := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		folders = append(folders, home)
+	}
+	for _, folder := range folders {
+		path := filepath.Join(folder, SettingsFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// LoadSettingsFile - the settings file of --config, FORMPARSER_CONFIG or the default folders. Nil when there is none.
+func LoadSettingsFile(explicit string, lookupEnv func(string) (string, bool)) (*SettingsFile, error) {
+	if explicit == "" {
+		explicit, _ = lookupEnv(EnvName(ConfigFlag))
+	}
+
+	path, err := FindSettingsFile(explicit)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return ReadSettingsFile(path)
+}
+
+// ReadSettingsFile - reads the values of the settings file. Scalars are kept as text, lists are joined with commas.
+func ReadSettingsFile(path string) (*SettingsFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]any
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	if err = decoder.Decode(&document); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s: %v", myerrors.ErrInvalidSettings, path, err)
+	}
+
+	file := &SettingsFile{Path: path, Values: map[string]string{}, Commands: map[string]map[string]string{}}
+	for key, value := range document {
+		if key != commandsKey {
+			if file.Values[key], err = settingValue(key, value); err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", myerrors.ErrInvalidSettings, path, err)
+			}
+			continue
+		}
+
+		commands, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: %s must map the commands to their flags", myerrors.ErrInvalidSettings, path, commandsKey)
+		}
+		for command, flags := range commands {
+			values, ok := flags.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s: %s.%s must map the flags to their values", myerrors.ErrInvalidSettings, path, commandsKey, command)
+			}
+			file.Commands[command] = map[string]string{}
+			for key, value := range values {
+				if file.Commands[command][key], err = settingValue(commandsKey+"."+command+"."+key, value); err != nil {
+					return nil, fmt.Errorf("%w: %s: %v", myerrors.ErrInvalidSettings, path, err)
+				}
+			}
+		}
+	}
+
+	return file, nil
+}
+
+// CheckKeys - every key must be a flag: a top level key of the parse command or a shared one, a key of a section
+// of its command. The flags are by the name of the command section. E.g. parse, serve
+func (f *SettingsFile) CheckKeys(commands map[string]*pflag.FlagSet) error {
+	if f == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(f.Values)) {
+		known := false
+		for command, flags := range commands {
+			if flag := flags.Lookup(key); flag != nil && readsTopLevel(command, flag) {
+				known = true
+			}
+		}
+		if !known {
+			errs = append(errs, fmt.Errorf("%w: %s: %s is not a flag of the parse command or a shared one, it goes under %s.<command>",
+				myerrors.ErrInvalidSettings, f.Path, key, commandsKey))
+		}
+	}
+
+	for _, command := range slices.Sorted(maps.Keys(f.Commands)) {
+		flags, ok := commands[command]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s: %s.%s is not a command", myerrors.ErrInvalidSettings, f.Path, commandsKey, command))
+			continue
+		}
+		for _, key := range slices.Sorted(maps.Keys(f.Commands[command])) {
+			if flags.Lookup(key) == nil {
+				errs = append(errs, fmt.Errorf("%w: %s: %s.%s.%s is not a flag of %s", myerrors.ErrInvalidSettings, f.Path, commandsKey, command, key, command))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Lookup - the value of the flag for the command: the section of the command first, then the top level
+// when the flag reads it (see readsTopLevel)
+func (f *SettingsFile) Lookup(command string, flag *pflag.Flag) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	if value, ok := f.Commands[command][flag.Name]; ok {
+		return value, true
+	}
+	if !readsTopLevel(command, flag) {
+		return "", false
+	}
+	value, ok := f.Values[flag.Name]
+	return value, ok
+}
+
+// MarkShared - the environment and the top level of the settings file also set these flags on the other commands
+func MarkShared(flags *pflag.FlagSet) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		_ = flags.SetAnnotation(flag.Name, SharedAnnotation, []string{"true"})
+	})
+}
+
+// readsTopLevel - the flag is set from the environment and the top level of the settings file
+func readsTopLevel(command string, flag *pflag.Flag) bool {
+	_, shared := flag.Annotations[SharedAnnotation]
+	return command == ParseCommand || shared
+}
+
+// EnvName - the environment variable of a flag. E.g. watermark-opacity -> FORMPARSER_WATERMARK_OPACITY
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// ApplySettings - sets the flags that are not on the command line from the environment and the settings file.
+// Returns the effective value of every flag, by name.
+func ApplySettings(flags *pflag.FlagSet, command string, file *SettingsFile, lookupEnv func(string) (string, bool)) ([]Setting, error) {
+	// The flags of the command line are known before any other is set
+	changed := map[string]bool{}
+	flags.VisitAll(func(flag *pflag.Flag) { changed[flag.Name] = flag.Changed })
+
+	var settings []Setting
+	var errs []error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Name == "help" || flag.Name == ConfigFlag {
+			return
+		}
+
+		setting := Setting{Name: flag.Name, Value: flagText(flag), Source: DefaultSettingSource}
+		switch {
+		case changed[flag.Name]:
+			setting.Source = FlagSettingSource
+
+		case excludedByFlag(flag, changed):
+			// Another flag of its mutually exclusive group is on the command line
+
+		default:
+			value, source, origin, ok := lookupSetting(flag, command, file, lookupEnv)
+			if !ok {
+				break
+			}
+			if err := flags.Set(flag.Name, value); err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s from %s: %v", myerrors.ErrInvalidSettings, flag.Name, origin, err))
+				return
+			}
+			setting = Setting{Name: flag.Name, Value: flagText(flag), Source: source, Origin: origin}
+		}
+		settings = append(settings, setting)
+	})
+
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Name < settings[j].Name })
+	return settings, errors.Join(errs...)
+}
+
+// lookupSetting - the value of the flag from the environment, then the settings file
+func lookupSetting(flag *pflag.Flag, command string, file *SettingsFile, lookupEnv func(string) (string, bool)) (string, SettingSource, string, bool) {
+	if readsTopLevel(command, flag) {
+		if value, ok := lookupEnv(EnvName(flag.Name)); ok {
+			return value, EnvSettingSource, EnvName(flag.Name), true
+		}
+	}
+	if value, ok := file.Lookup(command, flag); ok {
+		return value, FileSettingSource, file.Path, true
+	}
+	return "", "", "", false
+}
+
+// flagText - the value of the flag as it would be typed on the command line. E.g. a,b instead of [a,b] for a list.
+func flagText(flag *pflag.Flag) string {
+	if list, ok := flag.Value.(pflag.SliceValue); ok {
+		return strings.Join(list.GetSlice(), ",")
+	}
+	return flag.Value.String()
+}
+
+// excludedByFlag - a flag of one of the mutually exclusive groups of the flag is on the command line
+func excludedByFlag(flag *pflag.Flag, changed map[string]bool) bool {
+	for _, group := range flag.Annotations[mutuallyExclusiveAnnotation] {
+		for _, name := range strings.Split(group, " ") {
+			if name != flag.Name && changed[name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// settingValue - the text of a value of the settings file, as it would be typed on the command line
+func settingValue(key string, value any) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		var items []string
+		for _, item := range typed {
+			text, err := settingValue(key, item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, text)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		return "", fmt.Errorf("%s must be a value or a list of values", key)
+	default:
+		return fmt.Sprint(typed), nil
+	}
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSettingsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), SettingsFileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func testFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("parse", pflag.ContinueOnError)
+	flags.String("from", "", "")
+	flags.String("to", "", "")
+	flags.String("out", "", "")
+	flags.Float64("indent", 5, "")
+	flags.Bool("summary", false, "")
+	flags.StringSlice("property", nil, "")
+	return flags
+}
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func TestReadSettingsFile(t *testing.T) {
+	// Arrange
+	path := writeSettingsFile(t, "from: xml\nindent: 7.5\nsummary: true\nproperty: [a=1, b=2]\ncommands:\n  serve:\n    out: ./submissions/\n")
+
+	// Act
+	file, err := ReadSettingsFile(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"from": "xml", "indent": "7.5", "summary": "true", "property": "a=1,b=2"}, file.Values)
+	assert.Equal(t, map[string]map[string]string{"serve": {"out": "./submissions/"}}, file.Commands)
+}
+
+func TestReadSettingsFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"yaml", "from: [xml"},
+		{"nested value", "watermark:\n  text: DRAFT\n"},
+		{"commands", "commands: serve\n"},
+		{"command flags", "commands:\n  serve: out\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadSettingsFile(writeSettingsFile(t, tt.content))
+
+			assert.ErrorIs(t, err, myerrors.ErrInvalidSettings)
+		})
+	}
+}
+
+func TestReadSettingsFile_Empty(t *testing.T) {
+	// Act
+	file, err := ReadSettingsFile(writeSettingsFile(t, ""))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, file.Values)
+}
+
+func TestSettingsFile_CheckKeys(t *testing.T) {
+	serveFlags := pflag.NewFlagSet("serve", pflag.ContinueOnError)
+	serveFlags.String("addr", "", "")
+	commands := map[string]*pflag.FlagSet{"parse": testFlags(), "serve": serveFlags}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"known", "from: xml\ncommands:\n  serve:\n    addr: :9090\n", ""},
+		{"unknown top level", "form: xml\n", "form is not a flag of the parse command or a shared one"},
+		{"top level flag of another command", "addr: :8080\n", "addr is not a flag of the parse command or a shared one, it goes under commands.<command>"},
+		{"unknown command", "commands:\n  serv:\n    addr: :8080\n", "commands.serv is not a command"},
+		{"flag of another command", "commands:\n  serve:\n    summary: true\n", "commands.serve.summary is not a flag of serve"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			file, err := ReadSettingsFile(writeSettingsFile(t, tt.content))
+			require.NoError(t, err)
+
+			// Act
+			err = file.CheckKeys(commands)
+
+			// Assert
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, myerrors.ErrInvalidSettings)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestApplySettings_Precedence(t *testing.T) {
+	// Arrange - from is on the command line, to and out are in the environment and the file
+	path := writeSettingsFile(t, "from: json\nto: html\nout: ./file/\nproperty: [a=1]\ncommands:\n  parse:\n    indent: 9\n")
+	file, err := ReadSettingsFile(path)
+	require.NoError(t, err)
+	flags := testFlags()
+	require.NoError(t, flags.Parse([]string{"--from", "xml"}))
+
+	// Act
+	settings, err := ApplySettings(flags, "parse", file, env(map[string]string{"FORMPARSER_TO": "pdf"}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []Setting{
+		{Name: "from", Value: "xml", Source: FlagSettingSource},
+		{Name: "indent", Value: "9", Source: FileSettingSource, Origin: path},
+		{Name: "out", Value: "./file/", Source: FileSettingSource, Origin: path},
+		{Name: "property", Value: "a=1", Source: FileSettingSource, Origin: path},
+		{Name: "summary", Value: "false", Source: DefaultSettingSource},
+		{Name: "to", Value: "pdf", Source: EnvSettingSource, Origin: "FORMPARSER_TO"},
+	}, settings)
+
+	// The values are set on the flags, as if they were on the command line
+	to, err := flags.GetString("to")
+	require.NoError(t, err)
+	assert.Equal(t, "pdf", to)
+	assert.True(t, flags.Changed("to"))
+}
+
+func TestApplySettings_CommandSection(t *testing.T) {
+	// Arrange - the section of another command is not used
+	file := &SettingsFile{Path: "settings.yaml", Values: map[string]string{"out": "./output/"}, Commands: map[string]map[string]string{"serve": {"out": "./submissions/"}}}
+	flags := testFlags()
+
+	// Act
+	_, err := ApplySettings(flags, "parse", file, env(nil))
+
+	// Assert
+	require.NoError(t, err)
+	out, _ := flags.GetString("out")
+	assert.Equal(t, "./output/", out)
+}
+
+func TestApplySettings_OtherCommand(t *testing.T) {
+	// Arrange - out and addr are flags of serve, summary is shared with the parse command
+	file := &SettingsFile{Path: "settings.yaml", Values: map[string]string{"out": "./output/", "summary": "true"}}
+	flags := pflag.NewFlagSet("serve", pflag.ContinueOnError)
+	flags.String("out", "submissions", "")
+	flags.String("addr", "localhost:8080", "")
+	shared := pflag.NewFlagSet("render", pflag.ContinueOnError)
+	shared.Bool("summary", false, "")
+	MarkShared(shared)
+	flags.AddFlagSet(shared)
+
+	// Act
+	_, err := ApplySettings(flags, "serve", file, env(map[string]string{"FORMPARSER_ADDR": ":9090"}))
+
+	// Assert - only the shared flag is set from the top level and the environment
+	require.NoError(t, err)
+	out, _ := flags.GetString("out")
+	assert.Equal(t, "submissions", out)
+	addr, _ := flags.GetString("addr")
+	assert.Equal(t, "localhost:8080", addr)
+	summary, _ := flags.GetBool("summary")
+	assert.True(t, summary)
+}
+
+func TestApplySettings_MutuallyExclusive(t *testing.T) {
+	// Arrange - blank is in the file, sub on the command line
+	flags := pflag.NewFlagSet("parse", pflag.ContinueOnError)
+	flags.String("sub", "", "")
+	flags.Bool("blank", false, "")
+	for _, name := range []string{"sub", "blank"} {
+		require.NoError(t, flags.SetAnnotation(name, mutuallyExclusiveAnnotation, []string{"sub blank"}))
+	}
+	require.NoError(t, flags.Parse([]string{"--sub", "submission.json"}))
+	file := &SettingsFile{Values: map[string]string{"blank": "true"}}
+
+	// Act
+	_, err := ApplySettings(flags, "parse", file, env(nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, flags.Changed("blank"))
+}
+
+func TestApplySettings_InvalidValue(t *testing.T) {
+	// Act
+	_, err := ApplySettings(testFlags(), "parse", nil, env(map[string]string{"FORMPARSER_INDENT": "wide"}))
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrInvalidSettings)
+	assert.ErrorContains(t, err, "indent from FORMPARSER_INDENT")
+}
+
+func TestLoadSettingsFile(t *testing.T) {
+	// Arrange
+	path := writeSettingsFile(t, "from: xml\n")
+
+	// Act
+	fromEnv, err := LoadSettingsFile("", env(map[string]string{"FORMPARSER_CONFIG": path}))
+	require.NoError(t, err)
+	_, missingErr := LoadSettingsFile(filepath.Join(t.TempDir(), "missing.yaml"), env(nil))
+
+	// Assert
+	assert.Equal(t, path, fromEnv.Path)
+	assert.ErrorIs(t, missingErr, os.ErrNotExist)
+}
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "FORMPARSER_WATERMARK_OPACITY", EnvName("watermark-opacity"))
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+var sourceDateEpochVariable = "SOURCE_DATE_EPOCH"
+
+/* Reproducible output replaces the current time with a fixed one, following https://reproducible-builds.org/specs/source-date-epoch/
+ * SOURCE_DATE_EPOCH=1714557600 - seconds since 1970-01-01 UTC, usually the time of the last change of the inputs
+ * not set - 1970-01-01 UTC
+ */
+
+// ReadSourceDate - the fixed time used by the reproducible output
+func ReadSourceDate() (time.Time, error) {
+	value, ok := os.LookupEnv(sourceDateEpochVariable)
+	if !ok || value == "" {
+		return time.Unix(0, 0).UTC(), nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, fmt.Errorf("invalid %s: %s", sourceDateEpochVariable, value)
+	}
+
+	return time.Unix(seconds, 0).UTC(), nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSourceDate_FromEnvironment(t *testing.T) {
+	// Arrange
+	t.Setenv("SOURCE_DATE_EPOCH", "1714557600")
+
+	// Act
+	date, err := ReadSourceDate()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), date)
+}
+
+func TestReadSourceDate_NotSet(t *testing.T) {
+	// Arrange
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+
+	// Act
+	date, err := ReadSourceDate()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), date.Unix())
+}
+
+func TestReadSourceDate_Invalid(t *testing.T) {
+	// Arrange
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+
+	// Act
+	_, err := ReadSourceDate()
+
+	// Assert
+	assert.Error(t, err)
+}
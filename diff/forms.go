@@ -0,0 +1,230 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var topLevelTextValue = "(form)"
+var notSetTextValue = "(not set)"
+
+// FormChange - a difference of a field or section between two versions of a form
+type FormChange struct {
+	Change  models.ChangeType  `json:"change"`
+	Element models.ElementType `json:"element"`
+	Name    string             `json:"name"`
+	Old     string             `json:"old,omitempty"`
+	New     string             `json:"new,omitempty"`
+	// Details lists the added (+), removed (-) and relabeled (~) options of an options change
+	Details string `json:"details,omitempty"`
+}
+
+// formElement - a named field or section of a form, with the path of the sections it is in
+type formElement struct {
+	node *models.ContentNode
+	path string
+}
+
+/* Two versions of a form are compared by the Name of the fields and sections:
+   * added / removed - the name is only in the new / old version
+   * moved           - the element is in another section (the path of section names changed)
+   * retyped         - the FieldType or the Type changed. A change of the Enumeration members is an options change.
+   * caption         - the Caption of a field or the Title of a section changed
+   * options         - the labels (name or text) or the Enumeration members of a field changed
+   * optional        - the Optional flag changed
+
+   An element can have several changes. The changes are listed in the order of the new version,
+   the removed elements follow in the order of the old one. Elements without a Name are not compared.
+*/
+
+// CompareForms - the changes between the old and the new version of a form
+func CompareForms(old, new *models.ContentNode) []FormChange {
+	oldElements, oldOrder := indexElements(old)
+	newElements, newOrder := indexElements(new)
+
+	var changes []FormChange
+	for _, name := range newOrder {
+		element := newElements[name]
+		previous, ok := oldElements[name]
+		if !ok {
+			changes = append(changes, FormChange{Change: models.AddedChangeType, Element: element.node.ElementType, Name: name, New: element.path})
+			continue
+		}
+		changes = append(changes, compareElements(name, previous, element)...)
+	}
+
+	for _, name := range oldOrder {
+		if _, ok := newElements[name]; !ok {
+			element := oldElements[name]
+			changes = append(changes, FormChange{Change: models.RemovedChangeType, Element: element.node.ElementType, Name: name, Old: element.path})
+		}
+	}
+
+	return changes
+}
+
+// compareElements - the changes of an element found in both versions
+func compareElements(name string, old, new formElement) []FormChange {
+	var changes []FormChange
+	add := func(change models.ChangeType, oldValue, newValue string) {
+		changes = append(changes, FormChange{Change: change, Element: new.node.ElementType, Name: name, Old: oldValue, New: newValue})
+	}
+
+	if old.node.ElementType != new.node.ElementType {
+		add(models.RetypedChangeType, string(old.node.ElementType), string(new.node.ElementType))
+		return changes
+	}
+
+	if old.path != new.path {
+		add(models.MovedChangeType, old.path, new.path)
+	}
+
+	if oldType, newType := typeOf(old.node), typeOf(new.node); oldType != newType {
+		add(models.RetypedChangeType, oldType, newType)
+	}
+
+	if oldCaption, newCaption := captionOf(old.node), captionOf(new.node); oldCaption != newCaption {
+		add(models.CaptionChangeType, oldCaption, newCaption)
+	}
+
+	if details := compareMembers(old.node, new.node); details != "" {
+		add(models.OptionsChangeType, strings.Join(memberNames(old.node), ", "), strings.Join(memberNames(new.node), ", "))
+		changes[len(changes)-1].Details = details
+	}
+
+	if oldOptional, newOptional := optionalOf(old.node), optionalOf(new.node); !strings.EqualFold(oldOptional, newOptional) {
+		add(models.OptionalChangeType, oldOptional, newOptional)
+	}
+
+	return changes
+}
+
+// indexElements - the named fields and sections of the form by name, and their names in document order
+func indexElements(form *models.ContentNode) (map[string]formElement, []string) {
+	elements := make(map[string]formElement)
+	var order []string
+
+	var visit func(node *models.ContentNode, path []string)
+	visit = func(node *models.ContentNode, path []string) {
+		isElement := node.ElementType == models.FieldElementType || node.ElementType == models.SectionElementType
+		if isElement && node.Name != "" {
+			if _, ok := elements[node.Name]; !ok {
+				elements[node.Name] = formElement{node: node, path: formatPath(path)}
+				order = append(order, node.Name)
+			}
+		}
+
+		if node.ElementType == models.SectionElementType {
+			path = append(path[:len(path):len(path)], node.Name)
+		}
+		for _, child := range node.Children {
+			visit(child, path)
+		}
+	}
+
+	if form != nil {
+		visit(form, nil)
+	}
+	return elements, order
+}
+
+func formatPath(path []string) string {
+	if len(path) == 0 {
+		return topLevelTextValue
+	}
+	return strings.Join(path, "/")
+}
+
+// typeOf - the FieldType and the Type of a field, without the Enumeration members. E.g. TextBox Text([0,200])
+func typeOf(node *models.ContentNode) string {
+	if node.ElementType != models.FieldElementType {
+		return ""
+	}
+
+	fieldType := node.Metadata["FieldType"]
+	typeValue := node.Metadata["Type"]
+	if definition := models.ParseTypeDefinition(typeValue); strings.EqualFold(definition.Name, "Enumeration") {
+		typeValue = definition.Name
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s", fieldType, typeValue))
+}
+
+// captionOf - the Caption of a field or the Title of a section
+func captionOf(node *models.ContentNode) string {
+	for _, child := range node.Children {
+		if child.ElementType == models.CaptionElementType || child.ElementType == models.TitleElementType {
+			return child.Value
+		}
+	}
+	return ""
+}
+
+func optionalOf(node *models.ContentNode) string {
+	if value, ok := node.Metadata["Optional"]; ok {
+		return value
+	}
+	return notSetTextValue
+}
+
+// memberNames - the label names of a field, or the Enumeration members when it has no labels
+func memberNames(node *models.ContentNode) []string {
+	var names []string
+	for _, option := range node.Options() {
+		names = append(names, option.Name)
+	}
+	if len(names) > 0 {
+		return names
+	}
+
+	definition := models.ParseTypeDefinition(node.Metadata["Type"])
+	if strings.EqualFold(definition.Name, "Enumeration") {
+		return definition.Arguments
+	}
+	return nil
+}
+
+// compareMembers - the added (+), removed (-) and relabeled (~) options, or empty when they are the same
+func compareMembers(old, new *models.ContentNode) string {
+	oldLabels := optionLabels(old)
+	newLabels := optionLabels(new)
+
+	var details []string
+	for _, name := range memberNames(new) {
+		label, ok := oldLabels[name]
+		switch {
+		case !ok:
+			details = append(details, "+"+name)
+		case label != newLabels[name]:
+			details = append(details, "~"+name)
+		}
+	}
+	for _, name := range memberNames(old) {
+		if _, ok := newLabels[name]; !ok {
+			details = append(details, "-"+name)
+		}
+	}
+
+	// The Enumeration members are compared too - they should match the labels
+	oldMembers := models.ParseTypeDefinition(old.Metadata["Type"])
+	newMembers := models.ParseTypeDefinition(new.Metadata["Type"])
+	if len(details) == 0 && strings.EqualFold(oldMembers.Name, "Enumeration") && strings.EqualFold(newMembers.Name, "Enumeration") &&
+		strings.Join(oldMembers.Arguments, ",") != strings.Join(newMembers.Arguments, ",") {
+		details = append(details, fmt.Sprintf("Enumeration(%s) -> Enumeration(%s)", strings.Join(oldMembers.Arguments, ","), strings.Join(newMembers.Arguments, ",")))
+	}
+
+	return strings.Join(details, " ")
+}
+
+// optionLabels - the label text of every member. Enumeration members without a label have an empty text.
+func optionLabels(node *models.ContentNode) map[string]string {
+	labels := make(map[string]string)
+	for _, name := range memberNames(node) {
+		labels[name] = ""
+	}
+	for _, option := range node.Options() {
+		labels[option.Name] = option.Text
+	}
+	return labels
+}
@@ -0,0 +1,114 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSelectField(name, typeValue string, labels ...models.Option) *models.ContentNode {
+	field := newField(name)
+	field.Metadata["FieldType"] = "Select"
+	field.Metadata["Type"] = typeValue
+
+	labelsNode := &models.ContentNode{ElementType: models.LabelsElementType}
+	for _, label := range labels {
+		labelsNode.Children = append(labelsNode.Children, &models.ContentNode{
+			ElementType: models.LabelElementType, Metadata: map[string]string{"Name": label.Name}, Value: label.Text})
+	}
+	field.Children = append(field.Children, labelsNode)
+	return field
+}
+
+func withCaption(field *models.ContentNode, caption string) *models.ContentNode {
+	field.Children = append(field.Children, &models.ContentNode{ElementType: models.CaptionElementType, Value: caption})
+	return field
+}
+
+func TestCompareForms(t *testing.T) {
+	// Arrange
+	old := newForm()
+	new := newForm()
+
+	// user_name is removed, street moves out of the address section, phone is added
+	new.Children = append(new.Children[1:], withCaption(newField("phone"), "Phone"))
+	contents := new.Children[0].Children[1]
+	contents.Children = contents.Children[1:]
+	new.Children = append(new.Children, newField("street"))
+
+	// email gets a caption, is retyped and becomes required
+	email := old.Children[2]
+	email.Metadata["FieldType"] = "TextBox"
+	email.Metadata["Type"] = "Text([0,100])"
+	newEmail := new.Children[1]
+	withCaption(newEmail, "E-mail")
+	newEmail.Metadata["FieldType"] = "TextBox"
+	newEmail.Metadata["Type"] = "Text([0,200])"
+	newEmail.Metadata["Optional"] = "False"
+
+	// the address section gets another title
+	new.Children[0].Children[0] = &models.ContentNode{ElementType: models.TitleElementType, Value: "Address"}
+
+	// Act
+	changes := CompareForms(old, new)
+
+	// Assert
+	expected := []FormChange{
+		{Change: models.CaptionChangeType, Element: models.SectionElementType, Name: "address", Old: "Address Details", New: "Address"},
+		{Change: models.RetypedChangeType, Element: models.FieldElementType, Name: "email", Old: "TextBox Text([0,100])", New: "TextBox Text([0,200])"},
+		{Change: models.CaptionChangeType, Element: models.FieldElementType, Name: "email", Old: "", New: "E-mail"},
+		{Change: models.OptionalChangeType, Element: models.FieldElementType, Name: "email", Old: "(not set)", New: "False"},
+		{Change: models.AddedChangeType, Element: models.FieldElementType, Name: "phone", New: "(form)"},
+		{Change: models.MovedChangeType, Element: models.FieldElementType, Name: "street", Old: "address", New: "(form)"},
+		{Change: models.RemovedChangeType, Element: models.FieldElementType, Name: "user_name", Old: "(form)"},
+	}
+	assert.Equal(t, expected, changes)
+}
+
+func TestCompareForms_Options(t *testing.T) {
+	// Arrange
+	old := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		newSelectField("language", "Enumeration(A,B,C)", models.Option{Name: "A", Text: "Go"}, models.Option{Name: "B", Text: "C#"}, models.Option{Name: "C", Text: "Java"}),
+	}}
+	new := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		newSelectField("language", "Enumeration(A,B,D)", models.Option{Name: "A", Text: "Golang"}, models.Option{Name: "B", Text: "C#"}, models.Option{Name: "D", Text: "Rust"}),
+	}}
+
+	// Act
+	changes := CompareForms(old, new)
+
+	// Assert - the Enumeration members are an options change, not a new type
+	require.Len(t, changes, 1)
+	assert.Equal(t, models.OptionsChangeType, changes[0].Change)
+	assert.Equal(t, "A, B, C", changes[0].Old)
+	assert.Equal(t, "A, B, D", changes[0].New)
+	assert.Equal(t, "~A +D -C", changes[0].Details)
+}
+
+func TestCompareForms_EnumerationMembers(t *testing.T) {
+	// Arrange - the labels are the same, only the Enumeration members changed
+	old := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		newSelectField("language", "Enumeration(A,B)", models.Option{Name: "A", Text: "Go"}, models.Option{Name: "B", Text: "C#"}),
+	}}
+	new := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		newSelectField("language", "Enumeration(A,B,C)", models.Option{Name: "A", Text: "Go"}, models.Option{Name: "B", Text: "C#"}),
+	}}
+
+	// Act
+	changes := CompareForms(old, new)
+
+	// Assert
+	require.Len(t, changes, 1)
+	assert.Equal(t, models.OptionsChangeType, changes[0].Change)
+	assert.Equal(t, "Enumeration(A,B) -> Enumeration(A,B,C)", changes[0].Details)
+}
+
+func TestCompareForms_Unchanged(t *testing.T) {
+	// Act
+	changes := CompareForms(newForm(), newForm())
+
+	// Assert
+	assert.Empty(t, changes)
+}
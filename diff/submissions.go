@@ -0,0 +1,103 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+// FieldChange - the old and the new answer of a field
+type FieldChange struct {
+	Field *models.ContentNode
+	// Sections holds the titles of the sections the field is in, outermost first
+	Sections []string
+
+	Old    string
+	New    string
+	Change models.ChangeType
+}
+
+/* Two submissions of the same form are compared field by field, in the order of the form:
+   * added     - the old submission has no answer, the new one has
+   * removed   - the old submission has an answer, the new one has not
+   * changed   - both have an answer and they differ
+   * unchanged - the same answer (or no answer) in both
+
+   The answers are compared without the leading and trailing spaces. Submitted values of fields
+   that are not in the form are ignored.
+*/
+
+// CompareSubmissions - the changes of every field of the form, in document order
+func CompareSubmissions(form *models.ContentNode, old, new *models.ContentSubmission) []FieldChange {
+	var changes []FieldChange
+
+	var visit func(node *models.ContentNode, sections []string)
+	visit = func(node *models.ContentNode, sections []string) {
+		switch node.ElementType {
+		case models.FieldElementType:
+			change := FieldChange{
+				Field:    node,
+				Sections: sections,
+				Old:      answer(old, node.Name),
+				New:      answer(new, node.Name),
+			}
+			change.Change = compareAnswers(change.Old, change.New)
+			changes = append(changes, change)
+			return
+
+		case models.SectionElementType:
+			sections = append(sections[:len(sections):len(sections)], sectionTitle(node))
+		}
+
+		for _, child := range node.Children {
+			visit(child, sections)
+		}
+	}
+
+	if form != nil {
+		visit(form, nil)
+	}
+
+	return changes
+}
+
+// ChangedOnly - the changes without the unchanged fields
+func ChangedOnly(changes []FieldChange) []FieldChange {
+	var result []FieldChange
+	for _, change := range changes {
+		if change.Change != models.UnchangedChangeType {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+func compareAnswers(old, new string) models.ChangeType {
+	switch {
+	case old == new:
+		return models.UnchangedChangeType
+	case old == "":
+		return models.AddedChangeType
+	case new == "":
+		return models.RemovedChangeType
+	default:
+		return models.ChangedChangeType
+	}
+}
+
+func answer(submission *models.ContentSubmission, fieldName string) string {
+	if submission == nil {
+		return ""
+	}
+	return strings.TrimSpace((*submission)[fieldName])
+}
+
+// sectionTitle - the Title of the section, or its Name when it has none
+func sectionTitle(node *models.ContentNode) string {
+	for _, child := range node.Children {
+		if child.ElementType == models.TitleElementType {
+			return child.Value
+		}
+	}
+	return node.Name
+}
@@ -0,0 +1,80 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newField(name string) *models.ContentNode {
+	return &models.ContentNode{ElementType: models.FieldElementType, Name: name, Metadata: map[string]string{"Name": name}}
+}
+
+func newForm() *models.ContentNode {
+	return &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		newField("user_name"),
+		{ElementType: models.SectionElementType, Name: "address", Children: []*models.ContentNode{
+			{ElementType: models.TitleElementType, Value: "Address Details"},
+			{ElementType: models.ContentsElementType, Children: []*models.ContentNode{
+				newField("street"),
+				{ElementType: models.SectionElementType, Name: "country_region", Children: []*models.ContentNode{
+					{ElementType: models.ContentsElementType, Children: []*models.ContentNode{newField("country"), newField("region")}},
+				}},
+			}},
+		}},
+		newField("email"),
+	}}
+}
+
+func TestCompareSubmissions(t *testing.T) {
+	// Arrange
+	old := &models.ContentSubmission{"user_name": "Jane", "street": "Main Street 1", "country": "NL", "email": "a@b.c"}
+	new := &models.ContentSubmission{"user_name": "Jane ", "country": "BE", "region": "Flanders", "email": "a@b.c", "extra": "x"}
+
+	// Act
+	changes := CompareSubmissions(newForm(), old, new)
+
+	// Assert
+	require.Len(t, changes, 5)
+	expected := []struct {
+		name     string
+		change   models.ChangeType
+		sections []string
+	}{
+		{"user_name", models.UnchangedChangeType, nil},
+		{"street", models.RemovedChangeType, []string{"Address Details"}},
+		{"country", models.ChangedChangeType, []string{"Address Details", "country_region"}},
+		{"region", models.AddedChangeType, []string{"Address Details", "country_region"}},
+		{"email", models.UnchangedChangeType, nil},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.name, changes[i].Field.Name)
+		assert.Equal(t, e.change, changes[i].Change, e.name)
+		assert.Equal(t, e.sections, changes[i].Sections, e.name)
+	}
+	assert.Equal(t, "NL", changes[2].Old)
+	assert.Equal(t, "BE", changes[2].New)
+}
+
+func TestCompareSubmissions_MissingSubmission(t *testing.T) {
+	// Act
+	changes := CompareSubmissions(newForm(), nil, &models.ContentSubmission{"email": "a@b.c"})
+
+	// Assert
+	assert.Equal(t, models.AddedChangeType, changes[4].Change)
+	assert.Equal(t, models.UnchangedChangeType, changes[0].Change)
+}
+
+func TestChangedOnly(t *testing.T) {
+	// Arrange
+	changes := CompareSubmissions(newForm(), &models.ContentSubmission{"street": "a"}, &models.ContentSubmission{"street": "b"})
+
+	// Act
+	changed := ChangedOnly(changes)
+
+	// Assert
+	require.Len(t, changed, 1)
+	assert.Equal(t, "street", changed[0].Field.Name)
+}
@@ -4,3 +4,28 @@ import "errors"
 
 var ErrEmptyPathProvided = errors.New("empty path provided")
 var ErrEmptyFile = errors.New("empty file provided")
+var ErrDuplicateFieldName = errors.New("duplicate field name")
+var ErrDuplicateLabelName = errors.New("duplicate label name")
+var ErrNoSignature = errors.New("no signature found")
+var ErrInvalidByteRange = errors.New("invalid signature byte range")
+var ErrDigestMismatch = errors.New("document digest does not match the signature")
+var ErrInvalidSignature = errors.New("invalid signature")
+var ErrUnsupportedKey = errors.New("unsupported private key type")
+var ErrSignProtectedFile = errors.New("cannot sign a password protected file")
+var ErrReproducibleOwnerPassword = errors.New("reproducible protected output needs an owner password")
+var ErrDataTooLong = errors.New("data too long for the barcode")
+var ErrInvalidCode = errors.New("invalid barcode")
+var ErrUnknownField = errors.New("unknown field")
+var ErrMissingAnswer = errors.New("missing required answer")
+var ErrInvalidOption = errors.New("invalid option")
+var ErrInvalidLength = errors.New("invalid answer length")
+var ErrInvalidMigration = errors.New("invalid migration")
+var ErrMigrationConflict = errors.New("migration conflict")
+var ErrUnknownLintRule = errors.New("unknown lint rule")
+var ErrInvalidLintConfig = errors.New("invalid lint config")
+var ErrLintErrors = errors.New("the form has lint errors")
+var ErrSchemaViolation = errors.New("schema violation")
+var ErrInvalidDate = errors.New("invalid date")
+var ErrFillIncomplete = errors.New("the form is not filled in")
+var ErrInvalidSettings = errors.New("invalid settings")
+var ErrReservedProperty = errors.New("reserved property name")
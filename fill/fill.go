@@ -0,0 +1,346 @@
+package fill
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/schema"
+)
+
+var linesOption = "Lines"
+var dateLayout = "2006-01-02"
+
+// Commands that can be typed instead of an answer
+var (
+	backCommand = ":back"
+	skipCommand = ":skip"
+	quitCommand = ":quit"
+	helpCommand = ":help"
+	helpText    = "Type the answer and press Enter. Enter alone keeps the current answer.\n" +
+		"  :back  go to the previous field\n  :skip  clear the answer\n  :quit  save the progress and stop\n"
+	missingTitle = "(untitled section)"
+)
+
+/* The fields are asked one after the other, in document order. Every answer is validated right away,
+   with the schema of the form like a submission (required, options, lengths) - and a Date must be YYYY-MM-DD.
+   * a select lists its labels with a number, the number or the label Name is the answer
+   * a textbox with Lines:N (N > 1) takes several lines, an empty line ends the answer
+   * a required field inside an optional section can be skipped, until the section is started
+
+   Save is called after every answer, so the progress survives a quit or a dropped call.
+   At the end, all the answers are checked again - answering a field can start an optional section
+   and make an earlier skipped field required. The first field with a problem is asked again.
+*/
+
+// Session - fills the answers of a form by asking for the fields in the terminal
+type Session struct {
+	Answers models.ContentSubmission
+
+	schema    *schema.Schema
+	questions []question
+	input     *bufio.Scanner
+	output    io.Writer
+	save      func(models.ContentSubmission) error
+}
+
+// question - a field and the sections around it
+type question struct {
+	field    *models.ContentNode
+	sections []*models.ContentNode
+}
+
+// NewSession - a session that starts from the given answers, e.g. the saved progress
+func NewSession(form *models.ContentNode, answers models.ContentSubmission, input io.Reader, output io.Writer, save func(models.ContentSubmission) error) *Session {
+	if answers == nil {
+		answers = models.ContentSubmission{}
+	}
+	return &Session{
+		Answers:   answers,
+		schema:    schema.Generate(form, "", models.FlatSchemaLayout),
+		questions: collectQuestions(form),
+		input:     bufio.NewScanner(input),
+		output:    output,
+		save:      save,
+	}
+}
+
+// Run - asks for the fields until all the answers are valid. Returns ErrFillIncomplete on :quit or at the end of the input.
+func (s *Session) Run() error {
+	s.printf("%s", helpText)
+
+	index := s.firstUnanswered()
+	var previous *question
+	// After the end, only the fields with a problem are asked again
+	reviewing := false
+	for {
+		if index >= len(s.questions) {
+			problem := s.firstProblem()
+			if problem < 0 {
+				return nil
+			}
+			index, reviewing = problem, true
+		}
+
+		current := &s.questions[index]
+		s.printSections(previous, current)
+		previous = current
+
+		line, ok := s.ask(index)
+		if !ok {
+			return myerrors.ErrFillIncomplete
+		}
+
+		answer := s.Answers[current.field.Name]
+		switch strings.TrimSpace(line) {
+		case helpCommand:
+			s.printf("%s", helpText)
+			continue
+		case quitCommand:
+			return myerrors.ErrFillIncomplete
+		case backCommand:
+			if index == 0 {
+				s.printf("  ! already at the first field\n")
+				continue
+			}
+			index, reviewing = index-1, false
+			continue
+		case skipCommand:
+			answer = ""
+		case "":
+			// Enter alone keeps the current answer
+		default:
+			answer = s.readAnswer(current, line)
+		}
+
+		if err := s.validate(current, answer); err != nil {
+			s.printf("  ! %v\n", err)
+			continue
+		}
+
+		if answer == "" {
+			delete(s.Answers, current.field.Name)
+		} else {
+			s.Answers[current.field.Name] = answer
+		}
+		if err := s.save(s.Answers); err != nil {
+			return err
+		}
+		if reviewing {
+			index = len(s.questions)
+		} else {
+			index++
+		}
+	}
+}
+
+// ask - prints the question and reads the first line of the answer
+func (s *Session) ask(index int) (string, bool) {
+	field := s.questions[index].field
+	definition := models.ParseTypeDefinition(field.Metadata["Type"])
+
+	var hints []string
+	if s.isRequired(&s.questions[index]) {
+		hints = append(hints, "required")
+	}
+	if minimum, maximum, ok := definition.LengthRange(); ok {
+		hints = append(hints, fmt.Sprintf("%d-%d characters", minimum, maximum))
+	}
+	if strings.EqualFold(definition.Name, "Date") {
+		hints = append(hints, "YYYY-MM-DD")
+	}
+	if lines(field) > 1 {
+		hints = append(hints, "several lines, end with an empty line")
+	}
+
+	s.printf("\n[%d/%d] %s", index+1, len(s.questions), caption(field))
+	if len(hints) > 0 {
+		s.printf(" (%s)", strings.Join(hints, ", "))
+	}
+	s.printf("\n")
+
+	for i, option := range field.Options() {
+		s.printf("  %d) %s\n", i+1, option.Text)
+	}
+	if current, ok := s.Answers[field.Name]; ok {
+		s.printf("  current: %s\n", current)
+	}
+	s.printf("> ")
+
+	return s.readLine()
+}
+
+// readAnswer - the answer from the first line: the label of a number, or the rest of the lines of a multi-line textbox
+func (s *Session) readAnswer(q *question, line string) string {
+	field := q.field
+	options := field.Options()
+	if len(options) > 0 {
+		value := strings.TrimSpace(line)
+		if number, err := strconv.Atoi(value); err == nil && number >= 1 && number <= len(options) {
+			return options[number-1].Name
+		}
+		for _, option := range options {
+			if strings.EqualFold(option.Name, value) {
+				return option.Name
+			}
+		}
+		return value
+	}
+
+	if lines(field) > 1 {
+		answer := []string{line}
+		for {
+			next, ok := s.readLine()
+			if !ok || next == "" {
+				break
+			}
+			answer = append(answer, next)
+		}
+		line = strings.Join(answer, "\n")
+	}
+
+	return strings.TrimSpace(line)
+}
+
+// validate - the rules of the schema, for one answer
+func (s *Session) validate(q *question, answer string) error {
+	answers := models.ContentSubmission{}
+	for name, value := range s.Answers {
+		answers[name] = value
+	}
+	answers[q.field.Name] = answer
+
+	if err := schema.ValidateProperty(s.schema, instance(answers), q.field.Name); err != nil {
+		return err
+	}
+
+	definition := models.ParseTypeDefinition(q.field.Metadata["Type"])
+	if answer != "" && strings.EqualFold(definition.Name, "Date") {
+		if _, err := time.Parse(dateLayout, answer); err != nil {
+			return fmt.Errorf("%w: %s, expected YYYY-MM-DD", myerrors.ErrInvalidDate, answer)
+		}
+	}
+	return nil
+}
+
+// firstUnanswered - where a resumed session starts
+func (s *Session) firstUnanswered() int {
+	for i, q := range s.questions {
+		if _, ok := s.Answers[q.field.Name]; !ok {
+			return i
+		}
+	}
+	return len(s.questions)
+}
+
+// firstProblem - the first field whose answer is not valid anymore, -1 when all are valid
+func (s *Session) firstProblem() int {
+	for i := range s.questions {
+		if err := s.validate(&s.questions[i], s.Answers[s.questions[i].field.Name]); err != nil {
+			s.printf("\n  ! %v\n", err)
+			return i
+		}
+	}
+	return -1
+}
+
+// isRequired - a required field outside of the optional sections that were not started
+func (s *Session) isRequired(q *question) bool {
+	return schema.IsRequired(s.schema, instance(s.Answers), q.field.Name)
+}
+
+// printSections - the titles of the sections that the next field enters
+func (s *Session) printSections(previous, current *question) {
+	for depth, section := range current.sections {
+		if previous != nil && depth < len(previous.sections) && previous.sections[depth] == section {
+			continue
+		}
+		s.printf("\n%s== %s ==\n", strings.Repeat("  ", depth), title(section))
+	}
+}
+
+func (s *Session) readLine() (string, bool) {
+	if !s.input.Scan() {
+		return "", false
+	}
+	return strings.TrimRight(s.input.Text(), "\r"), true
+}
+
+func (s *Session) printf(format string, arguments ...any) {
+	_, _ = fmt.Fprintf(s.output, format, arguments...)
+}
+
+// collectQuestions - the named fields of the form in document order
+func collectQuestions(form *models.ContentNode) []question {
+	var questions []question
+
+	var visit func(node *models.ContentNode, sections []*models.ContentNode)
+	visit = func(node *models.ContentNode, sections []*models.ContentNode) {
+		switch node.ElementType {
+		case models.FieldElementType:
+			if node.Name != "" {
+				questions = append(questions, question{field: node, sections: sections})
+			}
+			return
+
+		case models.SectionElementType:
+			sections = append(sections[:len(sections):len(sections)], node)
+		}
+
+		for _, child := range node.Children {
+			visit(child, sections)
+		}
+	}
+
+	if form != nil {
+		visit(form, nil)
+	}
+	return questions
+}
+
+// instance - the answers as the object the flat schema describes
+func instance(answers models.ContentSubmission) map[string]any {
+	return schema.Instance(nil, &answers, models.FlatSchemaLayout)
+}
+
+// caption - the Caption of the field, its name when there is none
+func caption(field *models.ContentNode) string {
+	if value := childValue(field, models.CaptionElementType); value != "" {
+		return value
+	}
+	return field.Name
+}
+
+// title - the Title of the section, its name when there is none
+func title(section *models.ContentNode) string {
+	if value := childValue(section, models.TitleElementType); value != "" {
+		return value
+	}
+	if section.Name != "" {
+		return section.Name
+	}
+	return missingTitle
+}
+
+func childValue(node *models.ContentNode, elementType models.ElementType) string {
+	for _, child := range node.Children {
+		if child.ElementType == elementType {
+			return strings.TrimSpace(child.Value)
+		}
+	}
+	return ""
+}
+
+// lines - the number of lines of a textbox answer. E.g. Text([0,200],Lines:4)
+func lines(field *models.ContentNode) int {
+	value, err := strconv.Atoi(models.ParseTypeDefinition(field.Metadata["Type"]).Options[linesOption])
+	if err != nil {
+		return 1
+	}
+	return value
+}
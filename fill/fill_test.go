@@ -0,0 +1,106 @@
+package fill
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readForm(t *testing.T, fileName string) *models.ContentNode {
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	form, err := (&parsers.XMLParser{}).Parse(content)
+	require.NoError(t, err)
+	return form
+}
+
+// run - a session over the complex form with the lines as input, the saves are counted
+func run(t *testing.T, answers models.ContentSubmission, lines ...string) (*Session, string, int, error) {
+	var output bytes.Buffer
+	saves := 0
+	input := strings.NewReader(strings.Join(lines, "\n") + "\n")
+	session := NewSession(readForm(t, "../tests/payload/complex_valid_xml"), answers, input, &output,
+		func(models.ContentSubmission) error { saves++; return nil })
+
+	err := session.Run()
+	return session, output.String(), saves, err
+}
+
+func TestRun_AllFields(t *testing.T) {
+	// Act - the street takes two lines, the empty line ends it
+	session, output, saves, err := run(t, nil, "Ann", "1999-02-03", "2", "Main st", "2nd floor", "", "Romania", "", "ann@example.com")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentSubmission{
+		"user_name":  "Ann",
+		"birth_date": "1999-02-03",
+		"gender":     "F",
+		"street":     "Main st\n2nd floor",
+		"country":    "Romania",
+		"email":      "ann@example.com",
+	}, session.Answers)
+	assert.Equal(t, 7, saves)
+	assert.Contains(t, output, "== Personal Information ==")
+	assert.Contains(t, output, "  == Country and Region ==")
+	assert.Contains(t, output, "[3/7] Gender\n  1) Male\n  2) Female\n  3) Other\n")
+}
+
+func TestRun_InvalidAnswersAreAskedAgain(t *testing.T) {
+	// Act
+	session, output, _, err := run(t, nil, "", "Ann", "31/01/2000", "2000-01-31", "X", "m", ":quit")
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrFillIncomplete)
+	assert.Contains(t, output, "schema violation: /: missing required property user_name")
+	assert.Contains(t, output, "invalid date: 31/01/2000, expected YYYY-MM-DD")
+	assert.Contains(t, output, `schema violation: /gender: "X" is not one of M, F, O`)
+	assert.Equal(t, models.ContentSubmission{"user_name": "Ann", "birth_date": "2000-01-31", "gender": "M"}, session.Answers)
+}
+
+func TestRun_Back(t *testing.T) {
+	// Act - back to the name, Enter keeps the date
+	session, _, _, err := run(t, nil, "Ann", "2000-01-31", ":back", ":back", "Bob", "", ":quit")
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrFillIncomplete)
+	assert.Equal(t, models.ContentSubmission{"user_name": "Bob", "birth_date": "2000-01-31"}, session.Answers)
+}
+
+func TestRun_Resume(t *testing.T) {
+	// Act - starts at the first field without an answer
+	session, output, _, err := run(t, models.ContentSubmission{"user_name": "Ann", "birth_date": "2000-01-31"}, ":skip", ":quit")
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrFillIncomplete)
+	assert.NotContains(t, output, "[1/7]")
+	assert.Contains(t, output, "[3/7] Gender")
+	assert.Len(t, session.Answers, 2)
+}
+
+func TestRun_StartedSectionAsksForSkippedFields(t *testing.T) {
+	// Act - the street is skipped, answering the country starts the address section and makes it required
+	session, output, _, err := run(t, models.ContentSubmission{"user_name": "Ann", "birth_date": "2000-01-31", "gender": "M"},
+		"", "Romania", "", "", "Main st", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, output, "schema violation: /: missing required property street")
+	assert.Equal(t, "Main st", session.Answers["street"])
+}
+
+func TestRun_EndOfInput(t *testing.T) {
+	// Act
+	_, _, saves, err := run(t, nil, "Ann")
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrFillIncomplete)
+	assert.Equal(t, 1, saves)
+}
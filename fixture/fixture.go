@@ -0,0 +1,155 @@
+package fixture
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var loremWords = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor
+	incididunt ut labore et dolore magna aliqua ut enim ad minim veniam quis nostrud exercitation ullamco laboris`)
+
+var defaultTextLength = 26 // Lorem ipsum dolor sit amet
+var maxRandomTextLength = 200
+var sampleDate = time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC)
+var randomDateFrom = time.Date(1950, time.January, 1, 0, 0, 0, 0, time.UTC)
+var randomDateTo = time.Date(2010, time.December, 31, 0, 0, 0, 0, time.UTC)
+var dateLayout = "2006-01-02"
+var sampleFileName = "document"
+
+// Options - what the submission template contains
+type Options struct {
+	// RequiredOnly leaves out the fields that a valid submission can skip
+	RequiredOnly bool
+	// Random picks the values, when set. The same seed gives the same submission.
+	Random *rand.Rand
+}
+
+/* The value of a field comes from its type:
+   * Select - the first label, or a random one
+   * TextBox - lorem text with a length inside the Text([min,max]) range. A Date type gets a date (YYYY-MM-DD).
+   * File - a file name, an image for File(Images)
+
+   With RequiredOnly, only the required fields that are outside of optional sections are kept.
+   That is the smallest submission that is still valid for the form.
+*/
+
+// Generate - a submission with a value for every field of the form
+func Generate(form *models.ContentNode, options Options) models.ContentSubmission {
+	submission := models.ContentSubmission{}
+
+	var visit func(node *models.ContentNode, enforced bool)
+	visit = func(node *models.ContentNode, enforced bool) {
+		switch node.ElementType {
+		case models.FieldElementType:
+			if node.Name == "" || (options.RequiredOnly && !(enforced && node.IsRequired())) {
+				return
+			}
+			submission[node.Name] = value(node, options.Random)
+			return
+
+		case models.SectionElementType:
+			if node.BoolMetadata("Optional") {
+				enforced = false
+			}
+		}
+
+		for _, child := range node.Children {
+			visit(child, enforced)
+		}
+	}
+
+	if form != nil {
+		visit(form, true)
+	}
+	return submission
+}
+
+func value(field *models.ContentNode, random *rand.Rand) string {
+	definition := models.ParseTypeDefinition(field.Metadata["Type"])
+
+	switch models.SafeReadFieldType(field.Metadata["FieldType"]) {
+	case models.SelectFieldType:
+		options := optionNames(field, definition)
+		if len(options) == 0 {
+			return ""
+		}
+		if random != nil {
+			return options[random.Intn(len(options))]
+		}
+		return options[0]
+
+	case models.FileFieldType:
+		if definition.HasArgument("Images") {
+			return sampleFileName + ".png"
+		}
+		return sampleFileName + ".pdf"
+
+	case models.TextboxFieldType:
+		if strings.EqualFold(definition.Name, "Date") {
+			return date(random)
+		}
+		return lorem(definition, random)
+	}
+
+	return ""
+}
+
+// optionNames - the label names, or the Enumeration members when there are no labels
+func optionNames(field *models.ContentNode, definition models.TypeDefinition) []string {
+	var names []string
+	for _, option := range field.Options() {
+		names = append(names, option.Name)
+	}
+	if len(names) == 0 && strings.EqualFold(definition.Name, "Enumeration") {
+		names = definition.Arguments
+	}
+	return names
+}
+
+// lorem - lorem text with a length inside the range of the type
+func lorem(definition models.TypeDefinition, random *rand.Rand) string {
+	minimum, maximum, ok := definition.LengthRange()
+	if !ok {
+		minimum, maximum = 0, maxRandomTextLength
+	}
+	minimum = max(minimum, 1)
+	if maximum < minimum {
+		return ""
+	}
+
+	length := min(max(defaultTextLength, minimum), maximum)
+	if random != nil {
+		upper := min(maximum, max(minimum, maxRandomTextLength))
+		length = minimum + random.Intn(upper-minimum+1)
+	}
+
+	var text strings.Builder
+	for i := 0; text.Len() < length; i++ {
+		if text.Len() > 0 {
+			text.WriteString(" ")
+		}
+		word := loremWords[i%len(loremWords)]
+		if random != nil {
+			word = loremWords[random.Intn(len(loremWords))]
+		}
+		text.WriteString(word)
+	}
+
+	result := strings.TrimSpace(text.String()[:length])
+	if len(result) < minimum {
+		result += strings.Repeat(".", minimum-len(result))
+	}
+	return strings.ToUpper(result[:1]) + result[1:]
+}
+
+// date - the sample date, or a random one
+func date(random *rand.Rand) string {
+	if random == nil {
+		return sampleDate.Format(dateLayout)
+	}
+	days := int(randomDateTo.Sub(randomDateFrom).Hours() / 24)
+	return randomDateFrom.AddDate(0, 0, random.Intn(days+1)).Format(dateLayout)
+}
@@ -0,0 +1,111 @@
+package fixture
+
+import (
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readForm(t *testing.T, fileName string) *models.ContentNode {
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	form, err := (&parsers.XMLParser{}).Parse(content)
+	require.NoError(t, err)
+	return form
+}
+
+func TestGenerate(t *testing.T) {
+	// Act
+	submission := Generate(readForm(t, "../tests/payload/complex_valid_xml"), Options{})
+
+	// Assert
+	expected := models.ContentSubmission{
+		"user_name":  "Lorem ipsum dolor sit amet",
+		"birth_date": "2000-01-31",
+		"gender":     "M",
+		"street":     "Lorem ipsum dolor sit amet",
+		"country":    "Lorem ipsum dolor sit amet",
+		"region":     "Lorem ipsum dolor sit amet",
+		"email":      "Lorem ipsum dolor sit amet",
+	}
+	assert.Equal(t, expected, submission)
+}
+
+func TestGenerate_RequiredOnly(t *testing.T) {
+	// Act
+	submission := Generate(readForm(t, "../tests/payload/complex_valid_xml"), Options{RequiredOnly: true})
+
+	// Assert - the required fields of the optional address section are left out
+	assert.Equal(t, []string{"birth_date", "user_name"}, sortedNames(submission))
+}
+
+func TestGenerate_Random(t *testing.T) {
+	form := readForm(t, "../tests/payload/complex_valid_xml")
+
+	// Act
+	first := Generate(form, Options{Random: rand.New(rand.NewSource(42))})
+	second := Generate(form, Options{Random: rand.New(rand.NewSource(42))})
+	other := Generate(form, Options{Random: rand.New(rand.NewSource(7))})
+
+	// Assert
+	assert.Equal(t, first, second, "the same seed gives the same submission")
+	assert.NotEqual(t, first, other)
+	assert.Contains(t, []string{"M", "F", "O"}, first["gender"])
+}
+
+func TestGenerate_IsValid(t *testing.T) {
+	files := []string{"../tests/payload/valid_xml", "../tests/payload/complex_valid_xml"}
+	for _, fileName := range files {
+		form := readForm(t, fileName)
+		for seed := int64(0); seed < 20; seed++ {
+			options := []Options{{}, {RequiredOnly: true}, {Random: rand.New(rand.NewSource(seed))}}
+			for _, option := range options {
+				submission := Generate(form, option)
+				assert.NoError(t, schema.ValidateSubmission(form, &submission), fileName)
+			}
+		}
+	}
+}
+
+func TestGenerate_FieldTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    map[string]string
+		expected string
+	}{
+		{"file", map[string]string{"FieldType": "File", "Type": "File"}, "document.pdf"},
+		{"image", map[string]string{"FieldType": "File", "Type": "File(Images,MaxWidth:80)"}, "document.png"},
+		{"enumeration without labels", map[string]string{"FieldType": "Select", "Type": "Enumeration(X,Y)"}, "X"},
+		{"short text", map[string]string{"FieldType": "TextBox", "Type": "Text([0,5])"}, "Lorem"},
+		{"long minimum", map[string]string{"FieldType": "TextBox", "Type": "Text([30,40])"}, "Lorem ipsum dolor sit amet con"},
+		{"unknown", map[string]string{"FieldType": "Slider"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+				{ElementType: models.FieldElementType, Name: "field", Metadata: tt.field},
+			}}
+
+			submission := Generate(form, Options{})
+
+			assert.Equal(t, tt.expected, submission["field"])
+		})
+	}
+}
+
+func sortedNames(submission models.ContentSubmission) []string {
+	var names []string
+	for name := range submission {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
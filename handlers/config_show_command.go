@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
+	"gopkg.in/yaml.v3"
+)
+
+var maskedValue = "********"
+
+type ConfigShowCommandHandler struct {
+	Config *config.ConfigShowOptions
+	Writer io.Writer
+	// LookupEnv reads the environment variables - os.LookupEnv
+	LookupEnv func(string) (string, bool)
+}
+
+func NewConfigShowCommandHandler(writer io.Writer, config *config.ConfigShowOptions) *ConfigShowCommandHandler {
+	return &ConfigShowCommandHandler{
+		Config:    config,
+		Writer:    writer,
+		LookupEnv: os.LookupEnv,
+	}
+}
+
+// Handle - writes the effective settings of the command as YAML, with where every value comes from
+func (r *ConfigShowCommandHandler) Handle() error {
+	file, err := config.LoadSettingsFile(r.Config.ConfigPath, r.LookupEnv)
+	if err != nil {
+		logging.Log.Errorf("Error reading settings file: %v", err)
+		return err
+	}
+
+	settings, err := config.ApplySettings(r.Config.Flags, r.Config.Command, file, r.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	header := fmt.Sprintf("Settings of the %s command, no settings file", r.Config.Command)
+	if file != nil {
+		header = fmt.Sprintf("Settings of the %s command, settings file %s", r.Config.Command, file.Path)
+	}
+
+	document := &yaml.Node{Kind: yaml.MappingNode, HeadComment: header}
+	for _, setting := range settings {
+		comment := string(setting.Source)
+		if setting.Origin != "" {
+			comment += " " + setting.Origin
+		}
+		document.Content = append(document.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: setting.Name},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: displaySetting(setting), LineComment: comment})
+	}
+
+	encoder := yaml.NewEncoder(r.Writer)
+	encoder.SetIndent(2)
+	if err = encoder.Encode(document); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// displaySetting - the value of the setting, passwords are masked unless they point to env:NAME or file:PATH
+func displaySetting(setting config.Setting) string {
+	isReference := strings.HasPrefix(setting.Value, "env:") || strings.HasPrefix(setting.Value, "file:")
+	if strings.Contains(setting.Name, "password") && setting.Value != "" && !isReference {
+		return maskedValue
+	}
+	return setting.Value
+}
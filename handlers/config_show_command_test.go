@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigShowHandle(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), ".formparser.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("to: pdf\nowner-password: secret\n"), 0644))
+
+	flags := pflag.NewFlagSet("parse", pflag.ContinueOnError)
+	flags.String("from", "", "")
+	flags.String("to", "", "")
+	flags.String("owner-password", "", "")
+	flags.String("user-password", "", "")
+
+	var output bytes.Buffer
+	handler := NewConfigShowCommandHandler(&output, &config.ConfigShowOptions{Command: "parse", Flags: flags, ConfigPath: path})
+	handler.LookupEnv = func(name string) (string, bool) {
+		if name == "FORMPARSER_USER_PASSWORD" {
+			return "env:APPLICANT_PASSWORD", true
+		}
+		return "", false
+	}
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "# Settings of the parse command, settings file "+path+"\n"+
+		"from: # default\n"+
+		"owner-password: '********' # file "+path+"\n"+
+		"to: pdf # file "+path+"\n"+
+		"user-password: env:APPLICANT_PASSWORD # env FORMPARSER_USER_PASSWORD\n", output.String())
+}
+
+func TestConfigShowHandle_MissingFile(t *testing.T) {
+	// Arrange
+	handler := NewConfigShowCommandHandler(&bytes.Buffer{}, &config.ConfigShowOptions{
+		Command: "parse", Flags: pflag.NewFlagSet("parse", pflag.ContinueOnError), ConfigPath: "missing.yaml",
+	})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+)
+
+type DiffFormsCommandHandler struct {
+	Config   *config.DiffFormsOptions
+	Reader   reader.Reader
+	Parser   parsers.Parser
+	Renderer render.FormDiffRenderer
+}
+
+func NewDiffFormsCommandHandler(reader reader.Reader, parser parsers.Parser, renderer render.FormDiffRenderer, config *config.DiffFormsOptions) *DiffFormsCommandHandler {
+	return &DiffFormsCommandHandler{
+		Config:   config,
+		Reader:   reader,
+		Parser:   parser,
+		Renderer: renderer,
+	}
+}
+
+func (r *DiffFormsCommandHandler) Handle() error {
+	oldForm, err := r.readForm(r.Config.OldFileName)
+	if err != nil {
+		return err
+	}
+
+	newForm, err := r.readForm(r.Config.NewFileName)
+	if err != nil {
+		return err
+	}
+
+	// Compare the fields and sections by name and render the changes
+	changes := diff.CompareForms(oldForm, newForm)
+	err = r.Renderer.RenderFormDiff(oldForm, newForm, changes)
+	if err != nil {
+		logging.Log.Errorf("Error rendering the differences of %s and %s to %s: %v", r.Config.OldFileName, r.Config.NewFileName, r.Config.ToType, err)
+		return err
+	}
+
+	return nil
+}
+
+// readForm - reads and parses a form file, an empty one is an error
+func (r *DiffFormsCommandHandler) readForm(fileName string) (*models.ContentNode, error) {
+	fileContent, err := r.Reader.ReadBinary(fileName)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return nil, err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", fileName)
+		logging.Log.Error(err)
+		return nil, err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file %s: %v", fileName, err)
+		return nil, err
+	}
+
+	return form, nil
+}
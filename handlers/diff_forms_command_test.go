@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFormDiffRenderer struct {
+	renderError error
+	rendered    bool
+	changes     []diff.FormChange
+}
+
+func (r *fakeFormDiffRenderer) RenderFormDiff(_, _ *models.ContentNode, changes []diff.FormChange) error {
+	r.rendered = true
+	r.changes = changes
+	return r.renderError
+}
+
+func newDiffFormsHandler(reader *fakeReader, parser *fakeParser, renderer *fakeFormDiffRenderer) *DiffFormsCommandHandler {
+	return NewDiffFormsCommandHandler(reader, parser, renderer, &config.DiffFormsOptions{
+		OldFileName: "form_v1.xml",
+		NewFileName: "form_v2.xml",
+		ToType:      models.TextFileType,
+	})
+}
+
+func TestDiffFormsHandle_HappyPath(t *testing.T) {
+	// Arrange
+	renderer := &fakeFormDiffRenderer{}
+	handler := newDiffFormsHandler(&fakeReader{fileContent: []byte("some xml")}, &fakeParser{}, renderer)
+
+	// Act
+	err := handler.Handle()
+
+	// Assert - the same form on both sides has no changes
+	require.NoError(t, err)
+	assert.True(t, renderer.rendered)
+	assert.Empty(t, renderer.changes)
+}
+
+func TestDiffFormsHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		parser   *fakeParser
+		renderer *fakeFormDiffRenderer
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, &fakeParser{}, &fakeFormDiffRenderer{}, "read file error"},
+		{"empty file", &fakeReader{}, &fakeParser{}, &fakeFormDiffRenderer{}, "file form_v1.xml is empty"},
+		{"parse", &fakeReader{fileContent: []byte("x")}, &fakeParser{parseError: errors.New("parse error")}, &fakeFormDiffRenderer{}, "parse error"},
+		{"render", &fakeReader{fileContent: []byte("x")}, &fakeParser{}, &fakeFormDiffRenderer{renderError: errors.New("render error")}, "render error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newDiffFormsHandler(tt.reader, tt.parser, tt.renderer).Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
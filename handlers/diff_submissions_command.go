@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+)
+
+type DiffSubmissionsCommandHandler struct {
+	Config   *config.DiffSubmissionsOptions
+	Reader   reader.Reader
+	Parser   parsers.Parser
+	Renderer render.DiffRenderer
+}
+
+func NewDiffSubmissionsCommandHandler(reader reader.Reader, parser parsers.Parser, renderer render.DiffRenderer, config *config.DiffSubmissionsOptions) *DiffSubmissionsCommandHandler {
+	return &DiffSubmissionsCommandHandler{
+		Config:   config,
+		Reader:   reader,
+		Parser:   parser,
+		Renderer: renderer,
+	}
+}
+
+func (r *DiffSubmissionsCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	oldSubmission, err := r.readSubmission(r.Config.OldSubmissionFileName)
+	if err != nil {
+		return err
+	}
+
+	newSubmission, err := r.readSubmission(r.Config.NewSubmissionFileName)
+	if err != nil {
+		return err
+	}
+
+	// Compare the answers of every field and render the differences
+	changes := diff.CompareSubmissions(form, oldSubmission, newSubmission)
+	err = r.Renderer.RenderDiff(form, changes)
+	if err != nil {
+		logging.Log.Errorf("Error rendering the differences of %s to %s: %v", r.Config.Filename, r.Config.ToType, err)
+		return err
+	}
+
+	return nil
+}
+
+// readSubmission - reads a submission file, an empty one is an error
+func (r *DiffSubmissionsCommandHandler) readSubmission(fileName string) (*models.ContentSubmission, error) {
+	submission, err := r.Reader.ReadSubmissionFile(fileName)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return nil, err
+	}
+
+	if submission == nil {
+		err = fmt.Errorf("submission file %s is empty", fileName)
+		logging.Log.Error(err)
+		return nil, err
+	}
+
+	return submission, nil
+}
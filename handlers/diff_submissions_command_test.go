@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDiffRenderer struct {
+	renderError error
+	changes     []diff.FieldChange
+}
+
+func (r *fakeDiffRenderer) RenderDiff(_ *models.ContentNode, changes []diff.FieldChange) error {
+	r.changes = changes
+	return r.renderError
+}
+
+func newDiffSubmissionsHandler(reader *fakeReader, parser *fakeParser, renderer *fakeDiffRenderer) *DiffSubmissionsCommandHandler {
+	return NewDiffSubmissionsCommandHandler(reader, parser, renderer, &config.DiffSubmissionsOptions{
+		Filename:              "form.xml",
+		OldSubmissionFileName: "old.json",
+		NewSubmissionFileName: "new.json",
+		ToType:                models.HTMLFileType,
+	})
+}
+
+func TestDiffSubmissionsHandle_HappyPath(t *testing.T) {
+	// Arrange
+	renderer := &fakeDiffRenderer{}
+	handler := newDiffSubmissionsHandler(&fakeReader{fileContent: []byte("some xml"), submissionData: &models.ContentSubmission{}}, &fakeParser{}, renderer)
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, renderer.changes)
+}
+
+func TestDiffSubmissionsHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		parser   *fakeParser
+		renderer *fakeDiffRenderer
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, &fakeParser{}, &fakeDiffRenderer{}, "read file error"},
+		{"empty file", &fakeReader{}, &fakeParser{}, &fakeDiffRenderer{}, "is empty"},
+		{"parse", &fakeReader{fileContent: []byte("x")}, &fakeParser{parseError: errors.New("parse error")}, &fakeDiffRenderer{}, "parse error"},
+		{"read submission", &fakeReader{fileContent: []byte("x"), submissionError: errors.New("submission error")}, &fakeParser{}, &fakeDiffRenderer{}, "submission error"},
+		{"empty submission", &fakeReader{fileContent: []byte("x")}, &fakeParser{}, &fakeDiffRenderer{}, "submission file old.json is empty"},
+		{"render", &fakeReader{fileContent: []byte("x"), submissionData: &models.ContentSubmission{}}, &fakeParser{}, &fakeDiffRenderer{renderError: errors.New("render error")}, "render error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newDiffSubmissionsHandler(tt.reader, tt.parser, tt.renderer).Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/fill"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+)
+
+type FillCommandHandler struct {
+	Config *config.FillOptions
+	Reader reader.Reader
+	Parser parsers.Parser
+	// Renderer of the answers at the end, nil when no PDF is wanted
+	Renderer render.Renderer
+	Input    io.Reader
+	Output   io.Writer
+}
+
+func NewFillCommandHandler(reader reader.Reader, parser parsers.Parser, renderer render.Renderer, input io.Reader, output io.Writer, config *config.FillOptions) *FillCommandHandler {
+	return &FillCommandHandler{
+		Config:   config,
+		Reader:   reader,
+		Parser:   parser,
+		Renderer: renderer,
+		Input:    input,
+		Output:   output,
+	}
+}
+
+// Handle - asks for the answers of the form and saves them after every answer
+func (r *FillCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	answers := models.ContentSubmission{}
+	if r.Config.Resume {
+		saved, err := r.Reader.ReadSubmissionFile(r.Config.OutPath)
+		if err != nil {
+			logging.Log.Errorf("error reading saved answers: %v", err)
+			return err
+		}
+		if saved != nil {
+			answers = *saved
+		}
+	}
+
+	session := fill.NewSession(form, answers, r.Input, r.Output, r.save)
+	err = session.Run()
+	if errors.Is(err, myerrors.ErrFillIncomplete) {
+		_, err = fmt.Fprintf(r.Output, "\nProgress saved to %s, continue with --resume\n", r.Config.OutPath)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(r.Output, "\nAll the answers are saved to %s\n", r.Config.OutPath); err != nil {
+		return err
+	}
+
+	if r.Renderer == nil {
+		return nil
+	}
+	return r.Renderer.Render(form, &session.Answers)
+}
+
+// save - writes the answers with the same indentation as the submission files in the repo
+func (r *FillCommandHandler) save(answers models.ContentSubmission) error {
+	content, err := json.MarshalIndent(answers, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.Config.OutPath, append(content, '\n'), 0644)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillHandle_HappyPath(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	outPath := filepath.Join(t.TempDir(), "answers.json")
+	handler := NewFillCommandHandler(&fakeReader{fileContent: []byte("some xml")}, &formParser{}, &fakeRenderer{},
+		strings.NewReader("2\n"), &output, &config.FillOptions{Filename: "form.xml", OutPath: outPath})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n    \"language\": \"B\"\n}\n", string(content))
+	assert.Contains(t, output.String(), "All the answers are saved to "+outPath)
+}
+
+func TestFillHandle_Quit(t *testing.T) {
+	// Arrange - the renderer would fail, it is not called for partial answers
+	var output bytes.Buffer
+	outPath := filepath.Join(t.TempDir(), "answers.json")
+	handler := NewFillCommandHandler(&fakeReader{fileContent: []byte("some xml")}, &formParser{}, &fakeRenderer{renderError: errors.New("render error")},
+		strings.NewReader(":quit\n"), &output, &config.FillOptions{Filename: "form.xml", OutPath: outPath})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "Progress saved to "+outPath+", continue with --resume")
+}
+
+func TestFillHandle_Resume(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	handler := NewFillCommandHandler(&fakeReader{fileContent: []byte("some xml"), submissionData: &models.ContentSubmission{"language": "A"}}, &formParser{}, nil,
+		strings.NewReader(""), &output, &config.FillOptions{Filename: "form.xml", OutPath: filepath.Join(t.TempDir(), "answers.json"), Resume: true})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert - all the answers were saved before, nothing is asked
+	require.NoError(t, err)
+	assert.NotContains(t, output.String(), "[1/1]")
+}
+
+func TestFillHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		parser   *fakeParser
+		resume   bool
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, &fakeParser{}, false, "read file error"},
+		{"empty file", &fakeReader{}, &fakeParser{}, false, "file form.xml is empty"},
+		{"parse", &fakeReader{fileContent: []byte("x")}, &fakeParser{parseError: errors.New("parse error")}, false, "parse error"},
+		{"saved answers", &fakeReader{fileContent: []byte("x"), submissionError: errors.New("saved answers error")}, &fakeParser{}, true, "saved answers error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewFillCommandHandler(tt.reader, tt.parser, nil, strings.NewReader(""), &bytes.Buffer{},
+				&config.FillOptions{Filename: "form.xml", OutPath: "answers.json", Resume: tt.resume})
+
+			err := handler.Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alex-pricope/form-parser/codegen"
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+)
+
+type GenCommandHandler struct {
+	Config    *config.GenOptions
+	Reader    reader.Reader
+	Parser    parsers.Parser
+	Generator codegen.Generator
+	Writer    io.Writer
+}
+
+func NewGenCommandHandler(reader reader.Reader, parser parsers.Parser, generator codegen.Generator, writer io.Writer, config *config.GenOptions) *GenCommandHandler {
+	return &GenCommandHandler{
+		Config:    config,
+		Reader:    reader,
+		Parser:    parser,
+		Generator: generator,
+		Writer:    writer,
+	}
+}
+
+// Handle - writes the typed code of the submissions of the form
+func (r *GenCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	code, err := r.Generator.Generate(form, codegen.Options{Source: r.Config.Filename, Package: r.Config.Package, TypeName: r.Config.TypeName})
+	if err != nil {
+		logging.Log.Errorf("Error generating code: %v", err)
+		return err
+	}
+
+	_, err = r.Writer.Write(code)
+	return err
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/codegen"
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenHandle_HappyPath(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	handler := NewGenCommandHandler(&fakeReader{fileContent: []byte("some xml")}, &formParser{}, &codegen.GoGenerator{}, &output,
+		&config.GenOptions{Filename: "form.xml", Package: "forms", TypeName: "Submission"})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "// Code generated by form-parser gen from form.xml. DO NOT EDIT.")
+	assert.Contains(t, output.String(), "Language Language `json:\"language,omitempty\"`")
+	assert.Contains(t, output.String(), "LanguageA Language = \"A\" // Go")
+}
+
+func TestGenHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		parser   *fakeParser
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, &fakeParser{}, "read file error"},
+		{"empty file", &fakeReader{}, &fakeParser{}, "file form.xml is empty"},
+		{"parse", &fakeReader{fileContent: []byte("x")}, &fakeParser{parseError: errors.New("parse error")}, "parse error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewGenCommandHandler(tt.reader, tt.parser, &codegen.GoGenerator{}, &bytes.Buffer{}, &config.GenOptions{Filename: "form.xml"})
+
+			err := handler.Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/fixture"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+)
+
+type InitSubmissionCommandHandler struct {
+	Config *config.InitSubmissionOptions
+	Reader reader.Reader
+	Parser parsers.Parser
+	Writer io.Writer
+}
+
+func NewInitSubmissionCommandHandler(reader reader.Reader, parser parsers.Parser, writer io.Writer, config *config.InitSubmissionOptions) *InitSubmissionCommandHandler {
+	return &InitSubmissionCommandHandler{
+		Config: config,
+		Reader: reader,
+		Parser: parser,
+		Writer: writer,
+	}
+}
+
+// Handle - writes a submission with a value for every field of the form
+func (r *InitSubmissionCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	options := fixture.Options{RequiredOnly: r.Config.RequiredOnly}
+	if r.Config.Random {
+		options.Random = rand.New(rand.NewSource(r.Config.Seed))
+	}
+
+	// Same indentation as the submission files in the repo
+	encoder := json.NewEncoder(r.Writer)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(fixture.Generate(form, options))
+}
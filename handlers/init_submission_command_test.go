@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitSubmissionHandle_HappyPath(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	handler := NewInitSubmissionCommandHandler(&fakeReader{fileContent: []byte("some xml")}, &formParser{}, &output, &config.InitSubmissionOptions{Filename: "form.xml"})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "{\n    \"language\": \"A\"\n}\n", output.String())
+}
+
+func TestInitSubmissionHandle_Random(t *testing.T) {
+	// Arrange
+	render := func(seed int64) map[string]string {
+		var output bytes.Buffer
+		handler := NewInitSubmissionCommandHandler(&fakeReader{fileContent: []byte("some xml")}, &formParser{}, &output,
+			&config.InitSubmissionOptions{Filename: "form.xml", Random: true, Seed: seed})
+		require.NoError(t, handler.Handle())
+
+		var submission map[string]string
+		require.NoError(t, json.Unmarshal(output.Bytes(), &submission))
+		return submission
+	}
+
+	// Act
+	first, second := render(3), render(3)
+
+	// Assert
+	assert.Equal(t, first, second)
+	assert.Contains(t, []string{"A", "B"}, first["language"])
+}
+
+func TestInitSubmissionHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		parser   *fakeParser
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, &fakeParser{}, "read file error"},
+		{"empty file", &fakeReader{}, &fakeParser{}, "file form.xml is empty"},
+		{"parse", &fakeReader{fileContent: []byte("x")}, &fakeParser{parseError: errors.New("parse error")}, "parse error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewInitSubmissionCommandHandler(tt.reader, tt.parser, &bytes.Buffer{}, &config.InitSubmissionOptions{Filename: "form.xml"})
+
+			err := handler.Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
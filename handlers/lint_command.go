@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/lint"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/render"
+)
+
+type LintCommandHandler struct {
+	Config   *config.LintOptions
+	Reader   reader.Reader
+	Renderer render.LintRenderer
+}
+
+func NewLintCommandHandler(reader reader.Reader, renderer render.LintRenderer, config *config.LintOptions) *LintCommandHandler {
+	return &LintCommandHandler{
+		Config:   config,
+		Reader:   reader,
+		Renderer: renderer,
+	}
+}
+
+// Handle - renders the problems of the form. The lint fails (with an error) when at least one problem is an error.
+func (r *LintCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	lintConfig, err := r.readConfig()
+	if err != nil {
+		return err
+	}
+
+	report, err := lint.Lint(fileContent, lintConfig)
+	if err != nil {
+		logging.Log.Errorf("Error reading form %s: %v", r.Config.Filename, err)
+		return err
+	}
+
+	err = r.Renderer.RenderLint(report)
+	if err != nil {
+		logging.Log.Errorf("Error rendering the lint problems to %s: %v", r.Config.ToType, err)
+		return err
+	}
+
+	if report.HasErrors() {
+		return fmt.Errorf("%w: %s", myerrors.ErrLintErrors, report)
+	}
+	return nil
+}
+
+// readConfig - the lint config file, or nil when there is none
+func (r *LintCommandHandler) readConfig() (*lint.Config, error) {
+	if r.Config.ConfigFileName == "" {
+		return nil, nil
+	}
+
+	content, err := r.Reader.ReadBinary(r.Config.ConfigFileName)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return nil, err
+	}
+
+	lintConfig, err := lint.ReadConfig(content)
+	if err != nil {
+		logging.Log.Errorf("Error reading lint config %s: %v", r.Config.ConfigFileName, err)
+		return nil, err
+	}
+	return lintConfig, nil
+}
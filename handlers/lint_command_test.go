@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLintRenderer struct {
+	renderError error
+	report      *lint.Report
+}
+
+func (r *fakeLintRenderer) RenderLint(report *lint.Report) error {
+	r.report = report
+	return r.renderError
+}
+
+func TestLintHandle_HappyPath(t *testing.T) {
+	// Arrange
+	renderer := &fakeLintRenderer{}
+	reader := &fakeReader{fileContent: []byte(`<Form><Field Name="a" FieldType="TextBox"/></Form>`)}
+	handler := NewLintCommandHandler(reader, renderer, &config.LintOptions{Filename: "form.xml"})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert - a warning does not fail the lint
+	require.NoError(t, err)
+	require.Len(t, renderer.report.Problems, 1)
+	assert.Equal(t, "FP002", renderer.report.Problems[0].Rule.ID)
+}
+
+func TestLintHandle_LintErrors(t *testing.T) {
+	// Arrange
+	renderer := &fakeLintRenderer{}
+	reader := &fakeReader{fileContent: []byte(`<Form><Field Name="a"><Caption>A</Caption></Field></Form>`)}
+	handler := NewLintCommandHandler(reader, renderer, &config.LintOptions{Filename: "form.xml"})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert - the problems are rendered before failing
+	assert.ErrorIs(t, err, myerrors.ErrLintErrors)
+	assert.ErrorContains(t, err, "1 error, 0 warnings, 0 infos")
+	assert.Len(t, renderer.report.Problems, 1)
+}
+
+func TestLintHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		reader     *fakeReader
+		configFile string
+		renderer   *fakeLintRenderer
+		expected   string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, "", &fakeLintRenderer{}, "read file error"},
+		{"empty file", &fakeReader{}, "", &fakeLintRenderer{}, "file form.xml is empty"},
+		{"invalid xml", &fakeReader{fileContent: []byte("<Form>")}, "", &fakeLintRenderer{}, "XML syntax error"},
+		{"invalid config", &fakeReader{fileContent: []byte("<Form/>")}, "lint.yaml", &fakeLintRenderer{}, "invalid lint config"},
+		{"render", &fakeReader{fileContent: []byte("<Form/>")}, "", &fakeLintRenderer{renderError: errors.New("render error")}, "render error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewLintCommandHandler(tt.reader, tt.renderer, &config.LintOptions{Filename: "form.xml", ConfigFileName: tt.configFile})
+
+			err := handler.Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
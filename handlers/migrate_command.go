@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/migration"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/schema"
+)
+
+// MigratedFileSuffix - the suffix of the migrated submissions, they are skipped when their folder is migrated again
+var MigratedFileSuffix = "_migrated"
+
+type MigrateCommandHandler struct {
+	Config *config.MigrateOptions
+	Reader reader.Reader
+	Parser parsers.Parser
+}
+
+func NewMigrateCommandHandler(reader reader.Reader, parser parsers.Parser, config *config.MigrateOptions) *MigrateCommandHandler {
+	return &MigrateCommandHandler{
+		Config: config,
+		Reader: reader,
+		Parser: parser,
+	}
+}
+
+/* Every submission is migrated and validated against the new form on its own. A submission that fails
+   is reported and not written, the others are still migrated. The command fails when at least one did.
+*/
+
+func (r *MigrateCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	migrationContent, err := r.Reader.ReadBinary(r.Config.MigrationFileName)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	steps, err := migration.ReadMigration(migrationContent)
+	if err != nil {
+		logging.Log.Errorf("Error reading migration %s: %v", r.Config.MigrationFileName, err)
+		return err
+	}
+
+	if len(r.Config.SubmissionFileNames) == 0 {
+		err = fmt.Errorf("no submission files to migrate")
+		logging.Log.Error(err)
+		return err
+	}
+
+	failed := 0
+	for _, fileName := range r.Config.SubmissionFileNames {
+		if err = r.migrate(form, steps, fileName); err != nil {
+			logging.Log.Errorf("Error migrating %s: %v", fileName, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d submissions could not be migrated", failed, len(r.Config.SubmissionFileNames))
+	}
+
+	logging.Log.Infof("Migrated %d submissions", len(r.Config.SubmissionFileNames))
+	return nil
+}
+
+// migrate - applies the migration to one submission and writes it when it is valid for the new form
+func (r *MigrateCommandHandler) migrate(form *models.ContentNode, steps *migration.Migration, fileName string) error {
+	submission, err := r.Reader.ReadSubmissionFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	migrated, err := steps.Apply(submission)
+	if err != nil {
+		return err
+	}
+
+	err = schema.ValidateSubmission(form, migrated)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(migrated, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.outputFilePath(fileName), content, 0644)
+}
+
+// outputFilePath - E.g. out/submission_migrated.json
+func (r *MigrateCommandHandler) outputFilePath(fileName string) string {
+	baseName := strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName)) + MigratedFileSuffix + ".json"
+	if r.Config.OutputDir != "" {
+		return filepath.Join(r.Config.OutputDir, baseName)
+	}
+	return filepath.Join(filepath.Dir(fileName), baseName)
+}
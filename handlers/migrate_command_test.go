@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var renameMigration = []byte("renames:\n  program_language: language\n")
+
+// formParser - a parser that gives a form with one select field: language (A or B)
+type formParser struct{}
+
+func (p *formParser) Parse(_ []byte) (*models.ContentNode, error) {
+	return &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		{ElementType: models.FieldElementType, Name: "language", Metadata: map[string]string{"FieldType": "Select", "Optional": "False"}, Children: []*models.ContentNode{
+			{ElementType: models.LabelsElementType, Children: []*models.ContentNode{
+				{ElementType: models.LabelElementType, Metadata: map[string]string{"Name": "A"}, Value: "Go"},
+				{ElementType: models.LabelElementType, Metadata: map[string]string{"Name": "B"}, Value: "C#"},
+			}},
+		}},
+	}}, nil
+}
+
+func newMigrateHandler(reader *fakeReader, dir string) *MigrateCommandHandler {
+	return NewMigrateCommandHandler(reader, &formParser{}, &config.MigrateOptions{
+		Filename:            "form_v2.xml",
+		MigrationFileName:   "migration.yaml",
+		SubmissionFileNames: []string{"submissions/jane.json"},
+		OutputDir:           dir,
+	})
+}
+
+func TestMigrateHandle_HappyPath(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	reader := &fakeReader{fileContent: renameMigration, submissionData: &models.ContentSubmission{"program_language": "B"}}
+
+	// Act
+	err := newMigrateHandler(reader, dir).Handle()
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "jane_migrated.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"language": "B"}`, string(content))
+}
+
+func TestMigrateHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		files    []string
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, []string{"a.json"}, "read file error"},
+		{"empty file", &fakeReader{}, []string{"a.json"}, "file form_v2.xml is empty"},
+		{"invalid migration", &fakeReader{fileContent: []byte("rename: x")}, []string{"a.json"}, "invalid migration"},
+		{"no submissions", &fakeReader{fileContent: renameMigration}, nil, "no submission files to migrate"},
+		{"read submission", &fakeReader{fileContent: renameMigration, submissionError: errors.New("submission error")}, []string{"a.json", "b.json"}, "2 of 2 submissions could not be migrated"},
+		{"invalid for the new form", &fakeReader{fileContent: renameMigration, submissionData: &models.ContentSubmission{"program_language": "C"}}, []string{"a.json"}, "1 of 1 submissions could not be migrated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			handler := newMigrateHandler(tt.reader, dir)
+			handler.Config.SubmissionFileNames = tt.files
+
+			err := handler.Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+			written, _ := os.ReadDir(dir)
+			assert.Empty(t, written)
+		})
+	}
+}
+
+func TestMigrateHandle_ParseError(t *testing.T) {
+	// Arrange
+	handler := NewMigrateCommandHandler(&fakeReader{fileContent: []byte("x")}, &fakeParser{parseError: errors.New("parse error")}, &config.MigrateOptions{Filename: "form.xml"})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	assert.ErrorContains(t, err, "parse error")
+}
+
+func TestMigrateOutputFilePath(t *testing.T) {
+	handler := newMigrateHandler(&fakeReader{}, "")
+	assert.Equal(t, filepath.Join("submissions", "jane_migrated.json"), handler.outputFilePath("submissions/jane.json"))
+
+	handler.Config.OutputDir = "out"
+	assert.Equal(t, filepath.Join("out", "jane_migrated.json"), handler.outputFilePath("submissions/jane.json"))
+}
@@ -73,6 +73,11 @@ func (r *ParseFormCommandHandler) readFiles(fileName, submissionFileName string)
 		return nil, nil, errors.New(message)
 	}
 
+	// A blank form is rendered without a submission
+	if r.Config.Render.Blank {
+		return fileContent, nil, nil
+	}
+
 	submission, err := r.Reader.ReadSubmissionFile(submissionFileName)
 	if err != nil {
 		logging.Log.Errorf("error reading file: %v", err)
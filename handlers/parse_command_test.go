@@ -92,6 +92,22 @@ func TestHandle_ReadFilesError(t *testing.T) {
 	assert.Contains(t, err.Error(), "read file error")
 }
 
+func TestHandle_BlankSkipsSubmission(t *testing.T) {
+	// Arrange
+	handler := &ParseFormCommandHandler{
+		Reader:   &fakeReader{fileContent: []byte("some xml"), submissionError: errors.New("no submission")},
+		Parser:   &fakeParser{},
+		Renderer: &fakeRenderer{},
+		Config:   &config.CommandOptions{Render: config.RenderOptions{Blank: true}},
+	}
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+}
+
 func TestHandle_ParseError(t *testing.T) {
 	// Arrange
 	handler := &ParseFormCommandHandler{
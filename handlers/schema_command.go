@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/schema"
+)
+
+type SchemaCommandHandler struct {
+	Config *config.SchemaOptions
+	Reader reader.Reader
+	Parser parsers.Parser
+	Writer io.Writer
+}
+
+func NewSchemaCommandHandler(reader reader.Reader, parser parsers.Parser, writer io.Writer, config *config.SchemaOptions) *SchemaCommandHandler {
+	return &SchemaCommandHandler{
+		Config: config,
+		Reader: reader,
+		Parser: parser,
+		Writer: writer,
+	}
+}
+
+// Handle - writes the JSON Schema of the submissions of the form, or validates a submission against it
+func (r *SchemaCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	title := form.Metadata["Title"]
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(r.Config.Filename), filepath.Ext(r.Config.Filename))
+	}
+	submissionSchema := schema.Generate(form, title, r.Config.Layout)
+
+	if r.Config.SubmissionFileName == "" {
+		encoder := json.NewEncoder(r.Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(submissionSchema)
+	}
+
+	submission, err := r.Reader.ReadSubmissionFile(r.Config.SubmissionFileName)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	err = schema.Validate(submissionSchema, schema.Instance(form, submission, r.Config.Layout))
+	if err != nil {
+		logging.Log.Errorf("Submission %s is not valid:\n%v", r.Config.SubmissionFileName, err)
+		return err
+	}
+
+	logging.Log.Infof("Submission %s is valid", r.Config.SubmissionFileName)
+	return nil
+}
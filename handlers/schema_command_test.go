@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchemaHandler(reader *fakeReader, output *bytes.Buffer, submissionFileName string) *SchemaCommandHandler {
+	return NewSchemaCommandHandler(reader, &formParser{}, output, &config.SchemaOptions{
+		Filename:           "forms/application.xml",
+		SubmissionFileName: submissionFileName,
+		Layout:             models.FlatSchemaLayout,
+	})
+}
+
+func TestSchemaHandle_WritesSchema(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	handler := newSchemaHandler(&fakeReader{fileContent: []byte("some xml")}, &output, "")
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	var written schema.Schema
+	require.NoError(t, json.Unmarshal(output.Bytes(), &written))
+	assert.Equal(t, "application", written.Title)
+	assert.Equal(t, []string{"language"}, written.Required)
+	assert.Equal(t, []string{"A", "B"}, written.Properties["language"].Enum)
+}
+
+func TestSchemaHandle_ValidatesSubmission(t *testing.T) {
+	tests := []struct {
+		name       string
+		submission *models.ContentSubmission
+		expected   error
+	}{
+		{"valid", &models.ContentSubmission{"language": "A"}, nil},
+		{"invalid", &models.ContentSubmission{"language": "C"}, myerrors.ErrSchemaViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			handler := newSchemaHandler(&fakeReader{fileContent: []byte("some xml"), submissionData: tt.submission}, &output, "submission.json")
+
+			err := handler.Handle()
+
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Empty(t, output.String())
+		})
+	}
+}
+
+func TestSchemaHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, "read file error"},
+		{"empty file", &fakeReader{}, "file forms/application.xml is empty"},
+		{"read submission", &fakeReader{fileContent: []byte("x"), submissionError: errors.New("submission error")}, "submission error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newSchemaHandler(tt.reader, &bytes.Buffer{}, "submission.json").Handle()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/webform"
+)
+
+// The timeouts of the server - a slow or stuck client cannot hold a connection forever.
+// Writing includes rendering the PDF of the submission.
+var ServeReadHeaderTimeout = 10 * time.Second
+var ServeReadTimeout = 2 * time.Minute
+var ServeWriteTimeout = 2 * time.Minute
+
+type ServeCommandHandler struct {
+	Config      *config.ServeOptions
+	Reader      reader.Reader
+	Parser      parsers.Parser
+	NewRenderer webform.RendererFactory
+	// Listen serves the handler on the address - an http.Server with the timeouts
+	Listen func(address string, handler http.Handler) error
+}
+
+func NewServeCommandHandler(reader reader.Reader, parser parsers.Parser, newRenderer webform.RendererFactory, config *config.ServeOptions) *ServeCommandHandler {
+	return &ServeCommandHandler{
+		Config:      config,
+		Reader:      reader,
+		Parser:      parser,
+		NewRenderer: newRenderer,
+		Listen:      listenAndServe,
+	}
+}
+
+// Handle - serves the HTML form and saves the posted submissions, until the server stops
+func (r *ServeCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	if err = os.MkdirAll(r.Config.OutputDir, 0755); err != nil {
+		logging.Log.Errorf("Error creating output folder: %v", err)
+		return err
+	}
+
+	server, err := webform.NewServer(form, filepath.Base(r.Config.Filename), r.Config.OutputDir, r.NewRenderer)
+	if err != nil {
+		return err
+	}
+
+	logging.Log.Infof("Serving %s on %s, submissions are saved to %s", r.Config.Filename, r.Config.Address, r.Config.OutputDir)
+	return r.Listen(r.Config.Address, server)
+}
+
+// listenAndServe - serves the handler on the address with the timeouts
+func listenAndServe(address string, handler http.Handler) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: ServeReadHeaderTimeout,
+		ReadTimeout:       ServeReadTimeout,
+		WriteTimeout:      ServeWriteTimeout,
+	}
+	return server.ListenAndServe()
+}
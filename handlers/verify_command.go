@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"crypto/x509"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/signature"
+)
+
+// VerifyFunc - checks the signature of a document against the roots, the roots of the system when nil
+type VerifyFunc func(document []byte, roots *x509.CertPool) (*signature.Verification, error)
+
+type VerifySignatureCommandHandler struct {
+	Config *config.VerifyOptions
+	Reader reader.Reader
+	Verify VerifyFunc
+}
+
+func NewVerifySignatureCommandHandler(reader reader.Reader, config *config.VerifyOptions) *VerifySignatureCommandHandler {
+	return &VerifySignatureCommandHandler{
+		Config: config,
+		Reader: reader,
+		Verify: signature.Verify,
+	}
+}
+
+func (r *VerifySignatureCommandHandler) Handle() error {
+	content, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	var roots *x509.CertPool
+	if r.Config.TrustedCertsPath != "" {
+		certificates, err := r.Reader.ReadBinary(r.Config.TrustedCertsPath)
+		if err != nil {
+			logging.Log.Errorf("error reading trusted certificates: %v", err)
+			return err
+		}
+		if roots, err = signature.ParseTrustedCertificates(certificates); err != nil {
+			logging.Log.Errorf("error reading trusted certificates %s: %v", r.Config.TrustedCertsPath, err)
+			return err
+		}
+	}
+
+	// A certificate that does not chain up to the roots fails the verification, even when the document is intact
+	verification, err := r.Verify(content, roots)
+	if err != nil {
+		logging.Log.Errorf("Signature of %s is not valid: %v", r.Config.Filename, err)
+		return err
+	}
+
+	logging.Log.Infof("Signature of %s is valid. Signed by %s (issued by %s) at %s", r.Config.Filename,
+		verification.Signer, verification.Issuer, verification.SigningTime)
+	return nil
+}
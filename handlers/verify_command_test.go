@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"crypto/x509"
+	"errors"
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func fakeVerify(verification *signature.Verification, err error) VerifyFunc {
+	return func(_ []byte, _ *x509.CertPool) (*signature.Verification, error) {
+		return verification, err
+	}
+}
+
+func TestVerifyHandle_HappyPath(t *testing.T) {
+	// Arrange
+	handler := &VerifySignatureCommandHandler{
+		Config: &config.VerifyOptions{Filename: "signed.pdf"},
+		Reader: &fakeReader{fileContent: []byte("%PDF")},
+		Verify: fakeVerify(&signature.Verification{Signer: "CN=Test"}, nil),
+	}
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+}
+
+func TestVerifyHandle_ReadFileError(t *testing.T) {
+	// Arrange
+	readError := errors.New("read error")
+	handler := &VerifySignatureCommandHandler{
+		Config: &config.VerifyOptions{Filename: "signed.pdf"},
+		Reader: &fakeReader{fileError: readError},
+		Verify: fakeVerify(&signature.Verification{}, nil),
+	}
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	assert.ErrorIs(t, err, readError)
+}
+
+func TestVerifyHandle_InvalidSignature(t *testing.T) {
+	// Arrange
+	handler := &VerifySignatureCommandHandler{
+		Config: &config.VerifyOptions{Filename: "signed.pdf"},
+		Reader: &fakeReader{fileContent: []byte("%PDF")},
+		Verify: fakeVerify(nil, myerrors.ErrDigestMismatch),
+	}
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrDigestMismatch)
+}
+
+func TestVerifyHandle_InvalidTrustedCerts(t *testing.T) {
+	// Arrange
+	handler := &VerifySignatureCommandHandler{
+		Config: &config.VerifyOptions{Filename: "signed.pdf", TrustedCertsPath: "ca.pem"},
+		Reader: &fakeReader{fileContent: []byte("%PDF")},
+		Verify: fakeVerify(&signature.Verification{Signer: "CN=Test"}, nil),
+	}
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	assert.Error(t, err)
+}
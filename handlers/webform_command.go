@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/alex-pricope/form-parser/webform"
+)
+
+type WebformCommandHandler struct {
+	Config *config.WebformOptions
+	Reader reader.Reader
+	Parser parsers.Parser
+	Writer io.Writer
+}
+
+func NewWebformCommandHandler(reader reader.Reader, parser parsers.Parser, writer io.Writer, config *config.WebformOptions) *WebformCommandHandler {
+	return &WebformCommandHandler{
+		Config: config,
+		Reader: reader,
+		Parser: parser,
+		Writer: writer,
+	}
+}
+
+// Handle - writes the HTML form of the form file
+func (r *WebformCommandHandler) Handle() error {
+	fileContent, err := r.Reader.ReadBinary(r.Config.Filename)
+	if err != nil {
+		logging.Log.Errorf("error reading file: %v", err)
+		return err
+	}
+
+	if len(fileContent) == 0 {
+		err = fmt.Errorf("file %s is empty", r.Config.Filename)
+		logging.Log.Error(err)
+		return err
+	}
+
+	form, err := r.Parser.Parse(fileContent)
+	if err != nil {
+		logging.Log.Errorf("Error parsing file: %v", err)
+		return err
+	}
+
+	return webform.Generate(form, webform.Options{Action: r.Config.Action, Title: filepath.Base(r.Config.Filename)}, r.Writer)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebformHandle_HappyPath(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	handler := NewWebformCommandHandler(&fakeReader{fileContent: []byte("some xml")}, &formParser{}, &output,
+		&config.WebformOptions{Filename: "forms/form.xml", Action: "/intake"})
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "<title>form.xml</title>")
+	assert.Contains(t, output.String(), `action="/intake"`)
+	assert.Contains(t, output.String(), `<select id="language" name="language" required>`)
+}
+
+func TestServeHandle_HappyPath(t *testing.T) {
+	// Arrange - the listener gets the server instead of serving it
+	var served http.Handler
+	outputDir := filepath.Join(t.TempDir(), "submissions")
+	handler := NewServeCommandHandler(&fakeReader{fileContent: []byte("some xml")}, &formParser{}, nil,
+		&config.ServeOptions{Filename: "form.xml", Address: "localhost:0", OutputDir: outputDir})
+	handler.Listen = func(address string, server http.Handler) error {
+		served = server
+		return nil
+	}
+
+	// Act
+	err := handler.Handle()
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, served)
+	assert.DirExists(t, outputDir)
+
+	recorder := httptest.NewRecorder()
+	served.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Contains(t, recorder.Body.String(), `name="language"`)
+}
+
+func TestWebformAndServeHandle_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		reader   *fakeReader
+		parser   *fakeParser
+		expected string
+	}{
+		{"read file", &fakeReader{fileError: errors.New("read file error")}, &fakeParser{}, "read file error"},
+		{"empty file", &fakeReader{}, &fakeParser{}, "file form.xml is empty"},
+		{"parse", &fakeReader{fileContent: []byte("x")}, &fakeParser{parseError: errors.New("parse error")}, "parse error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webformHandler := NewWebformCommandHandler(tt.reader, tt.parser, &bytes.Buffer{}, &config.WebformOptions{Filename: "form.xml"})
+			serveHandler := NewServeCommandHandler(tt.reader, tt.parser, nil, &config.ServeOptions{Filename: "form.xml"})
+
+			assert.ErrorContains(t, webformHandler.Handle(), tt.expected)
+			assert.ErrorContains(t, serveHandler.Handle(), tt.expected)
+		})
+	}
+}
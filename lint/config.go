@@ -0,0 +1,89 @@
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"gopkg.in/yaml.v3"
+)
+
+/* The lint config file turns rules off or changes their severity for all the forms, by rule ID or Name:
+
+   disable: [FP002, section-without-title]
+   severity:
+     unknown-element: error
+
+   A single element is better handled with an inline comment, see the source.
+*/
+
+// Config - the rules turned off and the severities changed, by rule ID
+type Config struct {
+	Disabled   map[string]bool
+	Severities map[string]models.Severity
+}
+
+type configFile struct {
+	Disable  []string          `yaml:"disable"`
+	Severity map[string]string `yaml:"severity"`
+}
+
+// ReadConfig - parses and checks a lint config file. Unknown rules and severities are an error.
+func ReadConfig(content []byte) (*Config, error) {
+	config := &Config{Disabled: make(map[string]bool), Severities: make(map[string]models.Severity)}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	var file configFile
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidLintConfig, err)
+	}
+
+	var errs []error
+	for _, reference := range file.Disable {
+		rule, err := FindRule(reference)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		config.Disabled[rule.ID] = true
+	}
+
+	for reference, value := range file.Severity {
+		rule, err := FindRule(reference)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		severity := models.SafeReadSeverity(value)
+		if severity == models.UnknownSeverity {
+			errs = append(errs, fmt.Errorf("%w: unknown severity %q of %s, expected error, warning or info", myerrors.ErrInvalidLintConfig, value, reference))
+			continue
+		}
+		config.Severities[rule.ID] = severity
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// severity - the severity of the rule, changed by the config
+func (c *Config) severity(rule Rule) models.Severity {
+	if c != nil {
+		if severity, ok := c.Severities[rule.ID]; ok {
+			return severity
+		}
+	}
+	return rule.Severity
+}
+
+func (c *Config) isDisabled(rule Rule) bool {
+	return c != nil && c.Disabled[rule.ID]
+}
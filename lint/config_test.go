@@ -0,0 +1,53 @@
+package lint
+
+import (
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfig(t *testing.T) {
+	// Act
+	config, err := ReadConfig([]byte("disable: [FP002, section-without-title]\nseverity:\n  Unknown-Element: error\n"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"FP002": true, "FP008": true}, config.Disabled)
+	assert.Equal(t, map[string]models.Severity{"FP005": models.ErrorSeverity}, config.Severities)
+}
+
+func TestReadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected error
+	}{
+		{"unknown key", "disabled: [FP001]", myerrors.ErrInvalidLintConfig},
+		{"unknown rule", "disable: [FP999]", myerrors.ErrUnknownLintRule},
+		{"unknown severity", "severity:\n  FP001: fatal", myerrors.ErrInvalidLintConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadConfig([]byte(tt.content))
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestFindRule(t *testing.T) {
+	rule, err := FindRule("fp004")
+	require.NoError(t, err)
+	assert.Equal(t, "duplicate-name", rule.Name)
+
+	rule, err = FindRule("DUPLICATE-NAME")
+	require.NoError(t, err)
+	assert.Equal(t, "FP004", rule.ID)
+
+	_, err = FindRule("FP999")
+	assert.ErrorIs(t, err, myerrors.ErrUnknownLintRule)
+}
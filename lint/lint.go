@@ -0,0 +1,220 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+// Problem - a rule broken by the form, at the position of the element
+type Problem struct {
+	Rule     Rule
+	Severity models.Severity
+	Message  string
+	Line     int
+	Column   int
+}
+
+// Report - the problems of a form, in the order of the file
+type Report struct {
+	Problems []Problem
+}
+
+// Count - the number of problems with the severity
+func (r *Report) Count(severity models.Severity) int {
+	count := 0
+	for _, problem := range r.Problems {
+		if problem.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// HasErrors - at least one problem is an error. Warnings and infos do not fail the lint.
+func (r *Report) HasErrors() bool {
+	return r.Count(models.ErrorSeverity) > 0
+}
+
+// String - E.g. 1 error, 2 warnings, 0 infos
+func (r *Report) String() string {
+	return fmt.Sprintf("%s, %s, %s",
+		plural(r.Count(models.ErrorSeverity), "error"), plural(r.Count(models.WarningSeverity), "warning"), plural(r.Count(models.InfoSeverity), "info"))
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// linter - checks the elements of a form against the rules
+type linter struct {
+	source   *source
+	config   *Config
+	problems []Problem
+
+	fields   map[string]*sourceElement
+	sections map[string]*sourceElement
+}
+
+// Lint - checks the form file against the rules of the catalogue. An invalid XML file is an error.
+func Lint(content []byte, config *Config) (*Report, error) {
+	source, err := readSource(content)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{
+		source:   source,
+		config:   config,
+		fields:   make(map[string]*sourceElement),
+		sections: make(map[string]*sourceElement),
+	}
+	if source.root != nil {
+		l.visit(source.root)
+	}
+
+	sort.SliceStable(l.problems, func(i, j int) bool {
+		if l.problems[i].Line != l.problems[j].Line {
+			return l.problems[i].Line < l.problems[j].Line
+		}
+		return l.problems[i].Column < l.problems[j].Column
+	})
+	return &Report{Problems: l.problems}, nil
+}
+
+func (l *linter) visit(element *sourceElement) {
+	switch element.node.ElementType {
+	case models.FieldElementType:
+		l.checkField(element)
+	case models.SectionElementType:
+		l.checkSection(element)
+	case models.UnknownElementType:
+		l.report(unknownElement, element, "unknown element <%s>, it is not rendered", element.tag)
+	}
+
+	for _, child := range element.children {
+		l.visit(child)
+	}
+}
+
+func (l *linter) checkField(field *sourceElement) {
+	node := field.node
+	name := node.Name
+	if name == "" {
+		l.report(fieldWithoutName, field, "field without a Name")
+		name = "<" + field.tag + ">"
+	} else {
+		l.checkDuplicate(l.fields, field, "field")
+	}
+
+	if !hasChild(node, models.CaptionElementType) {
+		l.report(fieldWithoutCaption, field, "field %s has no Caption", name)
+	}
+
+	fieldType, ok := node.Metadata["FieldType"]
+	switch {
+	case !ok:
+		l.report(unknownFieldType, field, "field %s has no FieldType", name)
+	case models.SafeReadFieldType(fieldType) == models.UnknownFieldType:
+		l.report(unknownFieldType, field, "field %s has an unknown FieldType %q, expected Select, TextBox or File", name, fieldType)
+	}
+
+	options := node.Options()
+	if models.SafeReadFieldType(fieldType) == models.SelectFieldType && len(options) == 0 {
+		l.report(selectWithoutLabels, field, "select field %s has no Labels", name)
+	}
+
+	l.checkLabels(field, name)
+
+	definition := models.ParseTypeDefinition(node.Metadata["Type"])
+	if strings.EqualFold(definition.Name, "Enumeration") && len(options) > 0 {
+		labelNames := make([]string, len(options))
+		for i, option := range options {
+			labelNames[i] = option.Name
+		}
+		if !sameMembers(definition.Arguments, labelNames) {
+			l.report(enumerationLabelMismatch, field, "field %s has the Enumeration members %s but the Label Names %s",
+				name, strings.Join(definition.Arguments, ","), strings.Join(labelNames, ","))
+		}
+	}
+}
+
+// checkLabels - the label names of a field must be unique
+func (l *linter) checkLabels(field *sourceElement, name string) {
+	labels := make(map[string]*sourceElement)
+	for _, child := range field.children {
+		if child.node.ElementType != models.LabelsElementType {
+			continue
+		}
+		for _, label := range child.children {
+			if label.node.ElementType == models.LabelElementType && label.node.Name != "" {
+				l.checkDuplicate(labels, label, "label of field "+name)
+			}
+		}
+	}
+}
+
+func (l *linter) checkSection(section *sourceElement) {
+	if section.node.Name != "" {
+		l.checkDuplicate(l.sections, section, "section")
+	}
+
+	if !hasChild(section.node, models.TitleElementType) {
+		l.report(sectionWithoutTitle, section, "section %s has no Title", section.node.Name)
+	}
+}
+
+// checkDuplicate - reports the element when the name was already seen, at the position of the second one
+func (l *linter) checkDuplicate(seen map[string]*sourceElement, element *sourceElement, kind string) {
+	if first, ok := seen[element.node.Name]; ok {
+		l.report(duplicateName, element, "duplicate %s name %s, first used at line %d", kind, element.node.Name, first.line)
+		return
+	}
+	seen[element.node.Name] = element
+}
+
+func (l *linter) report(rule Rule, element *sourceElement, format string, args ...any) {
+	if l.config.isDisabled(rule) || l.source.isDisabled(element, rule) {
+		return
+	}
+
+	l.problems = append(l.problems, Problem{
+		Rule:     rule,
+		Severity: l.config.severity(rule),
+		Message:  fmt.Sprintf(format, args...),
+		Line:     element.line,
+		Column:   element.column,
+	})
+}
+
+func hasChild(node *models.ContentNode, elementType models.ElementType) bool {
+	for _, child := range node.Children {
+		if child.ElementType == elementType && child.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sameMembers - the same names, in any order
+func sameMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, name := range a {
+		counts[name]++
+	}
+	for _, name := range b {
+		counts[name]--
+		if counts[name] < 0 {
+			return false
+		}
+	}
+	return true
+}
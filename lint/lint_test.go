@@ -0,0 +1,141 @@
+package lint
+
+import (
+	"os"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var brokenForm = `<Form>
+    <Field Name="language" Type="Enumeration(A,B,C)" FieldType="Select">
+        <Caption>Language</Caption>
+        <Labels>
+            <Label Name="A">Go</Label>
+            <Label Name="A">Rust</Label>
+        </Labels>
+    </Field>
+    <Field Name="colour" FieldType="Select"/>
+    <Field FieldType="Checkbox">
+        <Caption>Agree</Caption>
+    </Field>
+    <Section Name="details">
+        <Contents>
+            <Field Name="language" FieldType="TextBox"><Caption>Again</Caption></Field>
+            <Notes>Some notes</Notes>
+        </Contents>
+    </Section>
+</Form>`
+
+type expectedProblem struct {
+	id     string
+	line   int
+	column int
+}
+
+func problemsOf(report *Report) []expectedProblem {
+	var problems []expectedProblem
+	for _, problem := range report.Problems {
+		problems = append(problems, expectedProblem{problem.Rule.ID, problem.Line, problem.Column})
+	}
+	return problems
+}
+
+func TestLint(t *testing.T) {
+	// Act
+	report, err := Lint([]byte(brokenForm), nil)
+
+	// Assert
+	require.NoError(t, err)
+	expected := []expectedProblem{
+		{"FP003", 2, 5},
+		{"FP004", 6, 13},
+		{"FP002", 9, 5},
+		{"FP001", 9, 5},
+		{"FP007", 10, 5},
+		{"FP006", 10, 5},
+		{"FP008", 13, 5},
+		{"FP004", 15, 13},
+		{"FP005", 16, 13},
+	}
+	assert.Equal(t, expected, problemsOf(report))
+	assert.Equal(t, "field language has the Enumeration members A,B,C but the Label Names A,A", report.Problems[0].Message)
+	assert.Equal(t, "duplicate label of field language name A, first used at line 5", report.Problems[1].Message)
+	assert.Equal(t, "field <Field> has an unknown FieldType \"Checkbox\", expected Select, TextBox or File", report.Problems[5].Message)
+	assert.Equal(t, "duplicate field name language, first used at line 2", report.Problems[7].Message)
+	assert.Equal(t, "unknown element <Notes>, it is not rendered", report.Problems[8].Message)
+	assert.Equal(t, "6 errors, 2 warnings, 1 info", report.String())
+	assert.True(t, report.HasErrors())
+}
+
+func TestLint_Payloads(t *testing.T) {
+	for _, fileName := range []string{"../tests/payload/valid_xml", "../tests/payload/complex_valid_xml"} {
+		t.Run(fileName, func(t *testing.T) {
+			// Arrange
+			content, err := os.ReadFile(fileName)
+			require.NoError(t, err)
+
+			// Act
+			report, err := Lint(content, nil)
+
+			// Assert
+			require.NoError(t, err)
+			assert.False(t, report.HasErrors(), report.Problems)
+		})
+	}
+}
+
+func TestLint_InlineComments(t *testing.T) {
+	// Arrange
+	content := `<Form>
+    <!-- lint-disable-file section-without-title -->
+    <!-- lint-disable FP002, FP006 -->
+    <Field Name="a">
+        <Unknown/>
+    </Field>
+    <Field Name="b" FieldType="TextBox"/>
+    <!-- lint-disable -->
+    <Section Name="s"><Unknown/></Section>
+</Form>`
+
+	// Act
+	report, err := Lint([]byte(content), nil)
+
+	// Assert - only the rules of the comments are turned off, and only for the next element
+	require.NoError(t, err)
+	assert.Equal(t, []expectedProblem{{"FP005", 5, 9}, {"FP002", 7, 5}}, problemsOf(report))
+}
+
+func TestLint_UnknownRuleInComment(t *testing.T) {
+	// Act
+	_, err := Lint([]byte("<Form>\n<!-- lint-disable FP999 -->\n</Form>"), nil)
+
+	// Assert
+	assert.ErrorContains(t, err, "unknown lint rule: FP999 (comment at line 2)")
+}
+
+func TestLint_Config(t *testing.T) {
+	// Arrange
+	config, err := ReadConfig([]byte("disable: [FP001]\nseverity:\n  unknown-element: error\n"))
+	require.NoError(t, err)
+	content := `<Form><Field Name="a" FieldType="Select"><Caption>A</Caption></Field><Other/></Form>`
+
+	// Act
+	report, err := Lint([]byte(content), config)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, "FP005", report.Problems[0].Rule.ID)
+	assert.Equal(t, models.ErrorSeverity, report.Problems[0].Severity)
+}
+
+func TestLint_InvalidXML(t *testing.T) {
+	// Act
+	_, err := Lint([]byte("<Form><Field></Form>"), nil)
+
+	// Assert
+	require.Error(t, err)
+}
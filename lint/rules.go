@@ -0,0 +1,50 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+// Rule - a recurring mistake in a form. A rule is referenced by its ID or its Name.
+type Rule struct {
+	ID          string
+	Name        string
+	Severity    models.Severity
+	Description string
+}
+
+var (
+	selectWithoutLabels      = Rule{"FP001", "select-without-labels", models.ErrorSeverity, "A Select field has no Labels to choose from"}
+	fieldWithoutCaption      = Rule{"FP002", "field-without-caption", models.WarningSeverity, "A Field has no Caption, only its name can be shown"}
+	enumerationLabelMismatch = Rule{"FP003", "enumeration-label-mismatch", models.ErrorSeverity, "The members of an Enumeration Type are not the Label Names of the field"}
+	duplicateName            = Rule{"FP004", "duplicate-name", models.ErrorSeverity, "Two fields, two sections or two labels of a field have the same Name"}
+	unknownElement           = Rule{"FP005", "unknown-element", models.WarningSeverity, "An element the parser does not know, it is not rendered"}
+	unknownFieldType         = Rule{"FP006", "unknown-field-type", models.ErrorSeverity, "A Field without a FieldType or with one the renderers do not support"}
+	fieldWithoutName         = Rule{"FP007", "field-without-name", models.ErrorSeverity, "A Field without a Name cannot be answered in a submission"}
+	sectionWithoutTitle      = Rule{"FP008", "section-without-title", models.InfoSeverity, "A Section has no Title, it is rendered without a heading"}
+)
+
+// Rules - the catalogue of the lint rules
+var Rules = []Rule{
+	selectWithoutLabels,
+	fieldWithoutCaption,
+	enumerationLabelMismatch,
+	duplicateName,
+	unknownElement,
+	unknownFieldType,
+	fieldWithoutName,
+	sectionWithoutTitle,
+}
+
+// FindRule - the rule with the ID or Name. The comparison ignores the case.
+func FindRule(reference string) (Rule, error) {
+	for _, rule := range Rules {
+		if strings.EqualFold(rule.ID, reference) || strings.EqualFold(rule.Name, reference) {
+			return rule, nil
+		}
+	}
+	return Rule{}, fmt.Errorf("%w: %s", myerrors.ErrUnknownLintRule, reference)
+}
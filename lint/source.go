@@ -0,0 +1,149 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var disableFileComment = "lint-disable-file"
+var disableComment = "lint-disable"
+var allRules = "*"
+
+/* The parser drops what the lint needs, so the form is read again here, keeping for every element:
+   * its tag, since unknown elements all become UnknownElementType
+   * its position (line and column of the '<')
+   * the rules turned off by the comments before it
+
+   The rules are turned off with a comment, by rule ID or Name. Without rules the comment turns all of them off.
+   <!-- lint-disable FP002, duplicate-name -->  the next element and everything inside it
+   <!-- lint-disable-file FP005 -->             the whole file
+*/
+
+// sourceElement - an element of the form with its position in the file
+type sourceElement struct {
+	node     *models.ContentNode
+	tag      string
+	line     int
+	column   int
+	disabled map[string]bool
+	children []*sourceElement
+}
+
+// source - the elements of the form file
+type source struct {
+	root     *sourceElement
+	disabled map[string]bool
+}
+
+func readSource(content []byte) (*source, error) {
+	result := &source{disabled: make(map[string]bool)}
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	var stack []*sourceElement
+	pending := make(map[string]bool)
+
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &sourceElement{
+				node:     &models.ContentNode{ElementType: models.SafeReadElementType(t.Name.Local), Metadata: make(map[string]string)},
+				tag:      t.Name.Local,
+				disabled: pending,
+			}
+			element.line, element.column = position(content, offset)
+			for _, attribute := range t.Attr {
+				element.node.Metadata[attribute.Name.Local] = attribute.Value
+			}
+			element.node.Name = element.node.Metadata["Name"]
+
+			if len(stack) == 0 {
+				result.root = element
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+				parent.node.Children = append(parent.node.Children, element.node)
+				for rule := range parent.disabled {
+					element.disabled[rule] = true
+				}
+			}
+			stack = append(stack, element)
+			pending = make(map[string]bool)
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].node.Value += strings.TrimSpace(string(t))
+			}
+
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+		case xml.Comment:
+			line, _ := position(content, offset)
+			if err = readDisableComment(string(t), line, pending, result.disabled); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// readDisableComment - adds the rules turned off by the comment to the next element or to the file
+func readDisableComment(comment string, line int, next, file map[string]bool) error {
+	fields := strings.Fields(strings.ReplaceAll(comment, ",", " "))
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var target map[string]bool
+	switch fields[0] {
+	case disableFileComment:
+		target = file
+	case disableComment:
+		target = next
+	default:
+		return nil
+	}
+
+	if len(fields) == 1 {
+		target[allRules] = true
+		return nil
+	}
+	for _, reference := range fields[1:] {
+		rule, err := FindRule(reference)
+		if err != nil {
+			return fmt.Errorf("%w (comment at line %d)", err, line)
+		}
+		target[rule.ID] = true
+	}
+	return nil
+}
+
+// position - the line and column (both from 1) of the byte at the offset
+func position(content []byte, offset int64) (int, int) {
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// isDisabled - the rule is turned off by a comment for the element or the whole file
+func (s *source) isDisabled(element *sourceElement, rule Rule) bool {
+	return s.disabled[allRules] || s.disabled[rule.ID] || element.disabled[allRules] || element.disabled[rule.ID]
+}
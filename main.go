@@ -2,8 +2,10 @@ package main
 
 import (
 	"github.com/alex-pricope/form-parser/cmd"
+	"github.com/alex-pricope/form-parser/config"
 	"github.com/alex-pricope/form-parser/logging"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"os"
 )
 
@@ -11,6 +13,19 @@ func main() {
 	// Decided not to inject the logger and use it globally like this to simplify the app
 	logging.BoostrapLogger()
 
+	rootCmd, err := newRootCommand()
+	if err != nil {
+		logging.Log.Error(err)
+		return
+	}
+
+	if err = rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// newRootCommand - the parse command with all the other commands
+func newRootCommand() (*cobra.Command, error) {
 	var rootCmd = &cobra.Command{
 		Use:     "parser",
 		Short:   "Simple file parser",
@@ -18,38 +33,273 @@ func main() {
 		Run:     cmd.ParseCommand,
 	}
 
+	// The flags that are not on the command line come from the environment and the settings file
+	rootCmd.PersistentFlags().String("config", "", "Settings file, .formparser.yaml in the working or home directory by default")
+	rootCmd.PersistentPreRunE = cmd.LoadSettings
+
 	// Add the flags - can be extended with others
 	rootCmd.Flags().StringP("file", "f", "", "file to parse")
 	err := rootCmd.MarkFlagRequired("file")
 	if err != nil {
-		logging.Log.Error(err)
-		return
+		return nil, err
 	}
 
+	// Either a submission or a blank form
 	rootCmd.Flags().StringP("sub", "s", "", "file to parse")
-	err = rootCmd.MarkFlagRequired("sub")
-	if err != nil {
-		logging.Log.Error(err)
-		return
-	}
+	rootCmd.Flags().Bool("blank", false, "Render a blank printable form, without a submission")
+	rootCmd.MarkFlagsOneRequired("sub", "blank")
+	rootCmd.MarkFlagsMutuallyExclusive("sub", "blank")
 
 	rootCmd.Flags().String("from", "", "Input file type")
 	err = rootCmd.MarkFlagRequired("from")
 	if err != nil {
-		logging.Log.Error(err)
-		return
+		return nil, err
 	}
 
 	rootCmd.Flags().String("to", "", "Target file type")
 	err = rootCmd.MarkFlagRequired("to")
 	if err != nil {
-		logging.Log.Error(err)
-		return
+		return nil, err
 	}
 
 	rootCmd.Flags().StringP("out", "o", "", "Output folder")
+	rootCmd.Flags().String("attachments-dir", "", "Folder with the uploaded files - found files are embedded in the PDF")
+	addRenderFlags(rootCmd)
 
-	if err = rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	verifyCmd := &cobra.Command{
+		Use:     "verify <file>",
+		Short:   "Verify the signature of a generated PDF",
+		Example: "parser verify ./output/form.pdf --trusted-certs ca.pem",
+		Args:    cobra.ExactArgs(1),
+		Run:     cmd.VerifyCommand,
+	}
+	verifyCmd.Flags().String("trusted-certs", "", "PEM file with the certificates trusted as roots - the roots of the system by default")
+	rootCmd.AddCommand(verifyCmd)
+
+	diffSubmissionsCmd := &cobra.Command{
+		Use:     "diff-submissions <old> <new>",
+		Short:   "Render the differences between two submissions of a form",
+		Example: "parser diff-submissions --file form.xml old.json new.json --to html --out ./output/",
+		Args:    cobra.ExactArgs(2),
+		Run:     cmd.DiffSubmissionsCommand,
+	}
+	diffSubmissionsCmd.Flags().StringP("file", "f", "", "Form file of the submissions")
+	err = diffSubmissionsCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
 	}
+	diffSubmissionsCmd.Flags().String("from", "xml", "Input file type")
+	diffSubmissionsCmd.Flags().String("to", "pdf", "Target file type: pdf or html")
+	diffSubmissionsCmd.Flags().StringP("out", "o", "", "Output folder, created when missing")
+	err = diffSubmissionsCmd.MarkFlagRequired("out")
+	if err != nil {
+		return nil, err
+	}
+	diffSubmissionsCmd.Flags().Bool("changed-only", false, "Only show the fields whose answer changed")
+	diffSubmissionsCmd.Flags().String("profile", "internal", "Redaction profile of the sensitive answers: internal, external, restricted or public")
+	rootCmd.AddCommand(diffSubmissionsCmd)
+
+	diffFormsCmd := &cobra.Command{
+		Use:     "diff-forms <old> <new>",
+		Short:   "Report the structural changes between two versions of a form",
+		Example: "parser diff-forms form_v1.xml form_v2.xml --to json",
+		Args:    cobra.ExactArgs(2),
+		Run:     cmd.DiffFormsCommand,
+	}
+	diffFormsCmd.Flags().String("from", "xml", "Input file type")
+	diffFormsCmd.Flags().String("to", "text", "Target file type: text, json or pdf (redline)")
+	diffFormsCmd.Flags().StringP("out", "o", "", "Output folder of the redline PDF")
+	rootCmd.AddCommand(diffFormsCmd)
+
+	migrateCmd := &cobra.Command{
+		Use:     "migrate <submission or folder>...",
+		Short:   "Move submissions to a new version of their form",
+		Example: "parser migrate --file form_v2.xml --migration v1_to_v2.yaml ./submissions --out ./migrated",
+		Args:    cobra.MinimumNArgs(1),
+		Run:     cmd.MigrateCommand,
+	}
+	migrateCmd.Flags().StringP("file", "f", "", "New version of the form")
+	migrateCmd.Flags().StringP("migration", "m", "", "Migration file (YAML or JSON)")
+	err = migrateCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
+	}
+	err = migrateCmd.MarkFlagRequired("migration")
+	if err != nil {
+		return nil, err
+	}
+	migrateCmd.Flags().String("from", "xml", "Input file type")
+	migrateCmd.Flags().StringP("out", "o", "", "Output folder")
+	rootCmd.AddCommand(migrateCmd)
+
+	lintCmd := &cobra.Command{
+		Use:     "lint <form>",
+		Short:   "Check a form for recurring mistakes",
+		Example: "parser lint form.xml --to sarif > lint.sarif",
+		Args:    cobra.ExactArgs(1),
+		Run:     cmd.LintCommand,
+	}
+	lintCmd.Flags().String("to", "text", "Output format: text, json or sarif")
+	lintCmd.Flags().String("lint-config", "", "Lint config file that turns rules off or changes their severity")
+	rootCmd.AddCommand(lintCmd)
+
+	schemaCmd := &cobra.Command{
+		Use:     "schema",
+		Short:   "Write the JSON Schema of the submissions of a form",
+		Example: "parser schema --file form.xml --layout nested > form.schema.json",
+		Args:    cobra.NoArgs,
+		Run:     cmd.SchemaCommand,
+	}
+	schemaCmd.Flags().StringP("file", "f", "", "Form file")
+	err = schemaCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
+	}
+	schemaCmd.Flags().String("from", "xml", "Input file type")
+	schemaCmd.Flags().String("layout", "flat", "Sections as nested objects or flattened: flat or nested")
+	schemaCmd.Flags().String("validate", "", "Submission file to validate against the schema, instead of writing it")
+	rootCmd.AddCommand(schemaCmd)
+
+	initSubmissionCmd := &cobra.Command{
+		Use:     "init-submission",
+		Short:   "Write a submission template with a value for every field of a form",
+		Example: "parser init-submission --file form.xml --random --seed 42 > submission.json",
+		Args:    cobra.NoArgs,
+		Run:     cmd.InitSubmissionCommand,
+	}
+	initSubmissionCmd.Flags().StringP("file", "f", "", "Form file")
+	err = initSubmissionCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
+	}
+	initSubmissionCmd.Flags().String("from", "xml", "Input file type")
+	initSubmissionCmd.Flags().Bool("required-only", false, "Only the fields a valid submission needs")
+	initSubmissionCmd.Flags().Bool("random", false, "Random values instead of the placeholders")
+	initSubmissionCmd.Flags().Int64("seed", 1, "Seed of the random values, the same seed gives the same submission (implies --random)")
+	rootCmd.AddCommand(initSubmissionCmd)
+
+	genCmd := &cobra.Command{
+		Use:     "gen",
+		Short:   "Generate typed Go or TypeScript code for the submissions of a form",
+		Example: "parser gen --lang go --file form.xml --package forms --out submission_gen.go",
+		Args:    cobra.NoArgs,
+		Run:     cmd.GenCommand,
+	}
+	genCmd.Flags().StringP("file", "f", "", "Form file")
+	err = genCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
+	}
+	genCmd.Flags().String("from", "xml", "Input file type")
+	genCmd.Flags().String("lang", "go", "Language of the generated code: go or typescript")
+	genCmd.Flags().String("package", "forms", "Package of the generated Go code")
+	genCmd.Flags().String("type", "Submission", "Name of the generated struct or interface")
+	genCmd.Flags().String("out", "", "File of the generated code, the standard output when not set")
+	rootCmd.AddCommand(genCmd)
+
+	fillCmd := &cobra.Command{
+		Use:     "fill",
+		Short:   "Fill the answers of a form interactively in the terminal",
+		Example: "parser fill --file form.xml --out answers.json --pdf ./output/",
+		Args:    cobra.NoArgs,
+		Run:     cmd.FillCommand,
+	}
+	fillCmd.Flags().StringP("file", "f", "", "Form file")
+	err = fillCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
+	}
+	fillCmd.Flags().StringP("out", "o", "", "Answers file, saved after every answer")
+	err = fillCmd.MarkFlagRequired("out")
+	if err != nil {
+		return nil, err
+	}
+	fillCmd.Flags().String("from", "xml", "Input file type")
+	fillCmd.Flags().Bool("resume", false, "Continue from the answers saved in the answers file")
+	fillCmd.Flags().String("pdf", "", "Output folder of a PDF of the answers, rendered at the end")
+	addRenderFlags(fillCmd)
+	rootCmd.AddCommand(fillCmd)
+
+	webformCmd := &cobra.Command{
+		Use:     "webform",
+		Short:   "Write a fillable HTML form of a form",
+		Example: "parser webform --file form.xml > form.html",
+		Args:    cobra.NoArgs,
+		Run:     cmd.WebformCommand,
+	}
+	webformCmd.Flags().StringP("file", "f", "", "Form file")
+	err = webformCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
+	}
+	webformCmd.Flags().String("from", "xml", "Input file type")
+	webformCmd.Flags().String("action", "", "Where the form is posted, the submit endpoint of parser serve by default")
+	rootCmd.AddCommand(webformCmd)
+
+	serveCmd := &cobra.Command{
+		Use:     "serve",
+		Short:   "Serve the HTML form of a form and save the posted submissions with their PDF",
+		Example: "parser serve --file form.xml --addr :8080 --out ./submissions/",
+		Args:    cobra.NoArgs,
+		Run:     cmd.ServeCommand,
+	}
+	serveCmd.Flags().StringP("file", "f", "", "Form file")
+	err = serveCmd.MarkFlagRequired("file")
+	if err != nil {
+		return nil, err
+	}
+	serveCmd.Flags().String("from", "xml", "Input file type")
+	serveCmd.Flags().String("addr", "localhost:8080", "Address the server listens on")
+	serveCmd.Flags().StringP("out", "o", "submissions", "Output folder of the submissions, the uploaded files and the PDFs")
+	addRenderFlags(serveCmd)
+	rootCmd.AddCommand(serveCmd)
+
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the settings read from the flags, the environment and the settings file",
+	}
+	configCmd.AddCommand(&cobra.Command{
+		Use:     "show [command]",
+		Short:   "Print the effective settings of a command, the parse command by default",
+		Example: "parser config show serve",
+		Run:     cmd.ConfigShowCommand,
+	})
+	rootCmd.AddCommand(configCmd)
+
+	return rootCmd, nil
+}
+
+// addRenderFlags - the flags of the rendered PDF, shared by the commands that render one.
+// They mean the same everywhere, so they are also set from the environment and the top level of the settings file
+func addRenderFlags(command *cobra.Command) {
+	flags := pflag.NewFlagSet("render", pflag.ContinueOnError)
+	flags.Bool("summary", false, "Render a summary page with the completion of the submission first")
+	flags.Bool("toc", false, "Render a table of contents on the first page")
+	flags.String("numbering", "decimal", "Section numbering scheme: decimal, alpha-roman or none")
+	flags.Bool("number-fields", false, "Number the fields inside their section. E.g. Q3.2")
+	flags.String("option-order", "declared", "Default order of the select options: declared, alpha or value")
+	flags.String("owner-password", "", "Owner password of the output (value, env:NAME or file:PATH)")
+	flags.String("user-password", "", "Password needed to open the output (value, env:NAME or file:PATH)")
+	flags.Bool("allow-print", true, "Allow printing a password protected output")
+	flags.Bool("allow-copy", false, "Allow copying from a password protected output")
+	flags.Bool("allow-modify", false, "Allow modifying a password protected output")
+	flags.String("sign-cert", "", "Sign the output with this PKCS#12 file or PEM certificate")
+	flags.String("sign-key", "", "PEM private key of the signing certificate")
+	flags.String("sign-password", "", "Password of the PKCS#12 file (value, env:NAME or file:PATH)")
+	flags.String("watermark", "on-invalid", "When to print a watermark on every page: always, never or on-invalid")
+	flags.String("watermark-text", "", "Watermark text - DRAFT, or INCOMPLETE when required answers are missing, by default")
+	flags.Float64("watermark-opacity", 0.15, "Watermark opacity between 0 and 1")
+	flags.StringSlice("author-fields", nil, "Submission fields used as the document author. E.g. first_name,last_name")
+	flags.StringToString("property", nil, "Custom document property, can be repeated. E.g. --property department=hr")
+	flags.String("stamp", "none", "Tracking code stamped on every page: none, qr or code128")
+	flags.String("stamp-position", "top-right", "Corner of the tracking code: top-left, top-right, bottom-left or bottom-right")
+	flags.Float64("stamp-size", 20, "Size (mm) of the tracking code")
+	flags.String("stamp-id-field", "", "Submission field holding the submission ID - the submission hash is used by default")
+	flags.String("profile", "internal", "Redaction profile of the sensitive answers: internal, external (masked), restricted (placeholder) or public (hidden)")
+	flags.Bool("reproducible", false, "Same inputs give the same bytes - uses SOURCE_DATE_EPOCH instead of the current time")
+	flags.Float64("indent", 5, "Indentation (mm) for every level of section nesting")
+	flags.Float64("font-step", 1, "Font size decrease (pt) for every level of section nesting")
+
+	config.MarkShared(flags)
+	command.Flags().AddFlagSet(flags)
 }
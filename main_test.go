@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The settings file of the README, shared by all the commands
+var sharedSettings = `from: xml
+to: pdf
+out: ./output/
+numbering: alpha-roman
+property: [form_id=F-12, version=3]
+commands:
+  serve:
+    out: ./submissions/
+`
+
+func TestLoadSettings_SharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".formparser.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(sharedSettings), 0o600))
+
+	tests := []struct {
+		name     string
+		command  []string
+		expected map[string]string
+	}{
+		{"parse", nil, map[string]string{"to": "pdf", "out": "./output/", "numbering": "alpha-roman"}},
+		{"lint", []string{"lint"}, map[string]string{"to": "text"}},
+		{"gen", []string{"gen"}, map[string]string{"out": ""}},
+		{"fill", []string{"fill"}, map[string]string{"out": "", "numbering": "alpha-roman"}},
+		{"serve", []string{"serve"}, map[string]string{"out": "./submissions/", "numbering": "alpha-roman"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			root, err := newRootCommand()
+			require.NoError(t, err)
+			target, _, err := root.Find(tt.command)
+			require.NoError(t, err)
+			require.NoError(t, target.ParseFlags([]string{"--config", path}))
+
+			// Act
+			err = cmd.LoadSettings(target, nil)
+
+			// Assert - the top level only sets the flags of the parse command and the shared ones
+			require.NoError(t, err)
+			for name, expected := range tt.expected {
+				assert.Equal(t, expected, target.Flags().Lookup(name).Value.String(), name)
+			}
+		})
+	}
+}
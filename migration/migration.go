@@ -0,0 +1,224 @@
+package migration
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"gopkg.in/yaml.v3"
+)
+
+/* A migration moves the submissions of an old version of a form to a new one. It is a YAML or JSON file
+   (JSON is valid YAML) with the steps, applied in this order:
+
+   renames:               # old field name: new field name
+     program_language: language
+   splits:                # one answer split into several fields
+     - field: user_name
+       into: [first_name, last_name]
+       separator: " "
+   merges:                # several answers joined into one field
+     - fields: [street, number]
+       into: address
+       separator: ", "
+   values:                # field: old value: new value. E.g. new enumeration codes
+     language:
+       A: GO
+   defaults:              # the answer of a field that has none
+     country: NL
+
+   The steps after the renames use the new names. The fields that are split or merged are removed,
+   unless they are also a target.
+*/
+
+// Migration - the steps that move a submission to a new version of a form
+type Migration struct {
+	Renames  map[string]string            `yaml:"renames"`
+	Splits   []Split                      `yaml:"splits"`
+	Merges   []Merge                      `yaml:"merges"`
+	Values   map[string]map[string]string `yaml:"values"`
+	Defaults map[string]string            `yaml:"defaults"`
+}
+
+// Split - the answer of Field split on Separator into the fields of Into, in order. The last field gets the rest.
+type Split struct {
+	Field     string   `yaml:"field"`
+	Into      []string `yaml:"into"`
+	Separator string   `yaml:"separator"`
+}
+
+// Merge - the answers of Fields joined with Separator into the Into field. Empty answers are skipped.
+type Merge struct {
+	Fields    []string `yaml:"fields"`
+	Into      string   `yaml:"into"`
+	Separator string   `yaml:"separator"`
+}
+
+// ReadMigration - parses and checks a migration file. Unknown keys are an error, to catch the typos.
+func ReadMigration(content []byte) (*Migration, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, myerrors.ErrEmptyFile
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	var migration Migration
+	if err := decoder.Decode(&migration); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidMigration, err)
+	}
+
+	if err := migration.validate(); err != nil {
+		return nil, err
+	}
+	return &migration, nil
+}
+
+// validate - all the problems of the steps at once
+func (m *Migration) validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{myerrors.ErrInvalidMigration}, args...)...))
+	}
+
+	targets := make(map[string]string)
+	for _, from := range sortedKeys(m.Renames) {
+		to := m.Renames[from]
+		if from == "" || to == "" {
+			invalid("rename %q to %q needs both names", from, to)
+		}
+		if previous, ok := targets[to]; ok {
+			invalid("%s and %s are both renamed to %s", previous, from, to)
+		}
+		targets[to] = from
+	}
+
+	for _, split := range m.Splits {
+		if split.Field == "" || len(split.Into) < 2 || split.Separator == "" {
+			invalid("split of %q needs a field, a separator and at least 2 target fields", split.Field)
+		}
+	}
+
+	for _, merge := range m.Merges {
+		if merge.Into == "" || len(merge.Fields) < 2 {
+			invalid("merge into %q needs a target and at least 2 fields", merge.Into)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Apply - the submission migrated to the new version of the form. The submission is not changed.
+func (m *Migration) Apply(submission *models.ContentSubmission) (*models.ContentSubmission, error) {
+	result := models.ContentSubmission{}
+	if submission != nil {
+		for name, value := range *submission {
+			result[name] = value
+		}
+	}
+
+	steps := []func(models.ContentSubmission) error{m.rename, m.split, m.merge, m.mapValues, m.setDefaults}
+	for _, step := range steps {
+		if err := step(result); err != nil {
+			return nil, err
+		}
+	}
+	return &result, nil
+}
+
+// rename - all the renames at once, so two fields can swap their names
+func (m *Migration) rename(answers models.ContentSubmission) error {
+	moved := make(map[string]string)
+	for _, from := range sortedKeys(m.Renames) {
+		if value, ok := answers[from]; ok {
+			moved[m.Renames[from]] = value
+			delete(answers, from)
+		}
+	}
+
+	for _, to := range sortedKeys(moved) {
+		if strings.TrimSpace(answers[to]) != "" {
+			return fmt.Errorf("%w: cannot rename to %s, the field already has an answer", myerrors.ErrMigrationConflict, to)
+		}
+		answers[to] = moved[to]
+	}
+	return nil
+}
+
+func (m *Migration) split(answers models.ContentSubmission) error {
+	for _, split := range m.Splits {
+		value, ok := answers[split.Field]
+		if !ok {
+			continue
+		}
+		delete(answers, split.Field)
+
+		parts := strings.SplitN(value, split.Separator, len(split.Into))
+		for i, part := range parts {
+			if err := setAnswer(answers, split.Into[i], strings.TrimSpace(part), split.Field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Migration) merge(answers models.ContentSubmission) error {
+	for _, merge := range m.Merges {
+		var values []string
+		for _, field := range merge.Fields {
+			if value := strings.TrimSpace(answers[field]); value != "" {
+				values = append(values, value)
+			}
+			delete(answers, field)
+		}
+
+		if len(values) > 0 {
+			if err := setAnswer(answers, merge.Into, strings.Join(values, merge.Separator), strings.Join(merge.Fields, "+")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Migration) mapValues(answers models.ContentSubmission) error {
+	for field, mapping := range m.Values {
+		if value, ok := mapping[answers[field]]; ok {
+			answers[field] = value
+		}
+	}
+	return nil
+}
+
+func (m *Migration) setDefaults(answers models.ContentSubmission) error {
+	for field, value := range m.Defaults {
+		if strings.TrimSpace(answers[field]) == "" {
+			answers[field] = value
+		}
+	}
+	return nil
+}
+
+// setAnswer - sets the answer of a split or merge target, an existing answer is a conflict
+func setAnswer(answers models.ContentSubmission, field, value, source string) error {
+	if strings.TrimSpace(answers[field]) != "" {
+		return fmt.Errorf("%w: cannot move %s to %s, the field already has an answer", myerrors.ErrMigrationConflict, source, field)
+	}
+	answers[field] = value
+	return nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
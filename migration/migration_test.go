@@ -0,0 +1,140 @@
+package migration
+
+import (
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var yamlMigration = `
+renames:
+  program_language: language
+splits:
+  - field: user_name
+    into: [first_name, last_name]
+    separator: " "
+merges:
+  - fields: [street, number]
+    into: address
+    separator: ", "
+values:
+  language:
+    A: GO
+    B: CS
+defaults:
+  country: NL
+`
+
+func TestReadMigration_YAML(t *testing.T) {
+	// Act
+	migration, err := ReadMigration([]byte(yamlMigration))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"program_language": "language"}, migration.Renames)
+	assert.Equal(t, []Split{{Field: "user_name", Into: []string{"first_name", "last_name"}, Separator: " "}}, migration.Splits)
+	assert.Equal(t, []Merge{{Fields: []string{"street", "number"}, Into: "address", Separator: ", "}}, migration.Merges)
+	assert.Equal(t, "GO", migration.Values["language"]["A"])
+	assert.Equal(t, "NL", migration.Defaults["country"])
+}
+
+func TestReadMigration_JSON(t *testing.T) {
+	// Act
+	migration, err := ReadMigration([]byte(`{"renames": {"a": "b"}, "defaults": {"c": "d"}}`))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "b", migration.Renames["a"])
+	assert.Equal(t, "d", migration.Defaults["c"])
+}
+
+func TestReadMigration_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected error
+		message  string
+	}{
+		{"empty", " \n", myerrors.ErrEmptyFile, ""},
+		{"unknown key", "rename:\n  a: b\n", myerrors.ErrInvalidMigration, "field rename not found"},
+		{"invalid yaml", "renames: [", myerrors.ErrInvalidMigration, ""},
+		{"empty rename", "renames:\n  a: \"\"\n", myerrors.ErrInvalidMigration, "needs both names"},
+		{"same target", "renames:\n  a: c\n  b: c\n", myerrors.ErrInvalidMigration, "a and b are both renamed to c"},
+		{"split into one field", "splits:\n  - field: a\n    into: [b]\n    separator: \" \"\n", myerrors.ErrInvalidMigration, "split of \"a\""},
+		{"merge one field", "merges:\n  - fields: [a]\n    into: b\n", myerrors.ErrInvalidMigration, "merge into \"b\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadMigration([]byte(tt.content))
+
+			assert.ErrorIs(t, err, tt.expected)
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	// Arrange
+	migration, err := ReadMigration([]byte(yamlMigration))
+	require.NoError(t, err)
+	submission := &models.ContentSubmission{
+		"program_language": "A",
+		"user_name":        "Jane van Dijk",
+		"street":           "Main Street",
+		"number":           "1",
+		"email":            "a@b.c",
+	}
+
+	// Act
+	result, err := migration.Apply(submission)
+
+	// Assert
+	require.NoError(t, err)
+	expected := &models.ContentSubmission{
+		"language":   "GO",
+		"first_name": "Jane",
+		"last_name":  "van Dijk",
+		"address":    "Main Street, 1",
+		"country":    "NL",
+		"email":      "a@b.c",
+	}
+	assert.Equal(t, expected, result)
+	assert.Equal(t, "A", (*submission)["program_language"], "the submission is not changed")
+}
+
+func TestApply_SwapRenames(t *testing.T) {
+	// Arrange
+	migration := &Migration{Renames: map[string]string{"a": "b", "b": "a"}}
+
+	// Act
+	result, err := migration.Apply(&models.ContentSubmission{"a": "1", "b": "2"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &models.ContentSubmission{"a": "2", "b": "1"}, result)
+}
+
+func TestApply_Conflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		migration *Migration
+		message   string
+	}{
+		{"rename", &Migration{Renames: map[string]string{"a": "b"}}, "cannot rename to b"},
+		{"split", &Migration{Splits: []Split{{Field: "a", Into: []string{"c", "b"}, Separator: " "}}}, "cannot move a to b"},
+		{"merge", &Migration{Merges: []Merge{{Fields: []string{"a", "c"}, Into: "b"}}}, "cannot move a+c to b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.migration.Apply(&models.ContentSubmission{"a": "x y", "b": "taken"})
+
+			assert.ErrorIs(t, err, myerrors.ErrMigrationConflict)
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+type ChangeType string
+
+// How an item changed between two versions
+const (
+	UnchangedChangeType ChangeType = "unchanged"
+	AddedChangeType     ChangeType = "added"
+	RemovedChangeType   ChangeType = "removed"
+	ChangedChangeType   ChangeType = "changed"
+
+	// The changes of a field or section between two versions of a form
+	MovedChangeType    ChangeType = "moved"    // in another section
+	RetypedChangeType  ChangeType = "retyped"  // FieldType or Type changed
+	CaptionChangeType  ChangeType = "caption"  // Caption of a field or Title of a section changed
+	OptionsChangeType  ChangeType = "options"  // labels or enumeration members changed
+	OptionalChangeType ChangeType = "optional" // Optional flag changed
+)
@@ -1,5 +1,7 @@
 package models
 
+import "strings"
+
 /* We cannot have a static model here. Each form is dynamic, it can have multiple elements
    But one thing is for sure: the file (when valid) will have different elements that have opening and closing tags
    * example: <Field> ... </Field>
@@ -28,6 +30,45 @@ type ContentNode struct {
 	Children    []*ContentNode
 }
 
+// Option - a label of a select field. E.g. <Label Name="A">A(+)</Label>
+type Option struct {
+	Name string
+	Text string
+}
+
+// Options - the labels of a select field in document order. Labels without a Name are skipped.
+func (n *ContentNode) Options() []Option {
+	var options []Option
+	for _, child := range n.Children {
+		if child.ElementType != LabelsElementType {
+			continue
+		}
+		for _, labelNode := range child.Children {
+			name, ok := labelNode.Metadata["Name"]
+			if labelNode.ElementType != LabelElementType || !ok {
+				continue
+			}
+			options = append(options, Option{Name: name, Text: labelNode.Value})
+		}
+	}
+	return options
+}
+
+// BoolMetadata - reads a True/False attribute. Missing or invalid values are false.
+func (n *ContentNode) BoolMetadata(key string) bool {
+	return strings.EqualFold(n.Metadata[key], "true")
+}
+
+// IsRequired - only Optional="False" makes a field or section required
+func (n *ContentNode) IsRequired() bool {
+	return strings.EqualFold(n.Metadata["Optional"], "false")
+}
+
+// IsSensitive - Sensitive="True" or Classification="PII" marks a field whose answer is redacted for third parties
+func (n *ContentNode) IsSensitive() bool {
+	return n.BoolMetadata("Sensitive") || strings.EqualFold(n.Metadata["Classification"], "PII")
+}
+
 /* Since I am not sure how the submission values get here, and the XML does not have the user data,
    I decided to use a separate JSON file that will hold that.
 
@@ -5,14 +5,15 @@ import "strings"
 type ElementType string
 
 const (
-	FormElementType     ElementType = "form"
-	FieldElementType    ElementType = "field"
-	CaptionElementType  ElementType = "caption"
-	LabelsElementType   ElementType = "labels"
-	LabelElementType    ElementType = "label"
-	SectionElementType  ElementType = "section"
-	TitleElementType    ElementType = "title"
-	ContentsElementType ElementType = "contents"
+	FormElementType      ElementType = "form"
+	FieldElementType     ElementType = "field"
+	CaptionElementType   ElementType = "caption"
+	LabelsElementType    ElementType = "labels"
+	LabelElementType     ElementType = "label"
+	SectionElementType   ElementType = "section"
+	TitleElementType     ElementType = "title"
+	ContentsElementType  ElementType = "contents"
+	PageBreakElementType ElementType = "pagebreak"
 
 	UnknownElementType ElementType = "unknown"
 )
@@ -36,6 +37,8 @@ func SafeReadElementType(name string) ElementType {
 		return ContentsElementType
 	case "labels":
 		return LabelsElementType
+	case "pagebreak":
+		return PageBreakElementType
 	default:
 		return UnknownElementType
 	}
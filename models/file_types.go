@@ -6,10 +6,12 @@ type FileType string
 
 // Using a custom enum to keep the file types centrally
 const (
-	XMLFileType  FileType = "xml"
-	JSonFileType FileType = "json"
-	PDFFileType  FileType = "pdf"
-	HTMLFileType FileType = "html"
+	XMLFileType   FileType = "xml"
+	JSonFileType  FileType = "json"
+	PDFFileType   FileType = "pdf"
+	HTMLFileType  FileType = "html"
+	TextFileType  FileType = "text"
+	SARIFFileType FileType = "sarif"
 
 	UnknownFileType FileType = "unknown"
 )
@@ -25,6 +27,10 @@ func SafeReadFileFormat(name string) FileType {
 		return PDFFileType
 	case "html":
 		return HTMLFileType
+	case "text", "txt":
+		return TextFileType
+	case "sarif":
+		return SARIFFileType
 
 	default:
 		return UnknownFileType
@@ -0,0 +1,26 @@
+package models
+
+import "strings"
+
+type Language string
+
+// The languages of the generated code
+const (
+	GoLanguage         Language = "go"
+	TypeScriptLanguage Language = "typescript"
+
+	UnknownLanguage Language = "unknown"
+)
+
+// SafeReadLanguage - read the language in a safe way to avoid panics.
+func SafeReadLanguage(name string) Language {
+	switch strings.ToLower(name) {
+	case "go", "golang":
+		return GoLanguage
+	case "typescript", "ts":
+		return TypeScriptLanguage
+
+	default:
+		return UnknownLanguage
+	}
+}
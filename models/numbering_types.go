@@ -0,0 +1,29 @@
+package models
+
+import "strings"
+
+type NumberingScheme string
+
+// Section numbering schemes - each level of nesting adds a new component to the number
+const (
+	DecimalNumberingScheme    NumberingScheme = "decimal"     // 1. 1.2. 1.2.3.
+	AlphaRomanNumberingScheme NumberingScheme = "alpha-roman" // A. A.i. A.i.a.
+	NoNumberingScheme         NumberingScheme = "none"
+
+	UnknownNumberingScheme NumberingScheme = "unknown"
+)
+
+// SafeReadNumberingScheme - read the numbering scheme in a safe way to avoid panics.
+func SafeReadNumberingScheme(name string) NumberingScheme {
+	switch strings.ToLower(name) {
+	case "decimal":
+		return DecimalNumberingScheme
+	case "alpha-roman":
+		return AlphaRomanNumberingScheme
+	case "none":
+		return NoNumberingScheme
+
+	default:
+		return UnknownNumberingScheme
+	}
+}
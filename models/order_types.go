@@ -0,0 +1,29 @@
+package models
+
+import "strings"
+
+type OptionOrder string
+
+// The order in which the labels (options) of a select field are rendered
+const (
+	DeclaredOptionOrder OptionOrder = "declared" // as written in the form
+	AlphaOptionOrder    OptionOrder = "alpha"    // by label Name
+	ValueOptionOrder    OptionOrder = "value"    // by label text
+
+	UnknownOptionOrder OptionOrder = "unknown"
+)
+
+// SafeReadOptionOrder - read the option order in a safe way to avoid panics.
+func SafeReadOptionOrder(name string) OptionOrder {
+	switch strings.ToLower(name) {
+	case "declared":
+		return DeclaredOptionOrder
+	case "alpha":
+		return AlphaOptionOrder
+	case "value":
+		return ValueOptionOrder
+
+	default:
+		return UnknownOptionOrder
+	}
+}
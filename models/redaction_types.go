@@ -0,0 +1,32 @@
+package models
+
+import "strings"
+
+type RedactionAction string
+
+// What happens to the answers of the sensitive fields
+const (
+	NoRedactionAction          RedactionAction = "none"
+	MaskRedactionAction        RedactionAction = "mask"        // only the last characters stay visible. E.g. ****1234
+	HideRedactionAction        RedactionAction = "hide"        // the field is left out of the output
+	PlaceholderRedactionAction RedactionAction = "placeholder" // the answer is replaced with a fixed text
+
+	UnknownRedactionAction RedactionAction = "unknown"
+)
+
+// SafeReadRedactionAction - read the redaction action in a safe way to avoid panics.
+func SafeReadRedactionAction(name string) RedactionAction {
+	switch strings.ToLower(name) {
+	case "none":
+		return NoRedactionAction
+	case "mask":
+		return MaskRedactionAction
+	case "hide":
+		return HideRedactionAction
+	case "placeholder":
+		return PlaceholderRedactionAction
+
+	default:
+		return UnknownRedactionAction
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+import "strings"
+
+type SchemaLayout string
+
+// How the sections of a form are represented in the JSON Schema of its submissions
+const (
+	FlatSchemaLayout   SchemaLayout = "flat"   // all the fields are properties of the submission, like in the submission files
+	NestedSchemaLayout SchemaLayout = "nested" // every named section is an object with the properties of its fields
+
+	UnknownSchemaLayout SchemaLayout = "unknown"
+)
+
+// SafeReadSchemaLayout - read the schema layout in a safe way to avoid panics.
+func SafeReadSchemaLayout(name string) SchemaLayout {
+	switch strings.ToLower(name) {
+	case "flat":
+		return FlatSchemaLayout
+	case "nested":
+		return NestedSchemaLayout
+
+	default:
+		return UnknownSchemaLayout
+	}
+}
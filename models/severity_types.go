@@ -0,0 +1,29 @@
+package models
+
+import "strings"
+
+type Severity string
+
+// How serious a lint problem is. Only errors fail the lint.
+const (
+	ErrorSeverity   Severity = "error"
+	WarningSeverity Severity = "warning"
+	InfoSeverity    Severity = "info"
+
+	UnknownSeverity Severity = "unknown"
+)
+
+// SafeReadSeverity - read the severity in a safe way to avoid panics.
+func SafeReadSeverity(name string) Severity {
+	switch strings.ToLower(name) {
+	case "error":
+		return ErrorSeverity
+	case "warning":
+		return WarningSeverity
+	case "info":
+		return InfoSeverity
+
+	default:
+		return UnknownSeverity
+	}
+}
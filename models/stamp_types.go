@@ -0,0 +1,68 @@
+package models
+
+import "strings"
+
+type StampType string
+
+// The code stamped on every page for tracking
+const (
+	NoStampType      StampType = "none"
+	QRStampType      StampType = "qr"
+	Code128StampType StampType = "code128"
+
+	UnknownStampType StampType = "unknown"
+)
+
+// SafeReadStampType - read the stamp type in a safe way to avoid panics.
+func SafeReadStampType(name string) StampType {
+	switch strings.ToLower(name) {
+	case "none":
+		return NoStampType
+	case "qr":
+		return QRStampType
+	case "code128":
+		return Code128StampType
+
+	default:
+		return UnknownStampType
+	}
+}
+
+type StampPosition string
+
+// The corner of the page the stamp is placed in
+const (
+	TopLeftStampPosition     StampPosition = "top-left"
+	TopRightStampPosition    StampPosition = "top-right"
+	BottomLeftStampPosition  StampPosition = "bottom-left"
+	BottomRightStampPosition StampPosition = "bottom-right"
+
+	UnknownStampPosition StampPosition = "unknown"
+)
+
+// SafeReadStampPosition - read the stamp position in a safe way to avoid panics.
+func SafeReadStampPosition(name string) StampPosition {
+	switch strings.ToLower(name) {
+	case "top-left":
+		return TopLeftStampPosition
+	case "top-right":
+		return TopRightStampPosition
+	case "bottom-left":
+		return BottomLeftStampPosition
+	case "bottom-right":
+		return BottomRightStampPosition
+
+	default:
+		return UnknownStampPosition
+	}
+}
+
+// IsTop - the stamp takes space from the top margin
+func (p StampPosition) IsTop() bool {
+	return p == TopLeftStampPosition || p == TopRightStampPosition
+}
+
+// IsLeft - the stamp is aligned with the left margin
+func (p StampPosition) IsLeft() bool {
+	return p == TopLeftStampPosition || p == BottomLeftStampPosition
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TrackingCode - what the code stamped on a page holds, so a scanned page can be matched to its submission
+type TrackingCode struct {
+	SubmissionID string
+	FormID       string
+	FormVersion  string
+	Page         int
+	Pages        int
+}
+
+/* The tracking code is written as key=value pairs separated by semicolons, short enough for a Code 128 barcode:
+   sub=c88eea39a8a2;form=job_application;ver=2;page=1/3
+*/
+
+// String - the text encoded in the stamp
+func (c TrackingCode) String() string {
+	return fmt.Sprintf("sub=%s;form=%s;ver=%s;page=%d/%d", c.SubmissionID, c.FormID, c.FormVersion, c.Page, c.Pages)
+}
+
+// ParseTrackingCode - reads the text of a scanned stamp
+func ParseTrackingCode(text string) (TrackingCode, error) {
+	var code TrackingCode
+
+	for _, pair := range strings.Split(text, ";") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return code, fmt.Errorf("invalid tracking code part: %q", pair)
+		}
+
+		switch key {
+		case "sub":
+			code.SubmissionID = value
+		case "form":
+			code.FormID = value
+		case "ver":
+			code.FormVersion = value
+		case "page":
+			page, pages, _ := strings.Cut(value, "/")
+			var err error
+			if code.Page, err = strconv.Atoi(page); err != nil {
+				return code, fmt.Errorf("invalid page: %q", value)
+			}
+			if code.Pages, err = strconv.Atoi(pages); err != nil {
+				return code, fmt.Errorf("invalid page: %q", value)
+			}
+		}
+	}
+
+	return code, nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackingCode_RoundTrip(t *testing.T) {
+	// Arrange
+	code := TrackingCode{SubmissionID: "c88eea39a8a2", FormID: "job_application", FormVersion: "2", Page: 1, Pages: 3}
+
+	// Act
+	text := code.String()
+	parsed, err := ParseTrackingCode(text)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "sub=c88eea39a8a2;form=job_application;ver=2;page=1/3", text)
+	assert.Equal(t, code, parsed)
+}
+
+func TestParseTrackingCode_Invalid(t *testing.T) {
+	tests := []string{
+		"sub=1;form",
+		"sub=1;page=x/2",
+		"sub=1;page=1",
+	}
+
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			_, err := ParseTrackingCode(text)
+			assert.Error(t, err)
+		})
+	}
+}
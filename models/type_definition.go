@@ -0,0 +1,106 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+/* The Type attribute of a field describes the data and its constraints. E.g.
+ * Text([0,200],Lines:4)  -> Name: Text, Arguments: [[0,200]], Options: {Lines: 4}
+ * Enumeration(A,B,C)     -> Name: Enumeration, Arguments: [A B C]
+ * File(Images,MaxWidth:80) -> Name: File, Arguments: [Images], Options: {MaxWidth: 80}
+ * Date                   -> Name: Date
+
+The arguments are split on commas outside of brackets, so [0,200] stays one argument.
+An argument with a colon is a Key:Value option, the rest are positional arguments.
+*/
+
+// TypeDefinition - the parsed Type attribute of a field
+type TypeDefinition struct {
+	Name      string
+	Arguments []string
+	Options   map[string]string
+}
+
+// ParseTypeDefinition - parses the Type attribute of a field. Invalid values give a definition with only a Name.
+func ParseTypeDefinition(value string) TypeDefinition {
+	definition := TypeDefinition{Options: make(map[string]string)}
+
+	value = strings.TrimSpace(value)
+	open := strings.Index(value, "(")
+	if open < 0 || !strings.HasSuffix(value, ")") {
+		definition.Name = value
+		return definition
+	}
+
+	definition.Name = strings.TrimSpace(value[:open])
+	for _, argument := range splitArguments(value[open+1 : len(value)-1]) {
+		if key, option, ok := strings.Cut(argument, ":"); ok {
+			definition.Options[strings.TrimSpace(key)] = strings.TrimSpace(option)
+			continue
+		}
+		definition.Arguments = append(definition.Arguments, argument)
+	}
+
+	return definition
+}
+
+// HasArgument - true when the positional argument is present. The comparison ignores the case.
+func (d TypeDefinition) HasArgument(argument string) bool {
+	for _, a := range d.Arguments {
+		if strings.EqualFold(a, argument) {
+			return true
+		}
+	}
+	return false
+}
+
+// LengthRange - the [min,max] length argument of a Text type. E.g. Text([0,200]) -> 0, 200, true
+func (d TypeDefinition) LengthRange() (int, int, bool) {
+	for _, argument := range d.Arguments {
+		if !strings.HasPrefix(argument, "[") || !strings.HasSuffix(argument, "]") {
+			continue
+		}
+
+		minValue, maxValue, ok := strings.Cut(argument[1:len(argument)-1], ",")
+		if !ok {
+			continue
+		}
+		minimum, minErr := strconv.Atoi(strings.TrimSpace(minValue))
+		maximum, maxErr := strconv.Atoi(strings.TrimSpace(maxValue))
+		if minErr == nil && maxErr == nil {
+			return minimum, maximum, true
+		}
+	}
+	return 0, 0, false
+}
+
+// splitArguments - splits on the commas that are not inside brackets
+func splitArguments(value string) []string {
+	var arguments []string
+	depth, start := 0, 0
+
+	for i, c := range value {
+		switch c {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				arguments = appendArgument(arguments, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return appendArgument(arguments, value[start:])
+}
+
+func appendArgument(arguments []string, argument string) []string {
+	argument = strings.TrimSpace(argument)
+	if argument == "" {
+		return arguments
+	}
+	return append(arguments, argument)
+}
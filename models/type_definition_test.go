@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTypeDefinition(t *testing.T) {
+	tests := []struct {
+		input     string
+		name      string
+		arguments []string
+		options   map[string]string
+	}{
+		{"Text([0,200],Lines:4)", "Text", []string{"[0,200]"}, map[string]string{"Lines": "4"}},
+		{"Enumeration(A,B,C)", "Enumeration", []string{"A", "B", "C"}, map[string]string{}},
+		{"File(Images, MaxWidth:80)", "File", []string{"Images"}, map[string]string{"MaxWidth": "80"}},
+		{"File", "File", nil, map[string]string{}},
+		{"Date", "Date", nil, map[string]string{}},
+		{"Broken(", "Broken(", nil, map[string]string{}},
+		{"", "", nil, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run("TypeDefinition_"+tt.input, func(t *testing.T) {
+			result := ParseTypeDefinition(tt.input)
+			assert.Equal(t, tt.name, result.Name)
+			assert.Equal(t, tt.arguments, result.Arguments)
+			assert.Equal(t, tt.options, result.Options)
+		})
+	}
+}
+
+func TestTypeDefinition_HasArgument(t *testing.T) {
+	definition := ParseTypeDefinition("File(Images,MaxWidth:80)")
+
+	assert.True(t, definition.HasArgument("images"))
+	assert.False(t, definition.HasArgument("MaxWidth"))
+}
+
+func TestTypeDefinition_LengthRange(t *testing.T) {
+	tests := []struct {
+		input    string
+		minimum  int
+		maximum  int
+		expected bool
+	}{
+		{"Text([0,200],Lines:4)", 0, 200, true},
+		{"Text([ 5 , 20 ])", 5, 20, true},
+		{"Text", 0, 0, false},
+		{"Text([a,b])", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("LengthRange_"+tt.input, func(t *testing.T) {
+			minimum, maximum, ok := ParseTypeDefinition(tt.input).LengthRange()
+			assert.Equal(t, tt.expected, ok)
+			assert.Equal(t, tt.minimum, minimum)
+			assert.Equal(t, tt.maximum, maximum)
+		})
+	}
+}
@@ -39,6 +39,7 @@ func TestSafeReadElementType(t *testing.T) {
 		{"Label", LabelElementType},
 		{"Title", TitleElementType},
 		{"Contents", ContentsElementType},
+		{"PageBreak", PageBreakElementType},
 		{"Unknown", UnknownElementType},
 		{"", UnknownElementType},
 	}
@@ -58,6 +59,9 @@ func TestSafeReadFileFormat(t *testing.T) {
 	}{
 		{"PDF", PDFFileType},
 		{"HTML", HTMLFileType},
+		{"txt", TextFileType},
+		{"Text", TextFileType},
+		{"SARIF", SARIFFileType},
 		{"Unknown", UnknownFileType},
 		{"", UnknownFileType},
 	}
@@ -69,3 +73,226 @@ func TestSafeReadFileFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestSafeReadNumberingScheme(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected NumberingScheme
+	}{
+		{"Decimal", DecimalNumberingScheme},
+		{"alpha-roman", AlphaRomanNumberingScheme},
+		{"None", NoNumberingScheme},
+		{"Unknown", UnknownNumberingScheme},
+		{"", UnknownNumberingScheme},
+	}
+
+	for _, tt := range tests {
+		t.Run("NumberingScheme_"+tt.input, func(t *testing.T) {
+			result := SafeReadNumberingScheme(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestContentNode_BoolMetadata(t *testing.T) {
+	node := &ContentNode{Metadata: map[string]string{
+		"Optional":        "True",
+		"PageBreakBefore": "false",
+		"Invalid":         "yes",
+	}}
+
+	assert.True(t, node.BoolMetadata("Optional"))
+	assert.False(t, node.BoolMetadata("PageBreakBefore"))
+	assert.False(t, node.BoolMetadata("Invalid"))
+	assert.False(t, node.BoolMetadata("Missing"))
+}
+
+func TestContentNode_IsRequired(t *testing.T) {
+	assert.True(t, (&ContentNode{Metadata: map[string]string{"Optional": "False"}}).IsRequired())
+	assert.False(t, (&ContentNode{Metadata: map[string]string{"Optional": "True"}}).IsRequired())
+	assert.False(t, (&ContentNode{Metadata: map[string]string{}}).IsRequired())
+}
+
+func TestContentNode_IsSensitive(t *testing.T) {
+	assert.True(t, (&ContentNode{Metadata: map[string]string{"Sensitive": "True"}}).IsSensitive())
+	assert.True(t, (&ContentNode{Metadata: map[string]string{"Classification": "pii"}}).IsSensitive())
+	assert.False(t, (&ContentNode{Metadata: map[string]string{"Classification": "Public"}}).IsSensitive())
+	assert.False(t, (&ContentNode{Metadata: map[string]string{}}).IsSensitive())
+}
+
+func TestSafeReadWatermarkPolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected WatermarkPolicy
+	}{
+		{"Always", AlwaysWatermarkPolicy},
+		{"never", NeverWatermarkPolicy},
+		{"on-invalid", OnInvalidWatermarkPolicy},
+		{"Unknown", UnknownWatermarkPolicy},
+		{"", UnknownWatermarkPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run("WatermarkPolicy_"+tt.input, func(t *testing.T) {
+			result := SafeReadWatermarkPolicy(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSafeReadOptionOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected OptionOrder
+	}{
+		{"Declared", DeclaredOptionOrder},
+		{"alpha", AlphaOptionOrder},
+		{"Value", ValueOptionOrder},
+		{"Unknown", UnknownOptionOrder},
+		{"", UnknownOptionOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run("OptionOrder_"+tt.input, func(t *testing.T) {
+			result := SafeReadOptionOrder(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestContentNode_Options(t *testing.T) {
+	node := &ContentNode{
+		ElementType: FieldElementType,
+		Children: []*ContentNode{
+			{ElementType: CaptionElementType, Value: "Pick one"},
+			{ElementType: LabelsElementType, Children: []*ContentNode{
+				{ElementType: LabelElementType, Metadata: map[string]string{"Name": "C"}, Value: "C lang"},
+				{ElementType: LabelElementType, Metadata: map[string]string{}, Value: "no name"},
+				{ElementType: LabelElementType, Metadata: map[string]string{"Name": "A"}, Value: "A lang"},
+			}},
+		},
+	}
+
+	assert.Equal(t, []Option{{Name: "C", Text: "C lang"}, {Name: "A", Text: "A lang"}}, node.Options())
+}
+
+func TestSafeReadStampType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected StampType
+	}{
+		{"QR", QRStampType},
+		{"code128", Code128StampType},
+		{"none", NoStampType},
+		{"ean13", UnknownStampType},
+	}
+
+	for _, tt := range tests {
+		t.Run("StampType_"+tt.input, func(t *testing.T) {
+			result := SafeReadStampType(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSafeReadStampPosition(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected StampPosition
+		top      bool
+		left     bool
+	}{
+		{"top-left", TopLeftStampPosition, true, true},
+		{"Top-Right", TopRightStampPosition, true, false},
+		{"bottom-left", BottomLeftStampPosition, false, true},
+		{"bottom-right", BottomRightStampPosition, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("StampPosition_"+tt.input, func(t *testing.T) {
+			result := SafeReadStampPosition(tt.input)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.top, result.IsTop())
+			assert.Equal(t, tt.left, result.IsLeft())
+		})
+	}
+
+	assert.Equal(t, UnknownStampPosition, SafeReadStampPosition("center"))
+}
+
+func TestSafeReadRedactionAction(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected RedactionAction
+	}{
+		{"None", NoRedactionAction},
+		{"mask", MaskRedactionAction},
+		{"hide", HideRedactionAction},
+		{"Placeholder", PlaceholderRedactionAction},
+		{"blur", UnknownRedactionAction},
+	}
+
+	for _, tt := range tests {
+		t.Run("RedactionAction_"+tt.input, func(t *testing.T) {
+			result := SafeReadRedactionAction(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSafeReadSeverity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Severity
+	}{
+		{"Error", ErrorSeverity},
+		{"warning", WarningSeverity},
+		{"INFO", InfoSeverity},
+		{"fatal", UnknownSeverity},
+	}
+
+	for _, tt := range tests {
+		t.Run("Severity_"+tt.input, func(t *testing.T) {
+			result := SafeReadSeverity(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSafeReadSchemaLayout(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected SchemaLayout
+	}{
+		{"Flat", FlatSchemaLayout},
+		{"nested", NestedSchemaLayout},
+		{"tree", UnknownSchemaLayout},
+	}
+
+	for _, tt := range tests {
+		t.Run("SchemaLayout_"+tt.input, func(t *testing.T) {
+			result := SafeReadSchemaLayout(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSafeReadLanguage(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Language
+	}{
+		{"Go", GoLanguage},
+		{"golang", GoLanguage},
+		{"TypeScript", TypeScriptLanguage},
+		{"ts", TypeScriptLanguage},
+		{"rust", UnknownLanguage},
+	}
+
+	for _, tt := range tests {
+		t.Run("Language_"+tt.input, func(t *testing.T) {
+			result := SafeReadLanguage(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
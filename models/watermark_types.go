@@ -0,0 +1,29 @@
+package models
+
+import "strings"
+
+type WatermarkPolicy string
+
+// When the output gets a watermark on every page
+const (
+	AlwaysWatermarkPolicy    WatermarkPolicy = "always"
+	NeverWatermarkPolicy     WatermarkPolicy = "never"
+	OnInvalidWatermarkPolicy WatermarkPolicy = "on-invalid" // only when required answers are missing
+
+	UnknownWatermarkPolicy WatermarkPolicy = "unknown"
+)
+
+// SafeReadWatermarkPolicy - read the watermark policy in a safe way to avoid panics.
+func SafeReadWatermarkPolicy(name string) WatermarkPolicy {
+	switch strings.ToLower(name) {
+	case "always":
+		return AlwaysWatermarkPolicy
+	case "never":
+		return NeverWatermarkPolicy
+	case "on-invalid":
+		return OnInvalidWatermarkPolicy
+
+	default:
+		return UnknownWatermarkPolicy
+	}
+}
@@ -1,13 +1,23 @@
 package parsers
 
 import (
+	"os"
 	"testing"
 
+	"github.com/alex-pricope/form-parser/logging"
 	"github.com/alex-pricope/form-parser/models"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestMain(m *testing.M) {
+	logging.Log = logrus.New()
+	logging.Log.SetLevel(logrus.FatalLevel)
+
+	os.Exit(m.Run())
+}
+
 func TestGetParser_XMLFileType(t *testing.T) {
 	// Arrange
 	parser, err := GetParser(models.XMLFileType)
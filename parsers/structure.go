@@ -0,0 +1,45 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+// validateStructure - checks the parsed graph for names that must be unique. All the problems are reported at once.
+// * Field Name - the submission links the answers to the fields by name
+// * Label Name inside a field - the submission selects the option by name
+func validateStructure(root *models.ContentNode) error {
+	var errs []error
+	fieldNames := make(map[string]bool)
+
+	var visit func(node *models.ContentNode)
+	visit = func(node *models.ContentNode) {
+		if node.ElementType == models.FieldElementType && node.Name != "" {
+			if fieldNames[node.Name] {
+				errs = append(errs, fmt.Errorf("%w: %s", myerrors.ErrDuplicateFieldName, node.Name))
+			}
+			fieldNames[node.Name] = true
+
+			labelNames := make(map[string]bool)
+			for _, option := range node.Options() {
+				if labelNames[option.Name] {
+					errs = append(errs, fmt.Errorf("%w: %s in field %s", myerrors.ErrDuplicateLabelName, option.Name, node.Name))
+				}
+				labelNames[option.Name] = true
+			}
+		}
+
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+
+	if root != nil {
+		visit(root)
+	}
+
+	return errors.Join(errs...)
+}
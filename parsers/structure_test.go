@@ -0,0 +1,69 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLParser_Parse_DuplicateFieldName(t *testing.T) {
+	// Arrange
+	parser := &XMLParser{}
+	content := []byte(`<Form>
+		<Field Name="other" FieldType="TextBox"><Caption>First</Caption></Field>
+		<Section Name="experience">
+			<Contents>
+				<Field Name="other" FieldType="TextBox"><Caption>Second</Caption></Field>
+			</Contents>
+		</Section>
+	</Form>`)
+
+	// Act
+	rootNode, err := parser.Parse(content)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, rootNode)
+	assert.ErrorIs(t, err, myerrors.ErrDuplicateFieldName)
+	assert.Contains(t, err.Error(), "other")
+}
+
+func TestXMLParser_Parse_DuplicateLabelName(t *testing.T) {
+	// Arrange
+	parser := &XMLParser{}
+	content := []byte(`<Form>
+		<Field Name="program_language" FieldType="Select">
+			<Caption>Pick your programing language</Caption>
+			<Labels>
+				<Label Name="A">A(+)</Label>
+				<Label Name="A">Another A</Label>
+			</Labels>
+		</Field>
+	</Form>`)
+
+	// Act
+	rootNode, err := parser.Parse(content)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, rootNode)
+	assert.ErrorIs(t, err, myerrors.ErrDuplicateLabelName)
+	assert.Contains(t, err.Error(), "A in field program_language")
+}
+
+func TestValidateStructure_ValidForm(t *testing.T) {
+	// Arrange
+	content, err := os.ReadFile("../tests/payload/complex_valid_xml")
+	require.NoError(t, err)
+	root, err := (&XMLParser{}).parseXMLContent(content)
+	require.NoError(t, err)
+
+	// Act
+	err = validateStructure(root)
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -23,6 +23,12 @@ func (p *XMLParser) Parse(content []byte) (*models.ContentNode, error) {
 		return nil, err
 	}
 
+	err = validateStructure(root)
+	if err != nil {
+		logging.Log.Errorf("XMLParser invalid form structure: %s", err)
+		return nil, err
+	}
+
 	return root, nil
 }
 
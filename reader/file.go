@@ -5,6 +5,9 @@ import (
 	myerrors "github.com/alex-pricope/form-parser/errors"
 	"github.com/alex-pricope/form-parser/models"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 type Reader interface {
@@ -46,3 +49,40 @@ func (r *FileReader) ReadSubmissionFile(fileName string) (*models.ContentSubmiss
 
 	return &submission, nil
 }
+
+// ListSubmissionFiles - the submission files of the paths. A folder gives its *.json files, in name order,
+// except the ones whose name ends with skipSuffix (e.g. the outputs of an earlier run). A file path is always kept.
+func ListSubmissionFiles(paths []string, skipSuffix string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		var folderFiles []string
+		for _, entry := range entries {
+			extension := filepath.Ext(entry.Name())
+			if entry.IsDir() || !strings.EqualFold(extension, ".json") {
+				continue
+			}
+			if skipSuffix != "" && strings.HasSuffix(strings.TrimSuffix(entry.Name(), extension), skipSuffix) {
+				continue
+			}
+			folderFiles = append(folderFiles, filepath.Join(path, entry.Name()))
+		}
+		sort.Strings(folderFiles)
+		files = append(files, folderFiles...)
+	}
+
+	return files, nil
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/alex-pricope/form-parser/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -82,3 +84,28 @@ func TestReadFileAsBinary_FileIsEmpty(t *testing.T) {
 	require.Empty(t, result)
 	require.NotNil(t, result)
 }
+
+func TestListSubmissionFiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	for _, name := range []string{"b.json", "a.JSON", "notes.txt", "a_migrated.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested.json"), 0o700))
+
+	// Act
+	files, err := ListSubmissionFiles([]string{"../tests/payload/valid_submission", dir}, "_migrated")
+
+	// Assert
+	require.NoError(t, err)
+	expected := []string{"../tests/payload/valid_submission", filepath.Join(dir, "a.JSON"), filepath.Join(dir, "b.json")}
+	assert.Equal(t, expected, files)
+}
+
+func TestListSubmissionFiles_NotFound(t *testing.T) {
+	// Act
+	_, err := ListSubmissionFiles([]string{"non_existent_folder"}, "")
+
+	// Assert
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package render
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/schema"
+)
+
+// completion - the rendered fields split by their answer status, in document order
+type completion struct {
+	answered        []*models.ContentNode
+	missingRequired []*models.ContentNode
+	missingOptional []*models.ContentNode
+}
+
+/* A field is required when the schema of the form requires it (see schema.IsRequired) - Optional="False",
+   and inside an optional section only if the applicant started the section. An untouched optional section is not an error.
+*/
+
+// checkCompletion - checks the answer of every field that is rendered
+func checkCompletion(root *models.ContentNode, submission *models.ContentSubmission) *completion {
+	submissionSchema := schema.Generate(root, "", models.FlatSchemaLayout)
+	instance := schema.Instance(root, submission, models.FlatSchemaLayout)
+
+	result := &completion{}
+	visitFields(root, func(node *models.ContentNode) {
+		if readFieldType(node) == models.UnknownFieldType {
+			return
+		}
+
+		switch {
+		case isAnswered(submission, node.Name):
+			result.answered = append(result.answered, node)
+		case schema.IsRequired(submissionSchema, instance, node.Name):
+			result.missingRequired = append(result.missingRequired, node)
+		default:
+			result.missingOptional = append(result.missingOptional, node)
+		}
+	})
+	return result
+}
+
+// complete - no required answer is missing
+func (c *completion) complete() bool {
+	return len(c.missingRequired) == 0
+}
+
+func (c *completion) isMissingRequired(node *models.ContentNode) bool {
+	return slices.Contains(c.missingRequired, node)
+}
+
+func isAnswered(submission *models.ContentSubmission, fieldName string) bool {
+	if submission == nil {
+		return false
+	}
+	return strings.TrimSpace((*submission)[fieldName]) != ""
+}
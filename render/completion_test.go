@@ -0,0 +1,65 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func withOptional(node *models.ContentNode, optional string) *models.ContentNode {
+	node.Metadata["Optional"] = optional
+	return node
+}
+
+func TestCheckCompletion_SplitsFieldsByAnswer(t *testing.T) {
+	// Arrange
+	language := withOptional(newSelectField("language", "A", "B"), "False")
+	country := withOptional(newSelectField("country", "RO", "NL"), "False")
+	gender := withOptional(newSelectField("gender", "M", "F"), "True")
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{language, country, gender}}
+
+	// Act
+	result := checkCompletion(root, &models.ContentSubmission{"language": "A", "country": "  "})
+
+	// Assert
+	assert.Equal(t, []*models.ContentNode{language}, result.answered)
+	assert.Equal(t, []*models.ContentNode{country}, result.missingRequired)
+	assert.Equal(t, []*models.ContentNode{gender}, result.missingOptional)
+	assert.False(t, result.complete())
+	assert.True(t, result.isMissingRequired(country))
+}
+
+func TestCheckCompletion_UntouchedOptionalSection(t *testing.T) {
+	// Arrange
+	street := withOptional(newSelectField("street", "A"), "False")
+	city := withOptional(newSelectField("city", "B"), "False")
+	section := &models.ContentNode{
+		ElementType: models.SectionElementType,
+		Metadata:    map[string]string{"Optional": "True"},
+		Children:    []*models.ContentNode{street, city},
+	}
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{section}}
+
+	// Act
+	untouched := checkCompletion(root, &models.ContentSubmission{})
+	started := checkCompletion(root, &models.ContentSubmission{"street": "A"})
+
+	// Assert
+	assert.True(t, untouched.complete())
+	assert.Len(t, untouched.missingOptional, 2)
+	assert.Equal(t, []*models.ContentNode{city}, started.missingRequired)
+}
+
+func TestCheckCompletion_NoSubmission(t *testing.T) {
+	// Arrange
+	field := withOptional(newSelectField("language", "A"), "False")
+	unknown := &models.ContentNode{ElementType: models.FieldElementType, Name: "unknown", Metadata: map[string]string{"Optional": "False"}}
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{field, unknown}}
+
+	// Act
+	result := checkCompletion(root, nil)
+
+	// Assert - fields that are not rendered are not counted
+	assert.Equal(t, []*models.ContentNode{field}, result.missingRequired)
+}
@@ -0,0 +1,105 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var diffFileSuffix = "_diff"
+var diffTitleTextValue = "Changes"
+var generalSectionTextValue = "General"
+
+// DiffRenderer - generic interface that the renderers of the submission differences implement
+type DiffRenderer interface {
+	// RenderDiff - Renders the changes of the form fields between two submissions
+	RenderDiff(form *models.ContentNode, changes []diff.FieldChange) error
+}
+
+// GetDiffRenderer - Factory method that creates the differences renderer based on file type
+func GetDiffRenderer(fileType models.FileType, fileName string, dir string, options config.DiffRenderOptions) (DiffRenderer, error) {
+	switch fileType {
+	case models.PDFFileType:
+		return NewPDFDiffRenderer(fileName, dir, options), nil
+	case models.HTMLFileType:
+		return NewHTMLDiffRenderer(fileName, dir, options), nil
+
+	default:
+		return nil, fmt.Errorf("unimplemented diff renderer type: %s", fileType)
+	}
+}
+
+// diffCounts - the number of fields for every type of change
+type diffCounts map[models.ChangeType]int
+
+func countChanges(changes []diff.FieldChange) diffCounts {
+	counts := make(diffCounts)
+	for _, change := range changes {
+		counts[change.Change]++
+	}
+	return counts
+}
+
+// String - E.g. 2 changed, 1 added, 0 removed, 5 unchanged
+func (c diffCounts) String() string {
+	return fmt.Sprintf("%d changed, %d added, %d removed, %d unchanged",
+		c[models.ChangedChangeType], c[models.AddedChangeType], c[models.RemovedChangeType], c[models.UnchangedChangeType])
+}
+
+// prepareChanges - the changes as they are rendered: redacted, and without the unchanged fields if requested
+func prepareChanges(changes []diff.FieldChange, options config.DiffRenderOptions) []diff.FieldChange {
+	changes = redactChanges(changes, options.Redaction)
+	if options.ChangedOnly {
+		changes = diff.ChangedOnly(changes)
+	}
+	return changes
+}
+
+// diffTitle - the title of the differences document. E.g. Changes - Job application
+func diffTitle(form *models.ContentNode, fileName string) string {
+	title := form.Metadata["Title"]
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+	return fmt.Sprintf("%s - %s", diffTitleTextValue, title)
+}
+
+// sectionLabel - the section titles of a field joined, or General for the fields outside of sections
+func sectionLabel(sections []string) string {
+	if len(sections) == 0 {
+		return generalSectionTextValue
+	}
+	return strings.Join(sections, " / ")
+}
+
+// displayValue - the answer as shown to the reader. The selected option is shown with its label. E.g. Female (F)
+func displayValue(field *models.ContentNode, value string) string {
+	if value == "" || readFieldType(field) != models.SelectFieldType {
+		return value
+	}
+	for _, option := range field.Options() {
+		if option.Name == value {
+			return fmt.Sprintf("%s (%s)", option.Text, option.Name)
+		}
+	}
+	return value
+}
+
+// outputFilePath - the output file next to the input file, or in dir when set. E.g. dir/form_diff.html
+func outputFilePath(fileName, dir, suffix string, fileType models.FileType) string {
+	baseName := strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName)) + suffix + "." + string(fileType)
+	if dir != "" {
+		return filepath.Join(dir, baseName)
+	}
+	return filepath.Join(filepath.Dir(fileName), baseName)
+}
+
+// createOutputDir - creates the folder of the output file, --out can be a folder that does not exist yet
+func createOutputDir(outputPath string) error {
+	return os.MkdirAll(filepath.Dir(outputPath), 0755)
+}
@@ -0,0 +1,200 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDiffForm() *models.ContentNode {
+	gender := newSelectField("gender", "M", "F")
+	gender.Children[1].Children[0].Value = "Male"
+	gender.Children[1].Children[1].Value = "Female"
+
+	return &models.ContentNode{ElementType: models.FormElementType, Metadata: map[string]string{"Title": "Job application"}, Children: []*models.ContentNode{
+		newTextField("user_name", "Text([0,100])"),
+		newSection("Personal", gender, withSensitive(newTextField("passport", "Text([0,20])"))),
+	}}
+}
+
+func newDiffChanges() []diff.FieldChange {
+	old := &models.ContentSubmission{"user_name": "Jane", "gender": "F", "passport": "NL1234567890"}
+	new := &models.ContentSubmission{"user_name": "Jane", "gender": "M", "passport": "NL0987654321"}
+	return diff.CompareSubmissions(newDiffForm(), old, new)
+}
+
+func TestGetDiffRenderer(t *testing.T) {
+	pdf, err := GetDiffRenderer(models.PDFFileType, "form.xml", "output", config.DiffRenderOptions{})
+	require.NoError(t, err)
+	assert.IsType(t, &PDFDiffRenderer{}, pdf)
+
+	html, err := GetDiffRenderer(models.HTMLFileType, "form.xml", "output", config.DiffRenderOptions{})
+	require.NoError(t, err)
+	assert.IsType(t, &HTMLDiffRenderer{}, html)
+
+	_, err = GetDiffRenderer(models.XMLFileType, "form.xml", "output", config.DiffRenderOptions{})
+	assert.ErrorContains(t, err, "unimplemented diff renderer type")
+}
+
+func TestDisplayValue(t *testing.T) {
+	gender := newDiffForm().Children[1].Children[1]
+
+	assert.Equal(t, "Female (F)", displayValue(gender, "F"))
+	assert.Equal(t, "X", displayValue(gender, "X"))
+	assert.Equal(t, "", displayValue(gender, ""))
+	assert.Equal(t, "F", displayValue(newTextField("user_name", "Text"), "F"))
+}
+
+func TestPrepareChanges(t *testing.T) {
+	external, err := config.ReadRedactionProfile("external")
+	require.NoError(t, err)
+	public, err := config.ReadRedactionProfile("public")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		options  config.DiffRenderOptions
+		expected []string
+	}{
+		{"all", config.DiffRenderOptions{}, []string{"user_name", "gender", "passport"}},
+		{"changed only", config.DiffRenderOptions{ChangedOnly: true}, []string{"gender", "passport"}},
+		{"hidden", config.DiffRenderOptions{ChangedOnly: true, Redaction: public}, []string{"gender"}},
+		{"masked", config.DiffRenderOptions{ChangedOnly: true, Redaction: external}, []string{"gender", "passport"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := prepareChanges(newDiffChanges(), tt.options)
+
+			var names []string
+			for _, change := range changes {
+				names = append(names, change.Field.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	// The masked answers still show the change
+	masked := prepareChanges(newDiffChanges(), config.DiffRenderOptions{Redaction: external})
+	assert.Equal(t, "****7890", masked[2].Old)
+	assert.Equal(t, "****4321", masked[2].New)
+	assert.Equal(t, models.ChangedChangeType, masked[2].Change)
+}
+
+func TestCountChanges(t *testing.T) {
+	assert.Equal(t, "2 changed, 0 added, 0 removed, 1 unchanged", countChanges(newDiffChanges()).String())
+}
+
+func TestOutputFilePath(t *testing.T) {
+	assert.Equal(t, filepath.Join("output", "form_diff.html"), outputFilePath("forms/form.xml", "output", diffFileSuffix, models.HTMLFileType))
+	assert.Equal(t, filepath.Join("forms", "form.pdf"), outputFilePath("forms/form.xml", "", "", models.PDFFileType))
+}
+
+func TestHTMLDiffRenderer_RenderDiff(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	renderer := NewHTMLDiffRenderer("form.xml", dir, config.DiffRenderOptions{})
+
+	// Act
+	err := renderer.RenderDiff(newDiffForm(), newDiffChanges())
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "form_diff.html"))
+	require.NoError(t, err)
+	html := string(content)
+	assert.Contains(t, html, "<title>Changes - Job application</title>")
+	assert.Contains(t, html, `<tr class="section"><th colspan="3">General</th></tr>`)
+	assert.Contains(t, html, `<tr class="section"><th colspan="3">Personal</th></tr>`)
+	assert.Contains(t, html, `<tr class="changed"><td>Pick one</td><td><del>Female (F)</del></td><td><ins>Male (M)</ins></td></tr>`)
+	assert.Contains(t, html, `<tr class="unchanged"><td>Street Address</td><td>Jane</td><td>Jane</td></tr>`)
+}
+
+func TestHTMLDiffRenderer_CountsRenderedChanges(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	renderer := NewHTMLDiffRenderer("form.xml", dir, config.DiffRenderOptions{ChangedOnly: true})
+
+	// Act
+	err := renderer.RenderDiff(newDiffForm(), newDiffChanges())
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "form_diff.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "<p>2 changed, 0 added, 0 removed, 0 unchanged</p>")
+}
+
+func TestHTMLDiffRenderer_EscapesAnswers(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	renderer := NewHTMLDiffRenderer("form.xml", dir, config.DiffRenderOptions{})
+	form := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{newTextField("user_name", "Text")}}
+	changes := diff.CompareSubmissions(form, &models.ContentSubmission{}, &models.ContentSubmission{"user_name": "<script>alert(1)</script>"})
+
+	// Act
+	err := renderer.RenderDiff(form, changes)
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "form_diff.html"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "<script>")
+}
+
+func TestPDFDiffRenderer_RenderDiff(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	renderer := NewPDFDiffRenderer("form.xml", dir, config.DiffRenderOptions{})
+
+	// Act
+	err := renderer.RenderDiff(newDiffForm(), newDiffChanges())
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "form_diff.pdf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "%PDF")
+	assert.Equal(t, 1, renderer.pdf.PageCount())
+}
+
+func TestDiffRenderer_CreatesOutputFolder(t *testing.T) {
+	for _, fileType := range []models.FileType{models.PDFFileType, models.HTMLFileType} {
+		t.Run(string(fileType), func(t *testing.T) {
+			// Arrange - the output folder does not exist yet
+			dir := filepath.Join(t.TempDir(), "reports", "diff")
+			renderer, err := GetDiffRenderer(fileType, "form.xml", dir, config.DiffRenderOptions{})
+			require.NoError(t, err)
+
+			// Act
+			err = renderer.RenderDiff(newDiffForm(), newDiffChanges())
+
+			// Assert
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(dir, "form_diff."+string(fileType)))
+		})
+	}
+}
+
+func TestPDFDiffRenderer_RepeatsHeaderOnNewPage(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	renderer := NewPDFDiffRenderer("form.xml", dir, config.DiffRenderOptions{})
+	form := &models.ContentNode{ElementType: models.FormElementType}
+	for i := 0; i < 60; i++ {
+		form.Children = append(form.Children, newTextField("field", "Text"))
+	}
+
+	// Act
+	err := renderer.RenderDiff(form, diff.CompareSubmissions(form, nil, nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Greater(t, renderer.pdf.PageCount(), 1)
+}
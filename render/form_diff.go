@@ -0,0 +1,212 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+var redlineFileSuffix = "_redline"
+var noFormChangesTextValue = "No changes"
+
+// FormDiffRenderer - generic interface that the renderers of the differences between two versions of a form implement
+type FormDiffRenderer interface {
+	// RenderFormDiff - Renders the structural changes from the old to the new version of the form
+	RenderFormDiff(old, new *models.ContentNode, changes []diff.FormChange) error
+}
+
+// GetFormDiffRenderer - Factory method that creates the form differences renderer based on file type.
+// Text and JSON are written to the writer, the redline PDF to a file in dir or next to the new form.
+func GetFormDiffRenderer(fileType models.FileType, oldFileName, newFileName, dir string, writer io.Writer) (FormDiffRenderer, error) {
+	switch fileType {
+	case models.TextFileType:
+		return &TextFormDiffRenderer{OldFileName: oldFileName, NewFileName: newFileName, Writer: writer}, nil
+	case models.JSonFileType:
+		return &JSONFormDiffRenderer{OldFileName: oldFileName, NewFileName: newFileName, Writer: writer}, nil
+	case models.PDFFileType:
+		return &PDFFormDiffRenderer{OldFileName: oldFileName, NewFileName: newFileName, Dir: dir}, nil
+
+	default:
+		return nil, fmt.Errorf("unimplemented form diff renderer type: %s", fileType)
+	}
+}
+
+// formChangeSummary - the old and the new value of a change. E.g. TextBox Text([0,100]) -> TextBox Text([0,200])
+func formChangeSummary(change diff.FormChange) string {
+	var summary string
+	switch change.Change {
+	case models.AddedChangeType:
+		summary = "in " + change.New
+	case models.RemovedChangeType:
+		summary = "from " + change.Old
+	default:
+		summary = fmt.Sprintf("%q -> %q", change.Old, change.New)
+	}
+
+	if change.Details != "" {
+		summary += fmt.Sprintf(" (%s)", change.Details)
+	}
+	return summary
+}
+
+// formDiffTitle - E.g. Changes - form_v1.xml -> form_v2.xml
+func formDiffTitle(oldFileName, newFileName string) string {
+	return fmt.Sprintf("%s - %s -> %s", diffTitleTextValue, filepath.Base(oldFileName), filepath.Base(newFileName))
+}
+
+// countFormChanges - E.g. 3 changes: 1 added, 1 removed, 1 moved
+func countFormChanges(changes []diff.FormChange) string {
+	if len(changes) == 0 {
+		return noFormChangesTextValue
+	}
+
+	counts := make(map[models.ChangeType]int)
+	var order []string
+	for _, change := range changes {
+		if counts[change.Change] == 0 {
+			order = append(order, string(change.Change))
+		}
+		counts[change.Change]++
+	}
+
+	parts := make([]string, len(order))
+	for i, change := range order {
+		parts[i] = fmt.Sprintf("%d %s", counts[models.ChangeType(change)], change)
+	}
+	return fmt.Sprintf("%d changes: %s", len(changes), strings.Join(parts, ", "))
+}
+
+// TextFormDiffRenderer - a changelog with one line for every change
+type TextFormDiffRenderer struct {
+	OldFileName string
+	NewFileName string
+	Writer      io.Writer
+}
+
+func (r *TextFormDiffRenderer) RenderFormDiff(_, _ *models.ContentNode, changes []diff.FormChange) error {
+	writer := tabwriter.NewWriter(r.Writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, formDiffTitle(r.OldFileName, r.NewFileName))
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", change.Change, change.Element, change.Name, formChangeSummary(change))
+	}
+	_, _ = fmt.Fprintln(writer, countFormChanges(changes))
+
+	return writer.Flush()
+}
+
+// JSONFormDiffRenderer - the changes as a JSON document, for the tools that build the changelogs
+type JSONFormDiffRenderer struct {
+	OldFileName string
+	NewFileName string
+	Writer      io.Writer
+}
+
+type formDiffDocument struct {
+	Old     string            `json:"old"`
+	New     string            `json:"new"`
+	Changes []diff.FormChange `json:"changes"`
+}
+
+func (r *JSONFormDiffRenderer) RenderFormDiff(_, _ *models.ContentNode, changes []diff.FormChange) error {
+	if changes == nil {
+		changes = []diff.FormChange{}
+	}
+
+	encoder := json.NewEncoder(r.Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(formDiffDocument{Old: r.OldFileName, New: r.NewFileName, Changes: changes})
+}
+
+/* The redline PDF lists every change like a marked-up document: the name and the kind of the change,
+   then the old value in red and struck out and the new value in green.
+   Added elements only have the new value and removed elements only the old one.
+*/
+
+// PDFFormDiffRenderer - the changes as a redline PDF
+type PDFFormDiffRenderer struct {
+	OldFileName string
+	NewFileName string
+	Dir         string
+
+	pdf *gofpdf.Fpdf
+}
+
+func (r *PDFFormDiffRenderer) RenderFormDiff(_, _ *models.ContentNode, changes []diff.FormChange) error {
+	r.pdf = gofpdf.New(orientation, unit, size, "")
+	r.pdf.SetTitle(formDiffTitle(r.OldFileName, r.NewFileName), true)
+	r.pdf.SetCreator(creatorTextValue, true)
+	r.pdf.AddPage()
+
+	r.pdf.SetFont(font, "B", titleFontSize)
+	r.pdf.MultiCell(0, titleCellHeight, formDiffTitle(r.OldFileName, r.NewFileName), "", "L", false)
+	r.pdf.SetFont(font, "", diffFontSize)
+	r.pdf.CellFormat(0, answerLineHeight, countFormChanges(changes), "", 1, "", false, 0, "")
+	r.pdf.Ln(answerSpacing)
+
+	for _, change := range changes {
+		r.renderChange(change)
+	}
+
+	err := r.pdf.OutputFileAndClose(outputFilePath(r.NewFileName, r.Dir, redlineFileSuffix, models.PDFFileType))
+	if err != nil {
+		logging.Log.Errorf("Error writing file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// renderChange - E.g. field email (retyped): TextBox Text([0,100]) TextBox Text([0,200])
+func (r *PDFFormDiffRenderer) renderChange(change diff.FormChange) {
+	r.pdf.SetFont(font, "B", diffFontSize)
+	r.pdf.SetTextColor(0, 0, 0)
+	r.pdf.Write(diffLineHeight, fmt.Sprintf("%s %s (%s): ", change.Element, change.Name, change.Change))
+
+	switch change.Change {
+	case models.AddedChangeType:
+		r.writeNew("in " + change.New)
+	case models.RemovedChangeType:
+		r.writeOld("from " + change.Old)
+	default:
+		r.writeOld(change.Old)
+		r.pdf.Write(diffLineHeight, " ")
+		r.writeNew(change.New)
+	}
+
+	if change.Details != "" {
+		r.pdf.SetFont(font, "I", diffFontSize)
+		r.pdf.SetTextColor(90, 90, 90)
+		r.pdf.Write(diffLineHeight, fmt.Sprintf(" (%s)", change.Details))
+	}
+
+	r.pdf.SetTextColor(0, 0, 0)
+	r.pdf.Ln(diffLineHeight + diffCellPadding)
+}
+
+// writeOld - red and struck out
+func (r *PDFFormDiffRenderer) writeOld(text string) {
+	if text == "" {
+		return
+	}
+	r.pdf.SetFont(font, "S", diffFontSize)
+	r.pdf.SetTextColor(200, 0, 0)
+	r.pdf.Write(diffLineHeight, text)
+}
+
+// writeNew - green and underlined
+func (r *PDFFormDiffRenderer) writeNew(text string) {
+	if text == "" {
+		return
+	}
+	r.pdf.SetFont(font, "U", diffFontSize)
+	r.pdf.SetTextColor(0, 140, 0)
+	r.pdf.Write(diffLineHeight, text)
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFormChanges() []diff.FormChange {
+	return []diff.FormChange{
+		{Change: models.AddedChangeType, Element: models.FieldElementType, Name: "phone", New: "(form)"},
+		{Change: models.RetypedChangeType, Element: models.FieldElementType, Name: "email", Old: "TextBox Text([0,100])", New: "TextBox Text([0,200])"},
+		{Change: models.OptionsChangeType, Element: models.FieldElementType, Name: "gender", Old: "M, F", New: "M, F, X", Details: "+X"},
+		{Change: models.RemovedChangeType, Element: models.SectionElementType, Name: "experience", Old: "(form)"},
+	}
+}
+
+func TestGetFormDiffRenderer(t *testing.T) {
+	text, err := GetFormDiffRenderer(models.TextFileType, "v1.xml", "v2.xml", "", &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.IsType(t, &TextFormDiffRenderer{}, text)
+
+	jsonRenderer, err := GetFormDiffRenderer(models.JSonFileType, "v1.xml", "v2.xml", "", &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.IsType(t, &JSONFormDiffRenderer{}, jsonRenderer)
+
+	pdf, err := GetFormDiffRenderer(models.PDFFileType, "v1.xml", "v2.xml", "output", nil)
+	require.NoError(t, err)
+	assert.IsType(t, &PDFFormDiffRenderer{}, pdf)
+
+	_, err = GetFormDiffRenderer(models.HTMLFileType, "v1.xml", "v2.xml", "", nil)
+	assert.ErrorContains(t, err, "unimplemented form diff renderer type")
+}
+
+func TestTextFormDiffRenderer_RenderFormDiff(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	renderer := &TextFormDiffRenderer{OldFileName: "forms/v1.xml", NewFileName: "forms/v2.xml", Writer: &output}
+
+	// Act
+	err := renderer.RenderFormDiff(nil, nil, newFormChanges())
+
+	// Assert
+	require.NoError(t, err)
+	expected := "Changes - v1.xml -> v2.xml\n" +
+		"added    field    phone       in (form)\n" +
+		"retyped  field    email       \"TextBox Text([0,100])\" -> \"TextBox Text([0,200])\"\n" +
+		"options  field    gender      \"M, F\" -> \"M, F, X\" (+X)\n" +
+		"removed  section  experience  from (form)\n" +
+		"4 changes: 1 added, 1 retyped, 1 options, 1 removed\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestTextFormDiffRenderer_NoChanges(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	renderer := &TextFormDiffRenderer{OldFileName: "v1.xml", NewFileName: "v2.xml", Writer: &output}
+
+	// Act
+	err := renderer.RenderFormDiff(nil, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Changes - v1.xml -> v2.xml\nNo changes\n", output.String())
+}
+
+func TestJSONFormDiffRenderer_RenderFormDiff(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	renderer := &JSONFormDiffRenderer{OldFileName: "v1.xml", NewFileName: "v2.xml", Writer: &output}
+
+	// Act
+	err := renderer.RenderFormDiff(nil, nil, newFormChanges())
+
+	// Assert
+	require.NoError(t, err)
+	var document formDiffDocument
+	require.NoError(t, json.Unmarshal(output.Bytes(), &document))
+	assert.Equal(t, "v1.xml", document.Old)
+	assert.Equal(t, newFormChanges(), document.Changes)
+	assert.Contains(t, output.String(), `"change": "options"`)
+}
+
+func TestJSONFormDiffRenderer_NoChanges(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	renderer := &JSONFormDiffRenderer{OldFileName: "v1.xml", NewFileName: "v2.xml", Writer: &output}
+
+	// Act
+	err := renderer.RenderFormDiff(nil, nil, nil)
+
+	// Assert - an empty list, not null
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), `"changes": []`)
+}
+
+func TestPDFFormDiffRenderer_RenderFormDiff(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	renderer := &PDFFormDiffRenderer{OldFileName: "forms/v1.xml", NewFileName: "forms/v2.xml", Dir: dir}
+
+	// Act
+	err := renderer.RenderFormDiff(nil, nil, newFormChanges())
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "v2_redline.pdf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "%PDF")
+	assert.Equal(t, 1, renderer.pdf.PageCount())
+}
@@ -0,0 +1,123 @@
+package render
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+type HTMLDiffRenderer struct {
+	Filename string
+	Dir      string
+	Options  config.DiffRenderOptions
+}
+
+func NewHTMLDiffRenderer(fileName, dir string, options config.DiffRenderOptions) *HTMLDiffRenderer {
+	return &HTMLDiffRenderer{
+		Filename: fileName,
+		Dir:      dir,
+		Options:  options,
+	}
+}
+
+// htmlDiffRow - a row of the differences table. Rows with a Section only start a new section.
+type htmlDiffRow struct {
+	Section string
+	Caption string
+	Old     string
+	New     string
+	Change  models.ChangeType
+	// Marked rows show the old answer struck through and the new one inserted
+	Marked bool
+}
+
+// The page is self-contained (no scripts, inline styles) so it can be attached to a ticket or sent by email
+var htmlDiffTemplate = template.Must(template.New("diff").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Arial, Helvetica, sans-serif; font-size: 14px; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #999; padding: 6px; text-align: left; vertical-align: top; white-space: pre-wrap; }
+th { background: #e6e6e6; }
+tr.section th { background: #f5f5f5; }
+tr.added td { background: #c8f0c8; }
+tr.removed td { background: #ffc8c8; }
+tr.changed td { background: #fff0b4; }
+del { color: #a00; }
+ins { color: #060; text-decoration: none; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Counts}}</p>
+<table>
+<thead><tr><th>Field</th><th>Old</th><th>New</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+{{- if .Section}}
+<tr class="section"><th colspan="3">{{.Section}}</th></tr>
+{{- else}}
+<tr class="{{.Change}}"><td>{{.Caption}}</td>{{if .Marked}}<td><del>{{.Old}}</del></td><td><ins>{{.New}}</ins></td>{{else}}<td>{{.Old}}</td><td>{{.New}}</td>{{end}}</tr>
+{{- end}}
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+func (r *HTMLDiffRenderer) RenderDiff(form *models.ContentNode, changes []diff.FieldChange) error {
+	// The counts match the rows - redacted and without the unchanged fields if requested
+	changes = prepareChanges(changes, r.Options)
+
+	var rows []htmlDiffRow
+	section := ""
+	for _, change := range changes {
+		if current := sectionLabel(change.Sections); current != section {
+			section = current
+			rows = append(rows, htmlDiffRow{Section: section})
+		}
+
+		rows = append(rows, htmlDiffRow{
+			Caption: findCaption(change.Field),
+			Old:     displayValue(change.Field, change.Old),
+			New:     displayValue(change.Field, change.New),
+			Change:  change.Change,
+			Marked:  change.Change != models.UnchangedChangeType,
+		})
+	}
+
+	var buffer bytes.Buffer
+	err := htmlDiffTemplate.Execute(&buffer, map[string]any{
+		"Title":  diffTitle(form, r.Filename),
+		"Counts": countChanges(changes).String(),
+		"Rows":   rows,
+	})
+	if err != nil {
+		logging.Log.Errorf("Error rendering the differences: %v", err)
+		return err
+	}
+
+	outputPath := outputFilePath(r.Filename, r.Dir, diffFileSuffix, models.HTMLFileType)
+	err = createOutputDir(outputPath)
+	if err != nil {
+		logging.Log.Errorf("Error creating the output folder: %v", err)
+		return err
+	}
+
+	err = os.WriteFile(outputPath, buffer.Bytes(), 0644)
+	if err != nil {
+		logging.Log.Errorf("Error writing file: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/alex-pricope/form-parser/lint"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var sarifVersion = "2.1.0"
+var sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+var lintToolName = "form-parser lint"
+
+// LintRenderer - generic interface that the renderers of the lint problems implement
+type LintRenderer interface {
+	// RenderLint - Renders the problems found in the form file
+	RenderLint(report *lint.Report) error
+}
+
+// GetLintRenderer - Factory method that creates the lint renderer based on file type. The output goes to the writer.
+func GetLintRenderer(fileType models.FileType, fileName string, writer io.Writer) (LintRenderer, error) {
+	switch fileType {
+	case models.TextFileType:
+		return &TextLintRenderer{Filename: fileName, Writer: writer}, nil
+	case models.JSonFileType:
+		return &JSONLintRenderer{Filename: fileName, Writer: writer}, nil
+	case models.SARIFFileType:
+		return &SARIFLintRenderer{Filename: fileName, Writer: writer}, nil
+
+	default:
+		return nil, fmt.Errorf("unimplemented lint renderer type: %s", fileType)
+	}
+}
+
+// TextLintRenderer - one line for every problem, like a compiler. E.g. form.xml:3:5: error FP001 select-without-labels: ...
+type TextLintRenderer struct {
+	Filename string
+	Writer   io.Writer
+}
+
+func (r *TextLintRenderer) RenderLint(report *lint.Report) error {
+	for _, problem := range report.Problems {
+		_, err := fmt.Fprintf(r.Writer, "%s:%d:%d: %s %s %s: %s\n",
+			r.Filename, problem.Line, problem.Column, problem.Severity, problem.Rule.ID, problem.Rule.Name, problem.Message)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(r.Writer, report.String())
+	return err
+}
+
+// JSONLintRenderer - the problems as a JSON document
+type JSONLintRenderer struct {
+	Filename string
+	Writer   io.Writer
+}
+
+type lintDocument struct {
+	File     string        `json:"file"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Infos    int           `json:"infos"`
+	Problems []lintProblem `json:"problems"`
+}
+
+type lintProblem struct {
+	Rule     string          `json:"rule"`
+	Name     string          `json:"name"`
+	Severity models.Severity `json:"severity"`
+	Message  string          `json:"message"`
+	Line     int             `json:"line"`
+	Column   int             `json:"column"`
+}
+
+func (r *JSONLintRenderer) RenderLint(report *lint.Report) error {
+	document := lintDocument{
+		File:     r.Filename,
+		Errors:   report.Count(models.ErrorSeverity),
+		Warnings: report.Count(models.WarningSeverity),
+		Infos:    report.Count(models.InfoSeverity),
+		Problems: []lintProblem{},
+	}
+	for _, problem := range report.Problems {
+		document.Problems = append(document.Problems, lintProblem{
+			Rule:     problem.Rule.ID,
+			Name:     problem.Rule.Name,
+			Severity: problem.Severity,
+			Message:  problem.Message,
+			Line:     problem.Line,
+			Column:   problem.Column,
+		})
+	}
+
+	encoder := json.NewEncoder(r.Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+/* SARIF (Static Analysis Results Interchange Format) is read by the code scanning tools, e.g. the CI annotations.
+   The log has one run with the rule catalogue in the tool driver and a result for every problem.
+   The info severity is a note in SARIF.
+*/
+
+// SARIFLintRenderer - the problems as a SARIF 2.1.0 log
+type SARIFLintRenderer struct {
+	Filename string
+	Writer   io.Writer
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+func (r *SARIFLintRenderer) RenderLint(report *lint.Report) error {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: lintToolName}}, Results: []sarifResult{}}
+
+	ruleIndexes := make(map[string]int)
+	for i, rule := range lint.Rules {
+		ruleIndexes[rule.ID] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.Name,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		})
+	}
+
+	for _, problem := range report.Problems {
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    problem.Rule.ID,
+			RuleIndex: ruleIndexes[problem.Rule.ID],
+			Level:     sarifLevel(problem.Severity),
+			Message:   sarifMessage{Text: problem.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(r.Filename)},
+				Region:           sarifRegion{StartLine: problem.Line, StartColumn: problem.Column},
+			}}},
+		})
+	}
+
+	encoder := json.NewEncoder(r.Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+// sarifLevel - error, warning or note
+func sarifLevel(severity models.Severity) string {
+	if severity == models.InfoSeverity {
+		return "note"
+	}
+	return string(severity)
+}
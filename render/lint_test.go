@@ -0,0 +1,95 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/lint"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLintReport(t *testing.T) *lint.Report {
+	report, err := lint.Lint([]byte("<Form>\n  <Field Name=\"a\" FieldType=\"Select\"/>\n  <Section Name=\"s\"/>\n</Form>"), nil)
+	require.NoError(t, err)
+	return report
+}
+
+func TestGetLintRenderer(t *testing.T) {
+	text, err := GetLintRenderer(models.TextFileType, "form.xml", &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.IsType(t, &TextLintRenderer{}, text)
+
+	jsonRenderer, err := GetLintRenderer(models.JSonFileType, "form.xml", &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.IsType(t, &JSONLintRenderer{}, jsonRenderer)
+
+	sarif, err := GetLintRenderer(models.SARIFFileType, "form.xml", &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.IsType(t, &SARIFLintRenderer{}, sarif)
+
+	_, err = GetLintRenderer(models.PDFFileType, "form.xml", &bytes.Buffer{})
+	assert.ErrorContains(t, err, "unimplemented lint renderer type")
+}
+
+func TestTextLintRenderer_RenderLint(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	renderer := &TextLintRenderer{Filename: "form.xml", Writer: &output}
+
+	// Act
+	err := renderer.RenderLint(newLintReport(t))
+
+	// Assert
+	require.NoError(t, err)
+	expected := "form.xml:2:3: warning FP002 field-without-caption: field a has no Caption\n" +
+		"form.xml:2:3: error FP001 select-without-labels: select field a has no Labels\n" +
+		"form.xml:3:3: info FP008 section-without-title: section s has no Title\n" +
+		"1 error, 1 warning, 1 info\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestJSONLintRenderer_RenderLint(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	renderer := &JSONLintRenderer{Filename: "form.xml", Writer: &output}
+
+	// Act
+	err := renderer.RenderLint(newLintReport(t))
+
+	// Assert
+	require.NoError(t, err)
+	var document lintDocument
+	require.NoError(t, json.Unmarshal(output.Bytes(), &document))
+	assert.Equal(t, 1, document.Errors)
+	require.Len(t, document.Problems, 3)
+	assert.Equal(t, lintProblem{Rule: "FP001", Name: "select-without-labels", Severity: models.ErrorSeverity, Message: "select field a has no Labels", Line: 2, Column: 3}, document.Problems[1])
+}
+
+func TestSARIFLintRenderer_RenderLint(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	renderer := &SARIFLintRenderer{Filename: "forms/form.xml", Writer: &output}
+
+	// Act
+	err := renderer.RenderLint(newLintReport(t))
+
+	// Assert
+	require.NoError(t, err)
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(output.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(lint.Rules))
+
+	results := log.Runs[0].Results
+	require.Len(t, results, 3)
+	assert.Equal(t, "FP001", results[1].RuleID)
+	assert.Equal(t, 0, results[1].RuleIndex)
+	assert.Equal(t, "error", results[1].Level)
+	assert.Equal(t, "note", results[2].Level)
+	assert.Equal(t, "forms/form.xml", results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, sarifRegion{StartLine: 2, StartColumn: 3}, results[1].Locations[0].PhysicalLocation.Region)
+}
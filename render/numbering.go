@@ -0,0 +1,75 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var fieldNumberPrefix = "Q"
+
+// formatSectionNumber - formats the position of a section (1-based index per nesting level) using the scheme
+// E.g. [1 2] => 1.2. (decimal) or A.ii. (alpha-roman)
+func formatSectionNumber(scheme models.NumberingScheme, path []int) string {
+	if scheme == models.NoNumberingScheme || len(path) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for level, index := range path {
+		if scheme == models.AlphaRomanNumberingScheme {
+			// Cycle through the styles when the nesting goes deeper than the scheme - A.i.a.1.
+			switch level % 4 {
+			case 0:
+				sb.WriteString(toAlpha(index))
+			case 1:
+				sb.WriteString(strings.ToLower(toRoman(index)))
+			case 2:
+				sb.WriteString(strings.ToLower(toAlpha(index)))
+			default:
+				sb.WriteString(strconv.Itoa(index))
+			}
+		} else {
+			// Decimal is the default for unknown schemes too
+			sb.WriteString(strconv.Itoa(index))
+		}
+		sb.WriteString(".")
+	}
+
+	return sb.String()
+}
+
+// formatFieldNumber - formats the number of a field inside its section. E.g. Q3.2 for the second field in section 3.
+func formatFieldNumber(sectionNumber string, index int) string {
+	if sectionNumber == "" {
+		return fieldNumberPrefix + strconv.Itoa(index)
+	}
+	return fieldNumberPrefix + sectionNumber + strconv.Itoa(index)
+}
+
+// toAlpha - converts a 1-based index to letters. E.g. 1 => A, 26 => Z, 27 => AA
+func toAlpha(index int) string {
+	var result []byte
+	for index > 0 {
+		index--
+		result = append([]byte{byte('A' + index%26)}, result...)
+		index /= 26
+	}
+	return string(result)
+}
+
+// toRoman - converts a 1-based index to roman numerals. E.g. 4 => IV
+func toRoman(index int) string {
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+
+	var sb strings.Builder
+	for i, value := range values {
+		for index >= value {
+			sb.WriteString(symbols[i])
+			index -= value
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSectionNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		scheme   models.NumberingScheme
+		path     []int
+		expected string
+	}{
+		{"DecimalTopLevel", models.DecimalNumberingScheme, []int{3}, "3."},
+		{"DecimalNested", models.DecimalNumberingScheme, []int{1, 2}, "1.2."},
+		{"AlphaRomanTopLevel", models.AlphaRomanNumberingScheme, []int{1}, "A."},
+		{"AlphaRomanNested", models.AlphaRomanNumberingScheme, []int{1, 4}, "A.iv."},
+		{"AlphaRomanDeep", models.AlphaRomanNumberingScheme, []int{2, 1, 3, 5}, "B.i.c.5."},
+		{"None", models.NoNumberingScheme, []int{1, 2}, ""},
+		{"UnknownUsesDecimal", "", []int{2, 1}, "2.1."},
+		{"EmptyPath", models.DecimalNumberingScheme, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatSectionNumber(tt.scheme, tt.path))
+		})
+	}
+}
+
+func TestFormatFieldNumber(t *testing.T) {
+	assert.Equal(t, "Q2", formatFieldNumber("", 2))
+	assert.Equal(t, "Q3.2", formatFieldNumber("3.", 2))
+	assert.Equal(t, "QA.i.1", formatFieldNumber("A.i.", 1))
+}
+
+func TestToAlpha(t *testing.T) {
+	assert.Equal(t, "A", toAlpha(1))
+	assert.Equal(t, "Z", toAlpha(26))
+	assert.Equal(t, "AA", toAlpha(27))
+}
+
+func TestToRoman(t *testing.T) {
+	assert.Equal(t, "I", toRoman(1))
+	assert.Equal(t, "IV", toRoman(4))
+	assert.Equal(t, "XIV", toRoman(14))
+	assert.Equal(t, "MCMXC", toRoman(1990))
+}
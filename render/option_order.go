@@ -0,0 +1,41 @@
+package render
+
+import (
+	"sort"
+
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var orderAttribute = "Order"
+
+// resolveOptionOrder - the Order attribute of the field wins over the default from the render options
+func resolveOptionOrder(node *models.ContentNode, defaultOrder models.OptionOrder) models.OptionOrder {
+	if value, ok := node.Metadata[orderAttribute]; ok {
+		order := models.SafeReadOptionOrder(value)
+		if order != models.UnknownOptionOrder {
+			return order
+		}
+		logging.Log.Warnf("Unknown option order '%s' for field '%s', using the default", value, node.Name)
+	}
+
+	if defaultOrder == "" || defaultOrder == models.UnknownOptionOrder {
+		return models.DeclaredOptionOrder
+	}
+	return defaultOrder
+}
+
+// orderOptions - returns the options in the requested order. Declared keeps the order from the form.
+func orderOptions(options []models.Option, order models.OptionOrder) []models.Option {
+	ordered := make([]models.Option, len(options))
+	copy(ordered, options)
+
+	switch order {
+	case models.AlphaOptionOrder:
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Name < ordered[j].Name })
+	case models.ValueOptionOrder:
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Text < ordered[j].Text })
+	}
+
+	return ordered
+}
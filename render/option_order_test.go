@@ -0,0 +1,67 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderOptions(t *testing.T) {
+	options := []models.Option{
+		{Name: "C", Text: "C (All flavors except C#)"},
+		{Name: "A", Text: "Zig"},
+		{Name: "B", Text: "B"},
+	}
+
+	tests := []struct {
+		order    models.OptionOrder
+		expected []string
+	}{
+		{models.DeclaredOptionOrder, []string{"C", "A", "B"}},
+		{models.AlphaOptionOrder, []string{"A", "B", "C"}},
+		{models.ValueOptionOrder, []string{"B", "C", "A"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("OptionOrder_"+string(tt.order), func(t *testing.T) {
+			// Act
+			result := orderOptions(options, tt.order)
+
+			// Assert
+			names := make([]string, 0, len(result))
+			for _, option := range result {
+				names = append(names, option.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, "C", options[0].Name, "the input must not be reordered")
+		})
+	}
+}
+
+func TestResolveOptionOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		attribute    string
+		defaultOrder models.OptionOrder
+		expected     models.OptionOrder
+	}{
+		{"NoAttributeNoDefault", "", "", models.DeclaredOptionOrder},
+		{"NoAttributeUsesDefault", "", models.AlphaOptionOrder, models.AlphaOptionOrder},
+		{"AttributeWins", "Value", models.AlphaOptionOrder, models.ValueOptionOrder},
+		{"UnknownAttributeUsesDefault", "random", models.AlphaOptionOrder, models.AlphaOptionOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			node := &models.ContentNode{Metadata: map[string]string{}}
+			if tt.attribute != "" {
+				node.Metadata[orderAttribute] = tt.attribute
+			}
+
+			// Act Assert
+			assert.Equal(t, tt.expected, resolveOptionOrder(node, tt.defaultOrder))
+		})
+	}
+}
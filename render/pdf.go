@@ -1,18 +1,26 @@
 package render
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
 	"github.com/alex-pricope/form-parser/logging"
 	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/signature"
 	"github.com/jung-kurt/gofpdf"
-	"path/filepath"
-	"sort"
+	"os"
+	"slices"
 	"strings"
+	"time"
 )
 
 var orientation, unit, size, font = "P", "mm", "A4", "Arial"
 var defaultFontSize float64 = 12
 var titleFontSize float64 = 14
+var minFontSize float64 = 8
+var captionLineHeight, answerLineHeight, answerSpacing float64 = 10, 8, 5
+var titleLineHeight, titleCellHeight float64 = 10, 12
 var missingCaptionTextValue = "(missing caption)"
 var selectedMarkerValue = "(selected)"
 var missingAnswerTextValue = "(missing answer)"
@@ -20,24 +28,94 @@ var missingAnswerTextValue = "(missing answer)"
 type PDFRenderer struct {
 	Filename string
 	Dir      string
+	Options  config.RenderOptions
+
+	pdf         *gofpdf.Fpdf
+	sections    map[*models.ContentNode]*sectionEntry
+	attachments map[string]*attachmentFile
+	thumbnails  map[*models.ContentNode]*thumbnail
+	fieldLinks  map[*models.ContentNode]*fieldLink
+	signer      *signature.Signer
+	completion  *completion
+	metadata    *documentMetadata
+	createdAt   time.Time
+
+	// Layout of the scope being rendered - see applyScope
+	leftMargin  float64
+	fontSize    float64
+	fieldNumber string
+}
 
-	pdf *gofpdf.Fpdf
+// renderScope - the section the traversal is currently in. The form itself is the scope with depth 0.
+type renderScope struct {
+	depth  int
+	number string
+	fields *int
 }
 
-func NewPDFRenderer(fileName, dir string) *PDFRenderer {
+func NewPDFRenderer(fileName, dir string, options config.RenderOptions) *PDFRenderer {
 	return &PDFRenderer{
 		Filename: fileName,
 		Dir:      dir,
+		Options:  options,
 	}
 }
 
 func (r *PDFRenderer) Render(content *models.ContentNode, submission *models.ContentSubmission) error {
-	r.pdf = gofpdf.New(orientation, unit, size, "")
-	r.useNormalFont(defaultFontSize)
-	r.pdf.AddPage()
+	// Load the signing key first - no point rendering a file that cannot be signed
+	err := r.loadSigner()
+	if err != nil {
+		return err
+	}
+
+	err = r.Options.Metadata.Validate()
+	if err != nil {
+		return err
+	}
+
+	// gofpdf picks a random owner password when none is set
+	if r.Options.Reproducible && r.Options.Protection.Enabled() && r.Options.Protection.OwnerPassword == "" {
+		return myerrors.ErrReproducibleOwnerPassword
+	}
+
+	r.newDocument()
+	r.applyProtection()
+
+	// Check the answers upfront - the watermark and the highlights depend on the missing ones
+	if !r.Options.Blank {
+		r.completion = checkCompletion(content, submission)
+	}
+	r.applyWatermark()
+
+	// From here on, only the redacted answers are used
+	submission = redactSubmission(content, submission, r.Options.Redaction)
+
+	// Number the sections upfront - the table of contents needs them before the content is rendered
+	entries := r.collectSections(content)
+	r.sections = make(map[*models.ContentNode]*sectionEntry, len(entries))
+	for _, entry := range entries {
+		r.sections[entry.node] = entry
+	}
+
+	r.metadata = r.collectMetadata(content, submission, entries)
+	r.applyMetadata()
+
+	if r.Options.Summary {
+		r.renderSummary(submission)
+	}
+
+	if r.Options.TableOfContents {
+		r.renderTableOfContents(entries)
+	}
 
 	// Traverse the graph and render the needed elements
-	err := r.renderNode(content, submission)
+	err = r.renderNode(content, submission, &renderScope{fields: new(int)})
+	if err != nil {
+		return err
+	}
+
+	// The tracking code holds the page count, so it is drawn once all the pages exist
+	err = r.applyStamps(content, submission)
 	if err != nil {
 		return err
 	}
@@ -51,15 +129,89 @@ func (r *PDFRenderer) Render(content *models.ContentNode, submission *models.Con
 	return nil
 }
 
-// renderNode - renders a content node
-func (r *PDFRenderer) renderNode(node *models.ContentNode, submission *models.ContentSubmission) error {
+// newDocument - creates the PDF document with the first page
+func (r *PDFRenderer) newDocument() {
+	r.pdf = gofpdf.New(orientation, unit, size, "")
+
+	// The same date is used in the document information and the XMP metadata
+	r.createdAt = r.now()
+	r.pdf.SetCreationDate(r.createdAt)
+	r.pdf.SetModificationDate(r.createdAt)
+	if r.Options.Reproducible {
+		// Resources written in a stable order
+		r.pdf.SetCatalogSort(true)
+	}
+	r.attachments = make(map[string]*attachmentFile)
+	r.thumbnails = make(map[*models.ContentNode]*thumbnail)
+	r.fieldLinks = make(map[*models.ContentNode]*fieldLink)
+	r.completion = &completion{}
+	r.metadata = &documentMetadata{}
+	r.useNormalFont(defaultFontSize)
+	r.reserveStampSpace()
+	r.pdf.AddPage()
+	r.leftMargin, _, _, _ = r.pdf.GetMargins()
+	r.fontSize = defaultFontSize
+}
+
+// applyProtection - encrypts the document with the passwords and permissions, if a password is set
+func (r *PDFRenderer) applyProtection() {
+	protection := r.Options.Protection
+	if !protection.Enabled() {
+		return
+	}
+
+	var permissions byte
+	if protection.AllowPrint {
+		permissions |= gofpdf.CnProtectPrint
+	}
+	if protection.AllowCopy {
+		permissions |= gofpdf.CnProtectCopy
+	}
+	if protection.AllowModify {
+		permissions |= gofpdf.CnProtectModify | gofpdf.CnProtectAnnotForms
+	}
+
+	r.pdf.SetProtection(permissions, protection.UserPassword, protection.OwnerPassword)
+}
+
+// loadSigner - loads the certificate and key used to sign the output, if signing is enabled
+func (r *PDFRenderer) loadSigner() error {
+	signing := r.Options.Signing
+	if !signing.Enabled() {
+		return nil
+	}
+
+	// A signature cannot be verified in an encrypted file
+	if r.Options.Protection.Enabled() {
+		return myerrors.ErrSignProtectedFile
+	}
+
+	signer, err := signature.LoadSigner(signing.CertificatePath, signing.KeyPath, signing.Password)
+	if err != nil {
+		logging.Log.Errorf("Error loading the signing certificate: %v", err)
+		return err
+	}
+	r.signer = signer
+	r.signer.Deterministic = r.Options.Reproducible
+
+	return nil
+}
+
+// renderNode - renders a content node. The children of a section are rendered one level deeper.
+func (r *PDFRenderer) renderNode(node *models.ContentNode, submission *models.ContentSubmission, scope *renderScope) error {
+	childScope := scope
+
 	switch node.ElementType {
 
 	case models.SectionElementType:
-		r.renderSection(node, submission)
+		r.renderSection(node, submission, scope)
+		childScope = r.sectionScope(node, scope)
 
 	case models.FieldElementType:
-		r.renderField(node, submission)
+		r.renderField(node, submission, scope)
+
+	case models.PageBreakElementType:
+		r.breakPage()
 
 	case models.FormElementType:
 		logging.Log.Info("(skip)Form content node type")
@@ -72,7 +224,7 @@ func (r *PDFRenderer) renderNode(node *models.ContentNode, submission *models.Co
 	}
 
 	for _, child := range node.Children {
-		err := r.renderNode(child, submission)
+		err := r.renderNode(child, submission, childScope)
 		if err != nil {
 			return err
 		}
@@ -85,14 +237,31 @@ func (r *PDFRenderer) renderNode(node *models.ContentNode, submission *models.Co
 func (r *PDFRenderer) writeFile() error {
 	// If Dir is set, use the dir/filename.pdf
 	// If not, use the filename_path/filename.pdf
-	basePdfName := strings.TrimSuffix(filepath.Base(r.Filename), filepath.Ext(r.Filename)) + ".pdf"
-	var outputPath string
-	if r.Dir != "" {
-		outputPath = filepath.Join(r.Dir, basePdfName)
-	} else {
-		outputPath = filepath.Join(filepath.Dir(r.Filename), basePdfName)
+	outputPath := outputFilePath(r.Filename, r.Dir, "", models.PDFFileType)
+
+	// The custom metadata and the signature are added to the written document, so it is rendered in memory first
+	var buffer bytes.Buffer
+	err := r.pdf.Output(&buffer)
+	if err != nil {
+		logging.Log.Errorf("Error writing file: %v", err)
+		return err
 	}
-	err := r.pdf.OutputFileAndClose(outputPath)
+
+	document, err := r.writeMetadata(buffer.Bytes())
+	if err != nil {
+		logging.Log.Errorf("Error writing the metadata: %v", err)
+		return err
+	}
+
+	if r.signer != nil {
+		document, err = signature.Sign(document, r.signer, r.now())
+		if err != nil {
+			logging.Log.Errorf("Error signing file: %v", err)
+			return err
+		}
+	}
+
+	err = os.WriteFile(outputPath, document, 0644)
 	if err != nil {
 		logging.Log.Errorf("Error writing file: %v", err)
 		return err
@@ -101,8 +270,16 @@ func (r *PDFRenderer) writeFile() error {
 	return nil
 }
 
-// findCaption - search the nodes for the Caption.
-func (r *PDFRenderer) findCaption(node *models.ContentNode) string {
+// now - the current time, or the fixed source date of a reproducible output
+func (r *PDFRenderer) now() time.Time {
+	if r.Options.Reproducible {
+		return r.Options.SourceDate
+	}
+	return time.Now()
+}
+
+// findCaption - search the nodes for the Caption. Used by the renderers of the differences too.
+func findCaption(node *models.ContentNode) string {
 	for _, child := range node.Children {
 		if child.ElementType == models.CaptionElementType {
 			return child.Value
@@ -122,23 +299,40 @@ func (r *PDFRenderer) findTitle(node *models.ContentNode) string {
 }
 
 // renderField - generic method that will render the field
-func (r *PDFRenderer) renderField(node *models.ContentNode, submission *models.ContentSubmission) {
+func (r *PDFRenderer) renderField(node *models.ContentNode, submission *models.ContentSubmission, scope *renderScope) {
+	if r.isHidden(node) {
+		return
+	}
 
-	// Read the FieldType - decided not to transform this in the parser to keep it simple
-	fieldTypeStr, ok := node.Metadata["FieldType"]
-	var fieldType models.FieldType
-	if !ok {
-		fieldType = models.UnknownFieldType
-	} else {
-		fieldType = models.SafeReadFieldType(fieldTypeStr)
+	r.applyScope(scope)
+
+	fieldType := readFieldType(node)
+
+	// Only the fields that are rendered get a number
+	r.fieldNumber = ""
+	if r.Options.NumberFields && fieldType != models.UnknownFieldType {
+		*scope.fields++
+		r.fieldNumber = formatFieldNumber(scope.number, *scope.fields)
+	}
+
+	// Keep the caption and the answer on the same page
+	r.breakPageIfRequested(node)
+	r.keepTogether(r.measureField(node, submission, r.pdf.GetX()))
+	r.linkField(node)
+
+	if r.Options.Blank && fieldType != models.UnknownFieldType {
+		r.renderBlankField(node, fieldType)
+		return
 	}
 
 	switch fieldType {
 
-	// For simplicity, File will render like a normal textbox
-	case models.FileFieldType, models.TextboxFieldType:
+	case models.TextboxFieldType:
 		r.renderTextBoxFieldType(node, submission)
 
+	case models.FileFieldType:
+		r.renderFileFieldType(node, submission)
+
 	case models.SelectFieldType:
 		r.renderSelectFieldType(node, submission)
 
@@ -150,83 +344,116 @@ func (r *PDFRenderer) renderField(node *models.ContentNode, submission *models.C
 }
 
 // renderSection - renders a Section. E.g. <section> ... </field>
-func (r *PDFRenderer) renderSection(node *models.ContentNode, submission *models.ContentSubmission) {
+func (r *PDFRenderer) renderSection(node *models.ContentNode, submission *models.ContentSubmission, scope *renderScope) {
+	r.applyScope(scope)
+
+	// Never leave the title alone at the bottom of the page
+	r.breakPageIfRequested(node)
+	r.keepTogether(r.measureSection(node, submission, r.pdf.GetX()))
+
+	// Register the section in the outline before the title so the bookmark points at it
+	r.bookmarkSection(node)
+
 	// Render title if any in a bigger and bolder text
-	r.renderTitle(node)
+	r.renderTitle(node, scope)
+}
+
+// sectionScope - creates the scope for the children of a section
+func (r *PDFRenderer) sectionScope(node *models.ContentNode, scope *renderScope) *renderScope {
+	child := &renderScope{
+		depth:  scope.depth + 1,
+		fields: new(int),
+	}
+
+	if entry, ok := r.sections[node]; ok {
+		child.number = entry.Number
+	}
+
+	// Without section numbers, the field numbers continue across sections - Q1, Q2, ... for the whole form
+	if child.number == "" {
+		child.fields = scope.fields
+	}
+
+	return child
+}
+
+// applyScope - indents the content and scales the font based on how deep the scope is nested
+func (r *PDFRenderer) applyScope(scope *renderScope) {
+	margin := r.leftMargin + float64(scope.depth)*r.Options.IndentPerDepth
+	r.pdf.SetLeftMargin(margin)
+	r.pdf.SetX(margin)
+	r.fontSize = r.scaledFontSize(defaultFontSize, scope.depth)
+}
+
+// scaledFontSize - the font size at the given depth, never smaller than minFontSize
+func (r *PDFRenderer) scaledFontSize(size float64, depth int) float64 {
+	scaled := size - float64(depth)*r.Options.FontSizeStep
+	if scaled < minFontSize {
+		return minFontSize
+	}
+	return scaled
+}
+
+// numberedCaption - prefixes the caption with the number of the field being rendered, if any. E.g. Q3.2 Country
+func (r *PDFRenderer) numberedCaption(caption string) string {
+	if r.fieldNumber == "" {
+		return caption
+	}
+	return fmt.Sprintf("%s %s", r.fieldNumber, caption)
 }
 
 // renderSelectFieldType - renders a Select FieldType. E.g. <field FieldType="Select"> ... </field>
 func (r *PDFRenderer) renderSelectFieldType(node *models.ContentNode, submission *models.ContentSubmission) {
 	// Step 1: Find the Caption if exists
-	caption := r.findCaption(node)
+	caption := r.numberedCaption(findCaption(node))
 
 	// Step 2: Find the submitted value
 	selectedValue := getSubmittedValue(submission, node.Name)
 
 	// Step 3: Render the Caption and submitted answer
 	line := fmt.Sprintf("%s: %s", caption, selectedValue)
-	r.useBoldFont(defaultFontSize)
-	r.writeCellLn(10, 10, line)
-
-	r.useNormalFont(defaultFontSize)
+	r.useBoldFont(r.fontSize)
+	if r.completion.isMissingRequired(node) {
+		r.useMissingColor()
+		r.pdf.CellFormat(0, captionLineHeight, line, "", 1, "", true, 0, "")
+		r.useNormalColor()
+	} else {
+		r.writeCellLn(captionLineHeight, captionLineHeight, line)
+	}
 
-	// Step 4: Find all Labels and construct the dropdown - also select the submitted value
-	validLabels := make(map[string]string)
+	r.useNormalFont(r.fontSize)
 
-	for _, child := range node.Children {
-		if child.ElementType == models.LabelsElementType {
-			for _, labelNode := range child.Children {
-				if labelNode.ElementType == models.LabelElementType {
-					labelName, ok := labelNode.Metadata["Name"]
-					if !ok {
-						logging.Log.Warnf("(skip)Label node without Name metadata: %+v", labelNode)
-						continue
-					}
-					labelText := labelNode.Value
-					validLabels[labelName] = labelText
-					continue
-				}
-				logging.Log.Warnf("(skip)Unknown label node type: %s", child.ElementType)
-			}
-		}
-	}
+	// Step 4: Find all Labels in the requested order - also select the submitted value
+	options := orderOptions(node.Options(), resolveOptionOrder(node, r.Options.OptionOrder))
 
 	// Step 5: Check if submitted value matches any label
-	if _, ok := validLabels[selectedValue]; !ok && selectedValue != "" {
+	isLabel := func(option models.Option) bool { return option.Name == selectedValue }
+	if !slices.ContainsFunc(options, isLabel) && selectedValue != "" && !r.isRedacted(node) {
 		logging.Log.Warnf("Submitted value '%s' for field '%s' not found in labels", selectedValue, node.Name)
 	}
 
 	// Step 6: Render all labels, marking the selected one with bold
-	// Apply a forced sorting
-	labelNames := make([]string, 0, len(validLabels))
-	for labelName := range validLabels {
-		labelNames = append(labelNames, labelName)
-	}
-	sort.Strings(labelNames)
-
-	for _, labelName := range labelNames {
-		labelText := validLabels[labelName]
-
+	for _, option := range options {
 		selectMarker := ""
-		if labelName == selectedValue {
+		if option.Name == selectedValue {
 			selectMarker = selectedMarkerValue
 		}
 
-		optionLine := fmt.Sprintf("- %s %s", labelText, selectMarker)
+		optionLine := fmt.Sprintf("- %s %s", option.Text, selectMarker)
 
-		if labelName == selectedValue {
+		if option.Name == selectedValue {
 			// Selected option
 			r.useHighlightColor()
-			r.useBoldFont(defaultFontSize)
+			r.useBoldFont(r.fontSize)
 
-			r.pdf.CellFormat(0, 8, optionLine, "", 1, "", true, 0, "")
+			r.pdf.CellFormat(0, answerLineHeight, optionLine, "", 1, "", true, 0, "")
 
 			// Reset the styling to default
-			r.useNormalFont(defaultFontSize)
+			r.useNormalFont(r.fontSize)
 			r.useNormalColor()
 		} else {
 			// Normal option - nothing special
-			r.writeCellLn(8, 8, optionLine)
+			r.writeCellLn(answerLineHeight, answerLineHeight, optionLine)
 		}
 	}
 }
@@ -234,7 +461,7 @@ func (r *PDFRenderer) renderSelectFieldType(node *models.ContentNode, submission
 // renderTextBoxFieldType - renders a Textbox FieldType. E.g. <field FieldType="TextBox"> ... </field>
 func (r *PDFRenderer) renderTextBoxFieldType(node *models.ContentNode, submission *models.ContentSubmission) {
 	// Step 1: Find the Caption if exists
-	caption := r.findCaption(node)
+	caption := r.numberedCaption(findCaption(node))
 
 	// Step 2: Find the submitted value
 	submittedValue := getSubmittedValue(submission, node.Name)
@@ -245,22 +472,49 @@ func (r *PDFRenderer) renderTextBoxFieldType(node *models.ContentNode, submissio
 	}
 
 	// Step 3: Render the Caption and the value
-	r.useBoldFont(defaultFontSize)
-	r.writeCellLn(10, 10, caption)
+	r.useBoldFont(r.fontSize)
+	r.writeCellLn(captionLineHeight, captionLineHeight, caption)
 
-	r.useNormalFont(defaultFontSize)
-	r.useHighlightColor()
-	r.pdf.MultiCell(0, 8, submittedValue, "", "", true)
+	r.useNormalFont(r.fontSize)
+	if r.completion.isMissingRequired(node) {
+		r.useMissingColor()
+	} else {
+		r.useHighlightColor()
+	}
+	r.pdf.MultiCell(0, answerLineHeight, submittedValue, "", "", true)
 	r.useNormalColor()
-	r.pdf.Ln(5)
+	r.pdf.Ln(answerSpacing)
+}
+
+// renderFileFieldType - renders a File FieldType as an image thumbnail, or like a textbox for the other files.
+// The embedded file follows if found.
+func (r *PDFRenderer) renderFileFieldType(node *models.ContentNode, submission *models.ContentSubmission) {
+	if image := r.findThumbnail(node, submission); image != nil {
+		r.renderThumbnail(node, image)
+	} else {
+		r.renderTextBoxFieldType(node, submission)
+	}
+	r.renderAttachment(node, submission)
 }
 
-func (r *PDFRenderer) renderTitle(node *models.ContentNode) {
+func (r *PDFRenderer) renderTitle(node *models.ContentNode, scope *renderScope) {
 	title := r.findTitle(node)
+	if entry, ok := r.sections[node]; ok && entry.Number != "" {
+		title = strings.TrimSpace(fmt.Sprintf("%s %s", entry.Number, title))
+	}
 
-	r.useBoldFont(titleFontSize)
-	r.writeCellLn(10, 12, title)
-	r.useNormalFont(defaultFontSize)
+	r.useBoldFont(r.scaledFontSize(titleFontSize, scope.depth))
+	r.writeCellLn(titleLineHeight, titleCellHeight, title)
+	r.useNormalFont(r.fontSize)
+}
+
+// readFieldType - reads the FieldType - decided not to transform this in the parser to keep it simple
+func readFieldType(node *models.ContentNode) models.FieldType {
+	fieldTypeStr, ok := node.Metadata["FieldType"]
+	if !ok {
+		return models.UnknownFieldType
+	}
+	return models.SafeReadFieldType(fieldTypeStr)
 }
 
 func getSubmittedValue(submission *models.ContentSubmission, fieldName string) string {
@@ -278,6 +532,11 @@ func (r *PDFRenderer) useHighlightColor() {
 	r.pdf.SetFillColor(220, 220, 220)
 }
 
+// useMissingColor - red background for the answers of required fields that are missing
+func (r *PDFRenderer) useMissingColor() {
+	r.pdf.SetFillColor(255, 200, 200)
+}
+
 func (r *PDFRenderer) useNormalFont(size float64) {
 	r.pdf.SetFont(font, "", size)
 }
@@ -0,0 +1,141 @@
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+var attachmentTextValue = "Attachment: %s (%s)"
+var missingAttachmentTextValue = "(attachment not found: %s)"
+var openAttachmentTextValue = "Open"
+var openAttachmentWidth float64 = 14
+
+/* The submission only has the name of the uploaded file for File fields. E.g. "code_repos": "repo.zip"
+   When an attachments folder is given, the file is looked up there and embedded in the PDF, so the reviewer
+   gets one document with everything inside. Under the answer, the renderer adds:
+   * a clickable annotation that opens the embedded file + the name and the size
+   * the SHA-256 of the file so it can be checked against the original upload
+
+   The file is looked up in the folder of its field first (<dir>/<field>/<file>, the layout of serve), so two
+   fields can get files with the same name, then directly in the folder (<dir>/<file>).
+*/
+
+// attachmentFile - an uploaded file found in the attachments folder
+type attachmentFile struct {
+	attachment *gofpdf.Attachment
+	size       int64
+	sha256     string
+}
+
+// findAttachment - looks up the submitted file of the field in the attachments folder. Only the base names are
+// used so the submission cannot point outside the folder. The files are loaded once per document.
+func (r *PDFRenderer) findAttachment(fieldName, fileName string) (*attachmentFile, error) {
+	name := filepath.Base(fileName)
+	path := filepath.Join(r.Options.AttachmentsDir, filepath.Base(fieldName), name)
+	if _, err := os.Stat(path); err != nil {
+		path = filepath.Join(r.Options.AttachmentsDir, name)
+	}
+	if file, ok := r.attachments[path]; ok {
+		return file, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	file := &attachmentFile{
+		attachment: &gofpdf.Attachment{
+			Content:  content,
+			Filename: name,
+		},
+		size:   int64(len(content)),
+		sha256: hex.EncodeToString(sum[:]),
+	}
+
+	if r.attachments == nil {
+		r.attachments = make(map[string]*attachmentFile)
+	}
+	r.attachments[path] = file
+
+	return file, nil
+}
+
+// hasAttachment - true when the field should show the attachment lines under the answer
+func (r *PDFRenderer) hasAttachment(node *models.ContentNode, submission *models.ContentSubmission) bool {
+	// The file of a sensitive field is never embedded when the answer is redacted
+	return r.Options.AttachmentsDir != "" && getSubmittedValue(submission, node.Name) != "" && !r.isRedacted(node)
+}
+
+// measureAttachment - the height of the attachment lines. A missing file only gets a note.
+func (r *PDFRenderer) measureAttachment(node *models.ContentNode, submission *models.ContentSubmission) float64 {
+	if !r.hasAttachment(node, submission) {
+		return 0
+	}
+
+	if _, err := r.findAttachment(node.Name, getSubmittedValue(submission, node.Name)); err != nil {
+		return answerLineHeight + answerSpacing
+	}
+	return 2*answerLineHeight + answerSpacing
+}
+
+// renderAttachment - embeds the submitted file and renders the annotation, size and checksum
+func (r *PDFRenderer) renderAttachment(node *models.ContentNode, submission *models.ContentSubmission) {
+	if !r.hasAttachment(node, submission) {
+		return
+	}
+
+	fileName := getSubmittedValue(submission, node.Name)
+	file, err := r.findAttachment(node.Name, fileName)
+	if err != nil {
+		// Report the missing file both in the logs and in the document
+		logging.Log.Warnf("Attachment '%s' for field '%s' not found in %s: %v", fileName, node.Name, r.Options.AttachmentsDir, err)
+
+		r.useBoldFont(r.fontSize)
+		r.writeCellLn(answerLineHeight, answerLineHeight, fmt.Sprintf(missingAttachmentTextValue, fileName))
+		r.useNormalFont(r.fontSize)
+		r.pdf.Ln(answerSpacing)
+		return
+	}
+
+	file.attachment.Description = findCaption(node)
+
+	// The annotation has no drawing of its own - draw a small button that marks the clickable area
+	x, y := r.pdf.GetX(), r.pdf.GetY()
+	r.pdf.CellFormat(openAttachmentWidth, answerLineHeight, openAttachmentTextValue, "1", 0, "C", false, 0, "")
+	r.pdf.AddAttachmentAnnotation(file.attachment, x, y, openAttachmentWidth, answerLineHeight)
+
+	r.writeCellLn(answerLineHeight, answerLineHeight, " "+fmt.Sprintf(attachmentTextValue, file.attachment.Filename, formatSize(file.size)))
+
+	// The checksum is long - use a smaller font so it fits on one line
+	r.useNormalFont(math.Max(r.fontSize-2, minFontSize))
+	r.writeCellLn(answerLineHeight, answerLineHeight, "SHA-256: "+file.sha256)
+	r.useNormalFont(r.fontSize)
+	r.pdf.Ln(answerSpacing)
+}
+
+// formatSize - human readable file size. E.g. 2.5 KB
+func formatSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+
+	value := float64(size)
+	unitIndex := 0
+	for value >= 1024 && unitIndex < len(units)-1 {
+		value /= 1024
+		unitIndex++
+	}
+
+	if unitIndex == 0 {
+		return fmt.Sprintf("%d %s", size, units[0])
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unitIndex])
+}
@@ -0,0 +1,109 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFileField(name string) *models.ContentNode {
+	return &models.ContentNode{
+		ElementType: models.FieldElementType,
+		Name:        name,
+		Metadata:    map[string]string{"Name": name, "FieldType": "File"},
+		Children: []*models.ContentNode{
+			{ElementType: models.CaptionElementType, Value: "Upload your code repo's in ZIP."},
+		},
+	}
+}
+
+func TestFindAttachment_HappyPath(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "repo.zip"), []byte("abc"), 0o600))
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{AttachmentsDir: dir})
+
+	// Act
+	file, err := renderer.findAttachment("code_repos", "repo.zip")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "repo.zip", file.attachment.Filename)
+	assert.Equal(t, int64(3), file.size)
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", file.sha256)
+}
+
+func TestFindAttachment_StaysInsideFolder(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "repo.zip"), []byte("abc"), 0o600))
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{AttachmentsDir: dir})
+
+	// Act
+	file, err := renderer.findAttachment("../code_repos", "../../somewhere/else/repo.zip")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "repo.zip", file.attachment.Filename)
+}
+
+func TestFindAttachment_FieldFolderFirst(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "code_repos"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "repo.zip"), []byte("other"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "code_repos", "repo.zip"), []byte("abc"), 0o600))
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{AttachmentsDir: dir})
+
+	// Act
+	fieldFile, err := renderer.findAttachment("code_repos", "repo.zip")
+	require.NoError(t, err)
+	otherFile, err := renderer.findAttachment("backup", "repo.zip")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(3), fieldFile.size)
+	assert.Equal(t, int64(5), otherFile.size)
+}
+
+func TestRender_EmbedsAttachment(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "repo.zip"), []byte("abc"), 0o600))
+	renderer := NewPDFRenderer(filepath.Join(dir, "form.xml"), dir, config.RenderOptions{AttachmentsDir: dir})
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{newFileField("code_repos")}}
+
+	// Act
+	err := renderer.Render(root, &models.ContentSubmission{"code_repos": "repo.zip"})
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "form.pdf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "/EmbeddedFile")
+	assert.Contains(t, string(content), "/FileAttachment")
+}
+
+func TestMeasureAttachment(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "repo.zip"), []byte("abc"), 0o600))
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{AttachmentsDir: dir})
+	field := newFileField("code_repos")
+
+	// Act Assert
+	assert.InDelta(t, 2*answerLineHeight+answerSpacing, renderer.measureAttachment(field, &models.ContentSubmission{"code_repos": "repo.zip"}), 0.01)
+	assert.InDelta(t, answerLineHeight+answerSpacing, renderer.measureAttachment(field, &models.ContentSubmission{"code_repos": "missing.zip"}), 0.01)
+	assert.Zero(t, renderer.measureAttachment(field, &models.ContentSubmission{}))
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "2.5 KB", formatSize(2560))
+	assert.Equal(t, "3.0 MB", formatSize(3*1024*1024))
+}
@@ -0,0 +1,100 @@
+package render
+
+import (
+	"strconv"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var linesOption = "Lines"
+var defaultBlankLines = 1
+var blankLineHeight float64 = 9
+var bubbleRadius float64 = 1.8
+var attachmentBoxHeight float64 = 16
+var attachDocumentTextValue = "Attach document"
+var attachImageTextValue = "Attach image (PNG or JPEG)"
+
+/* A blank form is rendered without a submission, to be printed and filled by hand:
+   * Textbox - a ruled line for every line of the answer, from the Lines option of the Type. E.g. Text([0,200],Lines:4)
+   * Select  - an empty bubble in front of every label (only one can be picked)
+   * File    - a box with an "attach document" note
+
+   Nothing is missing in a blank form, so the required answers are not highlighted and the on-invalid watermark is not printed.
+*/
+
+// renderBlankField - renders the empty answer area of the field
+func (r *PDFRenderer) renderBlankField(node *models.ContentNode, fieldType models.FieldType) {
+	r.useBoldFont(r.fontSize)
+	r.writeCellLn(captionLineHeight, captionLineHeight, r.numberedCaption(findCaption(node)))
+	r.useNormalFont(r.fontSize)
+
+	switch fieldType {
+	case models.TextboxFieldType:
+		r.renderRuledLines(blankLines(node))
+
+	case models.SelectFieldType:
+		options := orderOptions(node.Options(), resolveOptionOrder(node, r.Options.OptionOrder))
+		for _, option := range options {
+			x, y := r.pdf.GetX(), r.pdf.GetY()
+			r.pdf.Circle(x+bubbleRadius, y+answerLineHeight/2, bubbleRadius, "D")
+			r.pdf.SetX(x + 3*bubbleRadius)
+			r.writeCellLn(answerLineHeight, answerLineHeight, option.Text)
+		}
+		r.pdf.Ln(answerSpacing)
+
+	case models.FileFieldType:
+		text := attachDocumentTextValue
+		if models.ParseTypeDefinition(node.Metadata["Type"]).HasArgument(imagesArgument) {
+			text = attachImageTextValue
+		}
+		r.pdf.SetDrawColor(160, 160, 160)
+		r.pdf.SetDashPattern([]float64{1.5, 1}, 0)
+		r.pdf.CellFormat(0, attachmentBoxHeight, text, "1", 1, "C", false, 0, "")
+		r.pdf.SetDashPattern([]float64{}, 0)
+		r.pdf.SetDrawColor(0, 0, 0)
+		r.pdf.Ln(answerSpacing)
+	}
+}
+
+// renderRuledLines - the lines the answer is written on
+func (r *PDFRenderer) renderRuledLines(lines int) {
+	pageWidth, _ := r.pdf.GetPageSize()
+	_, _, right, _ := r.pdf.GetMargins()
+
+	r.pdf.SetDrawColor(160, 160, 160)
+	for i := 0; i < lines; i++ {
+		x, y := r.pdf.GetX(), r.pdf.GetY()+blankLineHeight
+		r.pdf.Line(x, y, pageWidth-right, y)
+		r.pdf.Ln(blankLineHeight)
+	}
+	r.pdf.SetDrawColor(0, 0, 0)
+	r.pdf.Ln(answerSpacing)
+}
+
+// measureBlankField - the height of the field block as renderBlankField draws it
+func (r *PDFRenderer) measureBlankField(node *models.ContentNode) float64 {
+	switch readFieldType(node) {
+	case models.TextboxFieldType:
+		return captionLineHeight + float64(blankLines(node))*blankLineHeight + answerSpacing
+	case models.SelectFieldType:
+		return captionLineHeight + float64(len(node.Options()))*answerLineHeight + answerSpacing
+	case models.FileFieldType:
+		return captionLineHeight + attachmentBoxHeight + answerSpacing
+	default:
+		return 0
+	}
+}
+
+// blankLines - the number of lines of a textbox answer. E.g. Text([0,200],Lines:4)
+func blankLines(node *models.ContentNode) int {
+	value, ok := models.ParseTypeDefinition(node.Metadata["Type"]).Options[linesOption]
+	if !ok {
+		return defaultBlankLines
+	}
+
+	lines, err := strconv.Atoi(value)
+	if err != nil || lines < 1 {
+		return defaultBlankLines
+	}
+	return lines
+}
@@ -0,0 +1,106 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTextField(name, fieldType string) *models.ContentNode {
+	return &models.ContentNode{
+		ElementType: models.FieldElementType,
+		Name:        name,
+		Metadata:    map[string]string{"Name": name, "Type": fieldType, "FieldType": "TextBox"},
+		Children: []*models.ContentNode{
+			{ElementType: models.CaptionElementType, Value: "Street Address"},
+		},
+	}
+}
+
+func newBlankTestRenderer() *PDFRenderer {
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{Blank: true})
+	renderer.newDocument()
+	return renderer
+}
+
+func TestBlankLines(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		expected  int
+	}{
+		{"Text([0,200],Lines:4)", 4},
+		{"Text([0,200])", defaultBlankLines},
+		{"Text([0,200],Lines:x)", defaultBlankLines},
+		{"Text([0,200],Lines:0)", defaultBlankLines},
+		{"Date", defaultBlankLines},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fieldType, func(t *testing.T) {
+			assert.Equal(t, tt.expected, blankLines(newTextField("street", tt.fieldType)))
+		})
+	}
+}
+
+func TestRenderBlankField_MatchesMeasure(t *testing.T) {
+	tests := []struct {
+		name  string
+		field *models.ContentNode
+	}{
+		{"textbox", newTextField("street", "Text([0,200],Lines:3)")},
+		{"select", newSelectField("language", "A", "B", "C")},
+		{"file", newFileField("code_repos")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			renderer := newBlankTestRenderer()
+			start := renderer.pdf.GetY()
+
+			// Act
+			renderer.renderField(tt.field, nil, &renderScope{fields: new(int)})
+
+			// Assert
+			assert.InDelta(t, renderer.measureField(tt.field, nil, renderer.pdf.GetX()), renderer.pdf.GetY()-start, 0.01)
+		})
+	}
+}
+
+func TestMeasureBlankField_SelectSpacing(t *testing.T) {
+	// Arrange
+	renderer := newBlankTestRenderer()
+
+	// Act
+	height := renderer.measureBlankField(newSelectField("language", "A", "B", "C"))
+
+	// Assert - the next field starts after the same spacing as below a textbox
+	assert.InDelta(t, captionLineHeight+3*answerLineHeight+answerSpacing, height, 0.01)
+}
+
+func TestRender_BlankForm(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		withOptional(newSelectField("language", "A", "B"), "False"),
+		withSensitive(newTextField("street", "Text([0,200],Lines:2)")),
+	}}
+	options := config.RenderOptions{
+		Blank:     true,
+		Watermark: config.WatermarkOptions{Policy: models.OnInvalidWatermarkPolicy, Opacity: 0.3},
+		Metadata:  config.MetadataOptions{AuthorFields: []string{"street"}},
+		Redaction: config.RedactionProfile{Action: models.HideRedactionAction},
+	}
+	renderer := NewPDFRenderer("output.pdf", t.TempDir(), options)
+
+	// Act
+	err := renderer.Render(root, nil)
+
+	// Assert - nothing is missing, hidden or authored in a blank form
+	require.NoError(t, err)
+	assert.True(t, renderer.completion.complete())
+	assert.False(t, renderer.isHidden(root.Children[1]))
+	assert.Empty(t, renderer.metadata.Author)
+}
@@ -0,0 +1,153 @@
+package render
+
+import (
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+var diffFontSize float64 = 10
+var diffLineHeight, diffCellPadding float64 = 5, 1.5
+var diffHeaders = []string{"Field", "Old", "New"}
+
+// diffColors - the fill of a row for every type of change
+var diffColors = map[models.ChangeType][3]int{
+	models.AddedChangeType:     {200, 240, 200},
+	models.RemovedChangeType:   {255, 200, 200},
+	models.ChangedChangeType:   {255, 240, 180},
+	models.UnchangedChangeType: {255, 255, 255},
+}
+
+type PDFDiffRenderer struct {
+	Filename string
+	Dir      string
+	Options  config.DiffRenderOptions
+
+	pdf     *gofpdf.Fpdf
+	widths  []float64
+	section string
+}
+
+func NewPDFDiffRenderer(fileName, dir string, options config.DiffRenderOptions) *PDFDiffRenderer {
+	return &PDFDiffRenderer{
+		Filename: fileName,
+		Dir:      dir,
+		Options:  options,
+	}
+}
+
+/* The changes are rendered as a table with the field, the old and the new answer on every row.
+   The row color shows the change: green when added, red when removed and yellow when changed.
+   A row with the section titles is added every time the fields move to another section.
+*/
+
+func (r *PDFDiffRenderer) RenderDiff(form *models.ContentNode, changes []diff.FieldChange) error {
+	r.pdf = gofpdf.New(orientation, unit, size, "")
+	r.pdf.SetTitle(diffTitle(form, r.Filename), true)
+	r.pdf.SetCreator(creatorTextValue, true)
+	r.pdf.AddPage()
+
+	// The counts match the rows - redacted and without the unchanged fields if requested
+	changes = prepareChanges(changes, r.Options)
+
+	pageWidth, _ := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+	column := (pageWidth - left - right) / 10
+	r.widths = []float64{3 * column, 3.5 * column, 3.5 * column}
+
+	r.pdf.SetFont(font, "B", titleFontSize)
+	r.pdf.CellFormat(0, titleCellHeight, diffTitle(form, r.Filename), "", 1, "", false, 0, "")
+	r.pdf.SetFont(font, "", diffFontSize)
+	r.pdf.CellFormat(0, answerLineHeight, countChanges(changes).String(), "", 1, "", false, 0, "")
+	r.pdf.Ln(answerSpacing)
+
+	r.renderHeader()
+	for _, change := range changes {
+		r.renderSectionRow(change.Sections)
+		r.renderChangeRow(change)
+	}
+
+	outputPath := outputFilePath(r.Filename, r.Dir, diffFileSuffix, models.PDFFileType)
+	err := createOutputDir(outputPath)
+	if err != nil {
+		logging.Log.Errorf("Error creating the output folder: %v", err)
+		return err
+	}
+
+	err = r.pdf.OutputFileAndClose(outputPath)
+	if err != nil {
+		logging.Log.Errorf("Error writing file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// renderHeader - the column names, repeated on every page
+func (r *PDFDiffRenderer) renderHeader() {
+	r.pdf.SetFont(font, "B", diffFontSize)
+	r.pdf.SetFillColor(230, 230, 230)
+	for i, header := range diffHeaders {
+		r.pdf.CellFormat(r.widths[i], diffLineHeight+2*diffCellPadding, header, "1", 0, "L", true, 0, "")
+	}
+	r.pdf.Ln(-1)
+	r.pdf.SetFont(font, "", diffFontSize)
+}
+
+// renderSectionRow - a row with the section titles, when the field is in another section than the previous one
+func (r *PDFDiffRenderer) renderSectionRow(sections []string) {
+	section := sectionLabel(sections)
+	if section == r.section {
+		return
+	}
+	r.section = section
+
+	r.breakPageIfNeeded(diffLineHeight + 2*diffCellPadding)
+	r.pdf.SetFont(font, "B", diffFontSize)
+	r.pdf.SetFillColor(245, 245, 245)
+	r.pdf.CellFormat(0, diffLineHeight+2*diffCellPadding, section, "1", 1, "L", true, 0, "")
+	r.pdf.SetFont(font, "", diffFontSize)
+}
+
+// renderChangeRow - the caption, the old and the new answer of the field, as tall as the longest of them
+func (r *PDFDiffRenderer) renderChangeRow(change diff.FieldChange) {
+	texts := []string{
+		findCaption(change.Field),
+		displayValue(change.Field, change.Old),
+		displayValue(change.Field, change.New),
+	}
+
+	lines := 1
+	for i, text := range texts {
+		lines = max(lines, len(r.pdf.SplitLines([]byte(text), r.widths[i]-2*diffCellPadding)))
+	}
+	height := float64(lines)*diffLineHeight + 2*diffCellPadding
+	r.breakPageIfNeeded(height)
+
+	color := diffColors[change.Change]
+	r.pdf.SetFillColor(color[0], color[1], color[2])
+
+	x, y := r.pdf.GetXY()
+	for i, text := range texts {
+		r.pdf.Rect(x, y, r.widths[i], height, "FD")
+		r.pdf.SetXY(x+diffCellPadding, y+diffCellPadding)
+		r.pdf.MultiCell(r.widths[i]-2*diffCellPadding, diffLineHeight, text, "", "L", false)
+		x += r.widths[i]
+	}
+	left, _, _, _ := r.pdf.GetMargins()
+	r.pdf.SetXY(left, y+height)
+}
+
+// breakPageIfNeeded - starts a new page with the header when a row of the given height does not fit
+func (r *PDFDiffRenderer) breakPageIfNeeded(height float64) {
+	_, pageHeight := r.pdf.GetPageSize()
+	_, bottom := r.pdf.GetAutoPageBreak()
+	if r.pdf.GetY()+height <= pageHeight-bottom {
+		return
+	}
+
+	r.pdf.AddPage()
+	r.renderHeader()
+}
@@ -0,0 +1,172 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg" // register the decoders used by image.DecodeConfig
+	_ "image/png"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+var imagesArgument, acceptOption, maxWidthOption, maxHeightOption = "Images", "Accept", "MaxWidth", "MaxHeight"
+var defaultThumbnailMaxHeight float64 = 60
+var thumbnailDpi float64 = 96
+
+// Content type (sniffed from the file) => gofpdf image type
+var thumbnailImageTypes = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpg",
+}
+
+/* File answers that are images (photo ID, signature scan, diagram) are rendered as a thumbnail under the caption.
+   The Type attribute of the field can tweak this:
+   * File(Images)              - the field expects images, other files are reported
+   * File(Accept:png)          - only these image types get a thumbnail (png, jpg), separated by |
+   * File(MaxWidth:80,MaxHeight:40) - the box (mm) the thumbnail is scaled into, keeping the aspect ratio
+
+   Everything else falls back to the file name, like a textbox.
+*/
+
+// thumbnail - an image answer, registered in the document. It is scaled by fitThumbnail to the X it is drawn at.
+type thumbnail struct {
+	name                    string
+	pixelWidth, pixelHeight int
+	// maxWidth is 0 when the Type has no MaxWidth
+	maxWidth, maxHeight float64
+}
+
+// findThumbnail - returns the image to render for a File field, nil when the file name should be rendered instead.
+// The field is measured before it is rendered, so the result is kept for the second call.
+func (r *PDFRenderer) findThumbnail(node *models.ContentNode, submission *models.ContentSubmission) *thumbnail {
+	if image, ok := r.thumbnails[node]; ok {
+		return image
+	}
+
+	image := r.loadThumbnail(node, submission)
+	r.thumbnails[node] = image
+	return image
+}
+
+// loadThumbnail - checks the submitted file and registers it in the document when it is a supported image
+func (r *PDFRenderer) loadThumbnail(node *models.ContentNode, submission *models.ContentSubmission) *thumbnail {
+	if !r.hasAttachment(node, submission) {
+		return nil
+	}
+
+	fileName := getSubmittedValue(submission, node.Name)
+	file, err := r.findAttachment(node.Name, fileName)
+	if err != nil {
+		return nil
+	}
+
+	definition := models.ParseTypeDefinition(node.Metadata["Type"])
+	imageType, ok := thumbnailImageTypes[http.DetectContentType(file.attachment.Content)]
+	if !ok {
+		if definition.HasArgument(imagesArgument) {
+			logging.Log.Warnf("Field '%s' expects an image but '%s' is not a PNG or JPEG", node.Name, fileName)
+		}
+		return nil
+	}
+
+	if !acceptsImageType(definition, imageType) {
+		return nil
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(file.attachment.Content))
+	if err != nil || imageConfig.Width == 0 || imageConfig.Height == 0 {
+		logging.Log.Warnf("Invalid image '%s' for field '%s': %v", fileName, node.Name, err)
+		return nil
+	}
+
+	// Register before anything is drawn - an image gofpdf cannot read must not break the whole document
+	name := "thumbnail-" + file.sha256
+	r.pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(file.attachment.Content))
+	if r.pdf.Err() {
+		logging.Log.Warnf("Cannot render image '%s' for field '%s': %v", fileName, node.Name, r.pdf.Error())
+		r.pdf.ClearError()
+		return nil
+	}
+
+	image := &thumbnail{name: name, pixelWidth: imageConfig.Width, pixelHeight: imageConfig.Height, maxHeight: defaultThumbnailMaxHeight}
+	if value, ok := readDimension(node, definition, maxWidthOption); ok {
+		image.maxWidth = value
+	}
+	if value, ok := readDimension(node, definition, maxHeightOption); ok {
+		image.maxHeight = value
+	}
+	return image
+}
+
+// fitThumbnail - scales the image into the width left at x and the max dimensions, keeping the aspect ratio.
+// x is where the image is drawn: the indented X of the field, not the X of the section being measured.
+func (r *PDFRenderer) fitThumbnail(image *thumbnail, x float64) (float64, float64) {
+	pageWidth, _ := r.pdf.GetPageSize()
+	_, _, right, _ := r.pdf.GetMargins()
+	maxWidth := pageWidth - right - x
+	if image.maxWidth > 0 && image.maxWidth < maxWidth {
+		maxWidth = image.maxWidth
+	}
+	maxHeight := image.maxHeight
+
+	// Never scale the image above its natural size
+	ratio := float64(image.pixelHeight) / float64(image.pixelWidth)
+	width := min(float64(image.pixelWidth)*25.4/thumbnailDpi, maxWidth)
+	height := width * ratio
+	if height > maxHeight {
+		height = maxHeight
+		width = height / ratio
+	}
+
+	return width, height
+}
+
+// readDimension - reads a positive number of mm from the Type options
+func readDimension(node *models.ContentNode, definition models.TypeDefinition, option string) (float64, bool) {
+	value, ok := definition.Options[option]
+	if !ok {
+		return 0, false
+	}
+
+	dimension, err := strconv.ParseFloat(value, 64)
+	if err != nil || dimension <= 0 {
+		logging.Log.Warnf("(skip)Invalid %s '%s' for field '%s'", option, value, node.Name)
+		return 0, false
+	}
+	return dimension, true
+}
+
+// acceptsImageType - checks the Accept option of the Type. E.g. File(Accept:png|jpg). All images are accepted without it.
+func acceptsImageType(definition models.TypeDefinition, imageType string) bool {
+	accept, ok := definition.Options[acceptOption]
+	if !ok {
+		return true
+	}
+
+	for _, accepted := range strings.Split(strings.ToLower(accept), "|") {
+		accepted = strings.TrimSpace(accepted)
+		if accepted == "jpeg" {
+			accepted = "jpg"
+		}
+		if accepted == imageType {
+			return true
+		}
+	}
+	return false
+}
+
+// renderThumbnail - renders the caption followed by the image
+func (r *PDFRenderer) renderThumbnail(node *models.ContentNode, image *thumbnail) {
+	r.useBoldFont(r.fontSize)
+	r.writeCellLn(captionLineHeight, captionLineHeight, r.numberedCaption(findCaption(node)))
+	r.useNormalFont(r.fontSize)
+
+	width, height := r.fitThumbnail(image, r.pdf.GetX())
+	r.pdf.ImageOptions(image.name, r.pdf.GetX(), r.pdf.GetY(), width, height, true, gofpdf.ImageOptions{}, 0, "")
+	r.pdf.Ln(answerSpacing)
+}
@@ -0,0 +1,128 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePNG(t *testing.T, path string, width, height int) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, height/2, color.Black)
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, png.Encode(&buffer, img))
+	require.NoError(t, os.WriteFile(path, buffer.Bytes(), 0o600))
+}
+
+func newImageTestRenderer(t *testing.T) (*PDFRenderer, string) {
+	dir := t.TempDir()
+	writePNG(t, filepath.Join(dir, "photo.png"), 400, 200)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "repo.zip"), []byte("not an image"), 0o600))
+
+	renderer := NewPDFRenderer(filepath.Join(dir, "form.xml"), dir, config.RenderOptions{AttachmentsDir: dir})
+	renderer.newDocument()
+	return renderer, dir
+}
+
+func TestFindThumbnail_ScalesIntoMaxWidth(t *testing.T) {
+	// Arrange
+	renderer, _ := newImageTestRenderer(t)
+	field := newFileField("photo")
+	field.Metadata["Type"] = "File(Images,MaxWidth:50)"
+
+	// Act
+	image := renderer.findThumbnail(field, &models.ContentSubmission{"photo": "photo.png"})
+
+	// Assert
+	require.NotNil(t, image)
+	width, height := renderer.fitThumbnail(image, renderer.pdf.GetX())
+	assert.InDelta(t, 50, width, 0.01)
+	assert.InDelta(t, 25, height, 0.01)
+}
+
+func TestFindThumbnail_ScalesIntoMaxHeight(t *testing.T) {
+	// Arrange
+	renderer, _ := newImageTestRenderer(t)
+	field := newFileField("photo")
+	field.Metadata["Type"] = "File(MaxHeight:10)"
+
+	// Act
+	image := renderer.findThumbnail(field, &models.ContentSubmission{"photo": "photo.png"})
+
+	// Assert
+	require.NotNil(t, image)
+	width, height := renderer.fitThumbnail(image, renderer.pdf.GetX())
+	assert.InDelta(t, 20, width, 0.01)
+	assert.InDelta(t, 10, height, 0.01)
+}
+
+func TestFitThumbnail_ScalesIntoWidthLeftAtX(t *testing.T) {
+	// Arrange
+	renderer, _ := newImageTestRenderer(t)
+	image := renderer.findThumbnail(newFileField("photo"), &models.ContentSubmission{"photo": "photo.png"})
+	require.NotNil(t, image)
+	pageWidth, _ := renderer.pdf.GetPageSize()
+	_, _, right, _ := renderer.pdf.GetMargins()
+
+	// Act
+	width, height := renderer.fitThumbnail(image, pageWidth-right-40)
+
+	// Assert - the same cached image is narrower at a deeper indent
+	assert.InDelta(t, 40, width, 0.01)
+	assert.InDelta(t, 20, height, 0.01)
+}
+
+func TestFindThumbnail_FallsBackToFileName(t *testing.T) {
+	tests := []struct {
+		name       string
+		fieldType  string
+		submission string
+	}{
+		{"NotAnImage", "File(Images)", "repo.zip"},
+		{"NotAccepted", "File(Accept:jpg)", "photo.png"},
+		{"MissingFile", "File", "missing.png"},
+		{"NoAnswer", "File", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			renderer, _ := newImageTestRenderer(t)
+			field := newFileField("photo")
+			field.Metadata["Type"] = tt.fieldType
+
+			// Act
+			image := renderer.findThumbnail(field, &models.ContentSubmission{"photo": tt.submission})
+
+			// Assert
+			assert.Nil(t, image)
+		})
+	}
+}
+
+func TestRender_ImageThumbnail(t *testing.T) {
+	// Arrange
+	renderer, dir := newImageTestRenderer(t)
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{newFileField("photo")}}
+
+	// Act
+	err := renderer.Render(root, &models.ContentSubmission{"photo": "photo.png"})
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "form.pdf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "/Subtype /Image")
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var pageBreakBeforeAttribute = "PageBreakBefore"
+
+/* gofpdf breaks the page automatically when a line does not fit anymore. That splits the fields in the middle:
+   the caption stays at the bottom of a page and the answer (or options) moves to the next one.
+
+   To avoid this, every block is measured before it is drawn. If it does not fit in the space left on the page,
+   the page is broken upfront so the whole block moves to the next page.
+   * field block: caption + answer (textbox, file) or caption + options (select)
+   * section block: title + the first block inside the section - a title is never left alone at the bottom
+*/
+
+// keepTogether - starts a new page when a block of the given height does not fit on the current one
+func (r *PDFRenderer) keepTogether(height float64) {
+	_, pageHeight := r.pdf.GetPageSize()
+	_, top, _, _ := r.pdf.GetMargins()
+	_, bottom := r.pdf.GetAutoPageBreak()
+
+	available := pageHeight - bottom - r.pdf.GetY()
+
+	// Blocks taller than a page will be split anyway - moving them would only leave an empty space behind
+	if height > available && height <= pageHeight-top-bottom {
+		r.breakPage()
+	}
+}
+
+// breakPage - starts a new page, unless nothing was rendered on the current one yet
+func (r *PDFRenderer) breakPage() {
+	_, top, _, _ := r.pdf.GetMargins()
+	if r.pdf.GetY() > top {
+		r.pdf.AddPage()
+	}
+}
+
+// breakPageIfRequested - handles the PageBreakBefore="True" attribute on fields and sections
+func (r *PDFRenderer) breakPageIfRequested(node *models.ContentNode) {
+	if node.BoolMetadata(pageBreakBeforeAttribute) {
+		r.breakPage()
+	}
+}
+
+// measureField - the height of the field block as it will be rendered at x
+func (r *PDFRenderer) measureField(node *models.ContentNode, submission *models.ContentSubmission, x float64) float64 {
+	if r.Options.Blank {
+		return r.measureBlankField(node)
+	}
+
+	switch readFieldType(node) {
+	case models.TextboxFieldType:
+		return r.measureTextBox(node, submission, x)
+
+	case models.FileFieldType:
+		if image := r.findThumbnail(node, submission); image != nil {
+			_, height := r.fitThumbnail(image, x)
+			return captionLineHeight + height + answerSpacing + r.measureAttachment(node, submission)
+		}
+		return r.measureTextBox(node, submission, x) + r.measureAttachment(node, submission)
+
+	case models.SelectFieldType:
+		return captionLineHeight + float64(len(node.Options()))*answerLineHeight
+
+	default:
+		return 0
+	}
+}
+
+// measureTextBox - the height of the caption and the answer box, with the answer wrapped from x
+func (r *PDFRenderer) measureTextBox(node *models.ContentNode, submission *models.ContentSubmission, x float64) float64 {
+	submittedValue := getSubmittedValue(submission, node.Name)
+	if submittedValue == "" {
+		submittedValue = missingAnswerTextValue
+	}
+
+	// Measure with the font the answer is rendered with
+	r.useNormalFont(r.fontSize)
+	pageWidth, _ := r.pdf.GetPageSize()
+	_, _, right, _ := r.pdf.GetMargins()
+	lines := r.pdf.SplitLines([]byte(submittedValue), pageWidth-right-x)
+
+	return captionLineHeight + float64(len(lines))*answerLineHeight + answerSpacing
+}
+
+// measureSection - the height of the section title at x together with the first block inside the section
+func (r *PDFRenderer) measureSection(node *models.ContentNode, submission *models.ContentSubmission, x float64) float64 {
+	// The title cell is taller than the line, but the cursor only moves by the line height.
+	// The children are one level deeper, so they are measured at their own indent.
+	return titleLineHeight + r.measureFirstBlock(node, submission, x+r.Options.IndentPerDepth)
+}
+
+// measureFirstBlock - finds the first field or section under the node and measures it at x
+func (r *PDFRenderer) measureFirstBlock(node *models.ContentNode, submission *models.ContentSubmission, x float64) float64 {
+	for _, child := range node.Children {
+		switch child.ElementType {
+		case models.FieldElementType:
+			if r.isHidden(child) {
+				continue
+			}
+			return r.measureField(child, submission, x)
+		case models.SectionElementType:
+			return r.measureSection(child, submission, x)
+		case models.ContentsElementType:
+			if height := r.measureFirstBlock(child, submission, x); height > 0 {
+				return height
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSelectField(name string, labels ...string) *models.ContentNode {
+	labelsNode := &models.ContentNode{ElementType: models.LabelsElementType}
+	for _, label := range labels {
+		labelsNode.Children = append(labelsNode.Children, &models.ContentNode{
+			ElementType: models.LabelElementType,
+			Metadata:    map[string]string{"Name": label},
+			Value:       label,
+		})
+	}
+
+	return &models.ContentNode{
+		ElementType: models.FieldElementType,
+		Name:        name,
+		Metadata:    map[string]string{"Name": name, "FieldType": "Select"},
+		Children: []*models.ContentNode{
+			{ElementType: models.CaptionElementType, Value: "Pick one"},
+			labelsNode,
+		},
+	}
+}
+
+func newTestRenderer() *PDFRenderer {
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{})
+	renderer.newDocument()
+	return renderer
+}
+
+func TestRenderField_KeepsCaptionAndOptionsTogether(t *testing.T) {
+	// Arrange
+	renderer := newTestRenderer()
+	field := newSelectField("language", "A", "B", "C")
+	submission := &models.ContentSubmission{"language": "B"}
+	_, top, _, _ := renderer.pdf.GetMargins()
+
+	// Only the caption would fit at the bottom of the page
+	_, pageHeight := renderer.pdf.GetPageSize()
+	_, bottom := renderer.pdf.GetAutoPageBreak()
+	renderer.pdf.SetY(pageHeight - bottom - captionLineHeight - 1)
+
+	// Act
+	renderer.renderField(field, submission, &renderScope{fields: new(int)})
+
+	// Assert
+	assert.Equal(t, 2, renderer.pdf.PageNo())
+	assert.InDelta(t, top+captionLineHeight+3*answerLineHeight, renderer.pdf.GetY(), 0.01)
+}
+
+func TestRenderField_FitsOnPage(t *testing.T) {
+	// Arrange
+	renderer := newTestRenderer()
+	field := newSelectField("language", "A", "B")
+
+	// Act
+	renderer.renderField(field, &models.ContentSubmission{}, &renderScope{fields: new(int)})
+
+	// Assert
+	assert.Equal(t, 1, renderer.pdf.PageNo())
+}
+
+func TestRenderField_PageBreakBefore(t *testing.T) {
+	// Arrange
+	renderer := newTestRenderer()
+	first := newSelectField("first", "A")
+	second := newSelectField("second", "A")
+	second.Metadata[pageBreakBeforeAttribute] = "True"
+	scope := &renderScope{fields: new(int)}
+
+	// Act
+	renderer.renderField(first, &models.ContentSubmission{}, scope)
+	renderer.renderField(second, &models.ContentSubmission{}, scope)
+
+	// Assert
+	assert.Equal(t, 2, renderer.pdf.PageNo())
+}
+
+func TestBreakPage_SkipsEmptyPage(t *testing.T) {
+	// Arrange
+	renderer := newTestRenderer()
+
+	// Act
+	renderer.breakPage()
+
+	// Assert
+	assert.Equal(t, 1, renderer.pdf.PageNo())
+}
+
+func TestMeasureSection_IncludesFirstField(t *testing.T) {
+	// Arrange
+	renderer := newTestRenderer()
+	section := &models.ContentNode{
+		ElementType: models.SectionElementType,
+		Children: []*models.ContentNode{
+			{ElementType: models.TitleElementType, Value: "Title"},
+			{ElementType: models.ContentsElementType, Children: []*models.ContentNode{
+				newSelectField("language", "A", "B"),
+			}},
+		},
+	}
+
+	// Act
+	height := renderer.measureSection(section, &models.ContentSubmission{}, renderer.pdf.GetX())
+
+	// Assert
+	assert.InDelta(t, titleLineHeight+captionLineHeight+2*answerLineHeight, height, 0.01)
+}
@@ -0,0 +1,201 @@
+package render
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/revision"
+)
+
+var creatorTextValue = "form-parser"
+
+// documentMetadata - the title, author, subject and keywords of the output, plus the custom properties
+type documentMetadata struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords string
+
+	Properties []property
+}
+
+type property struct {
+	Name  string
+	Value string
+}
+
+/* The metadata comes from the form and the submission:
+   * Title    - <Form Title="..."> or a <Title> inside the form, otherwise the title of the first section
+   * Author   - the submitted values of the configured author fields. E.g. first_name, last_name
+   * Subject, Keywords - <Form Subject="..." Keywords="...">
+   * FormID, FormVersion - <Form Name="..." Version="...">
+   * SubmissionSHA256 - hash of the submission as JSON with sorted keys, so the formatting of the file does not matter.
+     Left out when the redaction profile redacts, the hash would give the hidden answers away.
+
+   gofpdf only writes the standard fields. The XMP metadata (pdfx namespace, shown as custom properties by most
+   viewers) holds all of them and is written by gofpdf, so a password protected file gets it encrypted like the
+   rest. gofpdf does not link it from the catalog, which is done after the document is written, together with
+   the custom properties of the document information. Those are strings, so an encrypted file only has them in XMP.
+*/
+
+// collectMetadata - reads the metadata of the output from the form, its sections and the submission
+func (r *PDFRenderer) collectMetadata(form *models.ContentNode, submission *models.ContentSubmission, sections []*sectionEntry) *documentMetadata {
+	metadata := &documentMetadata{
+		Title:    form.Metadata["Title"],
+		Subject:  form.Metadata["Subject"],
+		Keywords: form.Metadata["Keywords"],
+	}
+
+	if metadata.Title == "" {
+		metadata.Title = r.findTitle(form)
+	}
+	for _, entry := range sections {
+		if metadata.Title != "" {
+			break
+		}
+		metadata.Title = entry.Title
+	}
+
+	// A blank form has no author
+	if submission != nil {
+		var author []string
+		for _, field := range r.Options.Metadata.AuthorFields {
+			if value := strings.TrimSpace(getSubmittedValue(submission, field)); value != "" {
+				author = append(author, value)
+			}
+		}
+		metadata.Author = strings.Join(author, " ")
+	}
+
+	properties := make(map[string]string)
+	if form.Name != "" {
+		properties["FormID"] = form.Name
+	}
+	if version := form.Metadata["Version"]; version != "" {
+		properties["FormVersion"] = version
+	}
+	// An unsalted hash of the answers could be brute-forced from the ones left in a redacted output
+	if submission != nil && !r.Options.Redaction.Enabled() {
+		properties["SubmissionSHA256"] = submissionHash(submission)
+	}
+
+	// The configured properties win over the ones read from the form
+	for name, value := range r.Options.Metadata.Properties {
+		properties[name] = value
+	}
+
+	for name, value := range properties {
+		metadata.Properties = append(metadata.Properties, property{Name: name, Value: value})
+	}
+	sort.Slice(metadata.Properties, func(i, j int) bool { return metadata.Properties[i].Name < metadata.Properties[j].Name })
+
+	return metadata
+}
+
+// applyMetadata - sets the standard fields of the document. gofpdf writes empty fields too, so they are skipped.
+func (r *PDFRenderer) applyMetadata() {
+	if r.metadata.Title != "" {
+		r.pdf.SetTitle(r.metadata.Title, true)
+	}
+	if r.metadata.Author != "" {
+		r.pdf.SetAuthor(r.metadata.Author, true)
+	}
+	if r.metadata.Subject != "" {
+		r.pdf.SetSubject(r.metadata.Subject, true)
+	}
+	if r.metadata.Keywords != "" {
+		r.pdf.SetKeywords(r.metadata.Keywords, true)
+	}
+	r.pdf.SetCreator(creatorTextValue, false)
+	r.pdf.SetXmpMetadata(r.metadata.xmp(r.createdAt))
+}
+
+// writeMetadata - links the XMP metadata from the catalog and adds the custom properties to the written document
+func (r *PDFRenderer) writeMetadata(document []byte) ([]byte, error) {
+	update, err := revision.New(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// gofpdf writes the XMP stream right before the document information
+	xmpNumber := update.Info() - 1
+	xmp, err := update.Dictionary(xmpNumber)
+	if err != nil || !bytes.Contains(xmp, []byte("/Type /Metadata")) {
+		return nil, errors.New("XMP metadata not found in the written document")
+	}
+
+	catalog, err := update.Dictionary(update.Root())
+	if err != nil {
+		return nil, err
+	}
+	update.Replace(update.Root(), revision.AppendEntries(catalog, fmt.Sprintf("/Metadata %d 0 R", xmpNumber)))
+
+	// The strings would have to be encrypted like the rest of the file - the XMP metadata has the properties
+	if !update.Encrypted() && len(r.metadata.Properties) > 0 {
+		info, err := update.Dictionary(update.Info())
+		if err != nil {
+			return nil, err
+		}
+
+		var entries strings.Builder
+		for _, p := range r.metadata.Properties {
+			fmt.Fprintf(&entries, "/%s %s\n", p.Name, revision.TextString(p.Value))
+		}
+		update.Replace(update.Info(), revision.AppendEntries(info, entries.String()))
+	}
+
+	return update.Bytes(), nil
+}
+
+// xmp - the XMP packet with the standard fields and the custom properties
+func (m *documentMetadata) xmp(createdAt time.Time) []byte {
+	var buffer bytes.Buffer
+	escape := func(value string) string {
+		var escaped bytes.Buffer
+		_ = xml.EscapeText(&escaped, []byte(value))
+		return escaped.String()
+	}
+
+	buffer.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	buffer.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	buffer.WriteString("<rdf:Description rdf:about=\"\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\"")
+	buffer.WriteString(" xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\" xmlns:pdfx=\"http://ns.adobe.com/pdfx/1.3/\">\n")
+
+	buffer.WriteString("<dc:format>application/pdf</dc:format>\n")
+	if m.Title != "" {
+		fmt.Fprintf(&buffer, "<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", escape(m.Title))
+	}
+	if m.Author != "" {
+		fmt.Fprintf(&buffer, "<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", escape(m.Author))
+	}
+	if m.Subject != "" {
+		fmt.Fprintf(&buffer, "<dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", escape(m.Subject))
+	}
+	if m.Keywords != "" {
+		fmt.Fprintf(&buffer, "<pdf:Keywords>%s</pdf:Keywords>\n", escape(m.Keywords))
+	}
+	fmt.Fprintf(&buffer, "<xmp:CreateDate>%s</xmp:CreateDate>\n", createdAt.Format(time.RFC3339))
+	fmt.Fprintf(&buffer, "<xmp:CreatorTool>%s</xmp:CreatorTool>\n", creatorTextValue)
+	for _, p := range m.Properties {
+		fmt.Fprintf(&buffer, "<pdfx:%s>%s</pdfx:%s>\n", p.Name, escape(p.Value), p.Name)
+	}
+
+	buffer.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n<?xpacket end=\"w\"?>")
+	return buffer.Bytes()
+}
+
+// submissionHash - SHA-256 of the submission as JSON. Maps are written with sorted keys.
+func submissionHash(submission *models.ContentSubmission) string {
+	content, _ := json.Marshal(submission)
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
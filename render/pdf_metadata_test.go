@@ -0,0 +1,149 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/revision"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSection(title string, children ...*models.ContentNode) *models.ContentNode {
+	return &models.ContentNode{
+		ElementType: models.SectionElementType,
+		Metadata:    map[string]string{},
+		Children: append([]*models.ContentNode{
+			{ElementType: models.TitleElementType, Value: title},
+		}, children...),
+	}
+}
+
+func TestCollectMetadata_FromFormAndSubmission(t *testing.T) {
+	// Arrange
+	form := &models.ContentNode{
+		ElementType: models.FormElementType,
+		Name:        "job_application",
+		Metadata:    map[string]string{"Name": "job_application", "Version": "2", "Title": "Job application", "Subject": "Hiring", "Keywords": "hr"},
+	}
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{Metadata: config.MetadataOptions{
+		AuthorFields: []string{"first_name", "middle_name", "last_name"},
+		Properties:   map[string]string{"department": "hr", "FormVersion": "3"},
+	}})
+	submission := &models.ContentSubmission{"first_name": "Jane", "last_name": "Doe"}
+
+	// Act
+	metadata := renderer.collectMetadata(form, submission, nil)
+
+	// Assert
+	assert.Equal(t, "Job application", metadata.Title)
+	assert.Equal(t, "Jane Doe", metadata.Author)
+	assert.Equal(t, "Hiring", metadata.Subject)
+	assert.Equal(t, "hr", metadata.Keywords)
+	assert.Equal(t, []property{
+		{Name: "FormID", Value: "job_application"},
+		{Name: "FormVersion", Value: "3"},
+		{Name: "SubmissionSHA256", Value: submissionHash(submission)},
+		{Name: "department", Value: "hr"},
+	}, metadata.Properties)
+}
+
+func TestCollectMetadata_TitleFallbacks(t *testing.T) {
+	// Arrange
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{})
+	sectionForm := &models.ContentNode{ElementType: models.FormElementType, Metadata: map[string]string{}, Children: []*models.ContentNode{
+		newSection("Personal Information"),
+	}}
+	titleForm := &models.ContentNode{ElementType: models.FormElementType, Metadata: map[string]string{}, Children: []*models.ContentNode{
+		{ElementType: models.TitleElementType, Value: "Intake"},
+	}}
+
+	// Act
+	fromSection := renderer.collectMetadata(sectionForm, nil, renderer.collectSections(sectionForm))
+	fromTitle := renderer.collectMetadata(titleForm, nil, nil)
+
+	// Assert
+	assert.Equal(t, "Personal Information", fromSection.Title)
+	assert.Equal(t, "Intake", fromTitle.Title)
+	assert.Empty(t, fromTitle.Properties)
+}
+
+func TestSubmissionHash_IgnoresKeyOrder(t *testing.T) {
+	first := submissionHash(&models.ContentSubmission{"a": "1", "b": "2"})
+	second := submissionHash(&models.ContentSubmission{"b": "2", "a": "1"})
+
+	assert.Equal(t, first, second)
+	assert.Len(t, first, 64)
+}
+
+func TestRender_CustomProperties(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Name: "intake", Metadata: map[string]string{"Name": "intake"}, Children: []*models.ContentNode{
+		newSelectField("language", "A", "B"),
+	}}
+	options := config.RenderOptions{Metadata: config.MetadataOptions{Properties: map[string]string{"department": "Sales & <HR>"}}}
+
+	// Act
+	content := string(renderToBytes(t, options, root, &models.ContentSubmission{"language": "A"}))
+
+	// Assert
+	assert.Contains(t, content, "/FormID (intake)")
+	assert.Contains(t, content, "/department (Sales & <HR>)")
+	assert.Contains(t, content, "<pdfx:department>Sales &amp; &lt;HR&gt;</pdfx:department>")
+	assert.Regexp(t, `/Metadata \d+ 0 R`, content)
+}
+
+func TestRender_InvalidPropertyName(t *testing.T) {
+	// Arrange
+	options := config.RenderOptions{Metadata: config.MetadataOptions{Properties: map[string]string{"not valid": "x"}}}
+	renderer := NewPDFRenderer("output.pdf", t.TempDir(), options)
+
+	// Act
+	err := renderer.Render(&models.ContentNode{ElementType: models.FormElementType}, &models.ContentSubmission{})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestRender_PropertiesOfProtectedOutput(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Name: "job-application"}
+	options := config.RenderOptions{
+		Protection: config.ProtectionOptions{UserPassword: "user"},
+		Metadata:   config.MetadataOptions{Properties: map[string]string{"department": "hr"}},
+	}
+
+	// Act
+	content := renderToBytes(t, options, root, &models.ContentSubmission{})
+
+	// Assert - the encrypted XMP metadata is linked from the catalog, the properties are not in clear text
+	update, err := revision.New(content)
+	require.NoError(t, err)
+	assert.True(t, update.Encrypted())
+	catalog, err := update.Dictionary(update.Root())
+	require.NoError(t, err)
+	assert.Contains(t, string(catalog), fmt.Sprintf("/Metadata %d 0 R", update.Info()-1))
+	assert.NotContains(t, string(content), "job-application")
+	assert.NotContains(t, string(content), "/department")
+}
+
+func TestRender_RedactedOutputHasNoSubmissionHash(t *testing.T) {
+	// Arrange
+	field := newSelectField("language", "A", "B")
+	field.Metadata["Sensitive"] = "True"
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{field}}
+	submission := &models.ContentSubmission{"language": "A"}
+	restricted, err := config.ReadRedactionProfile("restricted")
+	require.NoError(t, err)
+
+	// Act
+	internal := string(renderToBytes(t, config.RenderOptions{}, root, submission))
+	redacted := string(renderToBytes(t, config.RenderOptions{Redaction: restricted}, root, submission))
+
+	// Assert
+	assert.Contains(t, internal, "/SubmissionSHA256 ("+submissionHash(submission)+")")
+	assert.NotContains(t, redacted, "SubmissionSHA256")
+	assert.NotContains(t, redacted, submissionHash(submission))
+}
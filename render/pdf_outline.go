@@ -0,0 +1,117 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var tocTitleTextValue = "Table of contents"
+var tocIndent float64 = 6
+var tocPageNumberWidth float64 = 15
+
+// sectionEntry - a section as it appears in the PDF outline and the table of contents
+type sectionEntry struct {
+	Number string
+	Title  string
+	Depth  int
+
+	node      *models.ContentNode
+	link      int
+	pageAlias string
+}
+
+// Text - the numbered title shown in the outline and the table of contents. E.g. 2.1. Country and Region
+func (e *sectionEntry) Text() string {
+	if e.Number == "" {
+		return e.Title
+	}
+	return fmt.Sprintf("%s %s", e.Number, e.Title)
+}
+
+// collectSections - walk the graph and number the sections in document order
+func (r *PDFRenderer) collectSections(root *models.ContentNode) []*sectionEntry {
+	/* Nested sections are not direct children of their parent section, they live inside <Contents>.
+	   Every section starts a new counter for the sections found anywhere below it.
+
+	   <Section> 1.
+	      <Contents>
+	          <Section> 1.1. </Section>
+	          <Section> 1.2. </Section>
+	      </Contents>
+	   </Section>
+	   <Section> 2. </Section>
+	*/
+	var entries []*sectionEntry
+
+	// path holds the index of the section on every level of nesting, counter is the index on the current level
+	var visit func(node *models.ContentNode, path []int, counter *int)
+	visit = func(node *models.ContentNode, path []int, counter *int) {
+		if node.ElementType == models.SectionElementType {
+			*counter++
+			path = append(path[:len(path):len(path)], *counter)
+
+			entry := &sectionEntry{
+				Number: formatSectionNumber(r.Options.SectionNumbering, path),
+				Title:  r.findTitle(node),
+				Depth:  len(path) - 1,
+				node:   node,
+			}
+			if entry.Title == "" {
+				entry.Title = node.Name
+			}
+			entries = append(entries, entry)
+
+			counter = new(int)
+		}
+
+		for _, child := range node.Children {
+			visit(child, path, counter)
+		}
+	}
+
+	if root != nil {
+		visit(root, nil, new(int))
+	}
+
+	return entries
+}
+
+// renderTableOfContents - renders the section list on the first page, the content starts on the next one
+func (r *PDFRenderer) renderTableOfContents(entries []*sectionEntry) {
+	r.useBoldFont(titleFontSize)
+	r.writeCellLn(titleLineHeight, titleCellHeight, tocTitleTextValue)
+	r.useNormalFont(defaultFontSize)
+
+	pageWidth, _ := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+
+	for i, entry := range entries {
+		// The page numbers are not known yet - use an alias that gets replaced when the section is rendered
+		entry.link = r.pdf.AddLink()
+		entry.pageAlias = "{p" + strconv.Itoa(i+1) + "}"
+
+		indent := float64(entry.Depth) * tocIndent
+		r.pdf.SetX(left + indent)
+		r.pdf.CellFormat(pageWidth-left-right-indent-tocPageNumberWidth, 8, entry.Text(), "", 0, "L", false, entry.link, "")
+		r.pdf.CellFormat(tocPageNumberWidth, 8, entry.pageAlias, "", 1, "L", false, entry.link, "")
+	}
+
+	r.pdf.AddPage()
+}
+
+// bookmarkSection - adds the section to the PDF outline and resolves its table of contents link and page number
+func (r *PDFRenderer) bookmarkSection(node *models.ContentNode) {
+	entry, ok := r.sections[node]
+	if !ok {
+		return
+	}
+
+	r.pdf.Bookmark(entry.Text(), entry.Depth, -1)
+
+	if r.Options.TableOfContents {
+		r.pdf.SetLink(entry.link, -1, -1)
+		r.pdf.RegisterAlias(entry.pageAlias, strconv.Itoa(r.pdf.PageNo()))
+	}
+}
@@ -0,0 +1,93 @@
+package render
+
+import (
+	"os"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parsePayload(t *testing.T, path string) *models.ContentNode {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	root, err := (&parsers.XMLParser{}).Parse(content)
+	require.NoError(t, err)
+
+	return root
+}
+
+func TestCollectSections_NestedNumbering(t *testing.T) {
+	// Arrange
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{})
+	root := parsePayload(t, "../tests/payload/complex_valid_xml")
+
+	// Act
+	entries := renderer.collectSections(root)
+
+	// Assert
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "1.", entries[0].Number)
+	assert.Equal(t, "Personal Information", entries[0].Title)
+	assert.Equal(t, 0, entries[0].Depth)
+
+	assert.Equal(t, "2.", entries[1].Number)
+	assert.Equal(t, "Address Details", entries[1].Title)
+	assert.Equal(t, 0, entries[1].Depth)
+
+	assert.Equal(t, "2.1.", entries[2].Number)
+	assert.Equal(t, "2.1. Country and Region", entries[2].Text())
+	assert.Equal(t, 1, entries[2].Depth)
+}
+
+func TestCollectSections_MissingTitleUsesName(t *testing.T) {
+	// Arrange
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{})
+	root := &models.ContentNode{
+		ElementType: models.FormElementType,
+		Children: []*models.ContentNode{
+			{ElementType: models.SectionElementType, Name: "experience"},
+		},
+	}
+
+	// Act
+	entries := renderer.collectSections(root)
+
+	// Assert
+	require.Len(t, entries, 1)
+	assert.Equal(t, "1. experience", entries[0].Text())
+}
+
+func TestCollectSections_AlphaRomanNumbering(t *testing.T) {
+	// Arrange
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{SectionNumbering: models.AlphaRomanNumberingScheme})
+	root := parsePayload(t, "../tests/payload/complex_valid_xml")
+
+	// Act
+	entries := renderer.collectSections(root)
+
+	// Assert
+	require.Len(t, entries, 3)
+	assert.Equal(t, "A.", entries[0].Number)
+	assert.Equal(t, "B.", entries[1].Number)
+	assert.Equal(t, "B.i.", entries[2].Number)
+}
+
+func TestCollectSections_NoNumbering(t *testing.T) {
+	// Arrange
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{SectionNumbering: models.NoNumberingScheme})
+	root := parsePayload(t, "../tests/payload/complex_valid_xml")
+
+	// Act
+	entries := renderer.collectSections(root)
+
+	// Assert
+	require.Len(t, entries, 3)
+	assert.Equal(t, "Country and Region", entries[2].Text())
+	assert.Equal(t, 1, entries[2].Depth)
+}
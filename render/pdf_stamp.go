@@ -0,0 +1,201 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/barcode"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var defaultStampSize float64 = 20
+var stampGap float64 = 3
+var barcodeModuleWidth float64 = 0.25
+var barcodeTextFontSize float64 = 6
+var barcodeTextHeight float64 = 3
+var submissionIDLength = 12
+
+/* The tracking code is stamped in a corner of every page, so the mailroom can match a scanned page to its submission.
+   The code holds the submission ID, the form ID and version and the page number - see models.TrackingCode.
+
+   The space of the code is reserved when the document is created: the top margin (or the bottom page break margin)
+   is moved so the content never runs under the code. The codes are drawn after the content, when the number of
+   pages is known, by going back to every page.
+*/
+
+// stampSize - the size of the code in mm
+func (r *PDFRenderer) stampSize() float64 {
+	if r.Options.Stamp.Size > 0 {
+		return r.Options.Stamp.Size
+	}
+	return defaultStampSize
+}
+
+// stampHeight - the height taken by the code, with the readable text under a barcode
+func (r *PDFRenderer) stampHeight() float64 {
+	if r.Options.Stamp.Type == models.Code128StampType {
+		return r.stampSize() + barcodeTextHeight
+	}
+	return r.stampSize()
+}
+
+// reserveStampSpace - moves the margins so the content leaves room for the code. Called before the first page is added.
+func (r *PDFRenderer) reserveStampSpace() {
+	if !r.Options.Stamp.Enabled() {
+		return
+	}
+
+	_, top, _, _ := r.pdf.GetMargins()
+	reserved := top + r.stampHeight() + stampGap
+
+	if r.Options.Stamp.Position.IsTop() {
+		r.pdf.SetTopMargin(reserved)
+		return
+	}
+
+	_, bottom := r.pdf.GetAutoPageBreak()
+	r.pdf.SetAutoPageBreak(true, max(bottom, reserved))
+}
+
+// trackingCode - the tracking code of the submission, without the page
+func (r *PDFRenderer) trackingCode(form *models.ContentNode, submission *models.ContentSubmission) models.TrackingCode {
+	code := models.TrackingCode{
+		FormID:      form.Name,
+		FormVersion: form.Metadata["Version"],
+	}
+
+	code.SubmissionID = r.submissionID(submission)
+
+	return code
+}
+
+// submissionID - the submitted value of the ID field, or the start of the submission hash. Empty for a blank form.
+func (r *PDFRenderer) submissionID(submission *models.ContentSubmission) string {
+	if submission == nil {
+		return ""
+	}
+
+	if field := r.Options.Stamp.SubmissionIDField; field != "" {
+		if id := strings.TrimSpace(getSubmittedValue(submission, field)); id != "" {
+			return id
+		}
+	}
+	// Render passes the redacted answers, the hidden ones do not go into the ID
+	return submissionHash(submission)[:submissionIDLength]
+}
+
+// applyStamps - draws the tracking code on every page. Called after the content is rendered.
+func (r *PDFRenderer) applyStamps(form *models.ContentNode, submission *models.ContentSubmission) error {
+	if !r.Options.Stamp.Enabled() {
+		return nil
+	}
+
+	code := r.trackingCode(form, submission)
+	code.Pages = r.pdf.PageCount()
+
+	for page := 1; page <= code.Pages; page++ {
+		code.Page = page
+		r.pdf.SetPage(page)
+
+		var err error
+		switch r.Options.Stamp.Type {
+		case models.QRStampType:
+			err = r.drawQRCode(code.String())
+		case models.Code128StampType:
+			err = r.drawBarcode(code.String())
+		}
+		if err != nil {
+			return fmt.Errorf("page %d: %w", page, err)
+		}
+	}
+
+	// The last page is closed when the document is written
+	r.pdf.SetPage(code.Pages)
+	r.useNormalColor()
+
+	return nil
+}
+
+// stampOrigin - the top left corner of a code of the given width
+func (r *PDFRenderer) stampOrigin(width float64) (float64, float64) {
+	pageWidth, pageHeight := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+
+	x := left
+	if !r.Options.Stamp.Position.IsLeft() {
+		x = pageWidth - right - width
+	}
+
+	// The page margin is used on both sides - the reserved space is below (or above) the code
+	margin := left
+	y := margin
+	if !r.Options.Stamp.Position.IsTop() {
+		y = pageHeight - margin - r.stampHeight()
+	}
+
+	return x, y
+}
+
+// drawQRCode - draws the QR code of the text as black squares
+func (r *PDFRenderer) drawQRCode(text string) error {
+	qr, err := barcode.EncodeQR([]byte(text))
+	if err != nil {
+		return err
+	}
+
+	size := r.stampSize()
+	module := size / float64(qr.Size)
+	x, y := r.stampOrigin(size)
+
+	r.pdf.SetFillColor(0, 0, 0)
+	for row, modules := range qr.Modules {
+		// Dark modules next to each other are drawn as one rectangle
+		for column := 0; column < len(modules); {
+			if !modules[column] {
+				column++
+				continue
+			}
+			start := column
+			for column < len(modules) && modules[column] {
+				column++
+			}
+			r.pdf.Rect(x+float64(start)*module, y+float64(row)*module, float64(column-start)*module, module, "F")
+		}
+	}
+
+	return nil
+}
+
+// drawBarcode - draws the Code 128 barcode of the text, with the text under it
+func (r *PDFRenderer) drawBarcode(text string) error {
+	code, err := barcode.EncodeCode128(text)
+	if err != nil {
+		return err
+	}
+
+	// Narrower bars when the barcode does not fit between the margins
+	pageWidth, _ := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+	module := min(barcodeModuleWidth, (pageWidth-left-right)/float64(code.Modules()))
+
+	width := float64(code.Modules()) * module
+	height := r.stampSize()
+	x, y := r.stampOrigin(width)
+
+	r.pdf.SetFillColor(0, 0, 0)
+	// The widths alternate bar and space, starting with a bar
+	bar := x
+	for i, bars := range code.Widths {
+		if i%2 == 0 {
+			r.pdf.Rect(bar, y, float64(bars)*module, height, "F")
+		}
+		bar += float64(bars) * module
+	}
+
+	r.pdf.SetFont(font, "", barcodeTextFontSize)
+	r.pdf.SetTextColor(0, 0, 0)
+	textWidth := r.pdf.GetStringWidth(text)
+	r.pdf.Text(x+width/2-textWidth/2, y+height+barcodeTextHeight-0.5, text)
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/barcode"
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var filledRectanglePattern = regexp.MustCompile(`([\d.]+) ([\d.]+) ([\d.]+) (-?[\d.]+) re f`)
+
+// stampPages - an uncompressed document with the given number of empty pages, stamped with the tracking code
+func stampPages(t *testing.T, options config.StampOptions, form *models.ContentNode, pages int) []byte {
+	renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{Stamp: options})
+	renderer.newDocument()
+	renderer.pdf.SetCompression(false)
+	for page := 1; page < pages; page++ {
+		renderer.pdf.AddPage()
+	}
+
+	require.NoError(t, renderer.applyStamps(form, &models.ContentSubmission{"id": "A-1042"}))
+
+	var buffer bytes.Buffer
+	require.NoError(t, renderer.pdf.Output(&buffer))
+	return buffer.Bytes()
+}
+
+// rasterize - draws the filled rectangles of the document in an image, 4 pixels per point, cropped to the rectangles
+func rasterize(document []byte) image.Image {
+	width, height := 595, 842
+	img := image.NewGray(image.Rect(0, 0, width*4, height*4))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+
+	var drawn image.Rectangle
+	for _, match := range filledRectanglePattern.FindAllSubmatch(document, -1) {
+		var values [4]float64
+		for i := range values {
+			values[i], _ = strconv.ParseFloat(string(match[i+1]), 64)
+		}
+		// PDF coordinates start at the bottom left corner and the height is negative
+		x0, y0 := int(values[0]*4+0.5), int((float64(height)-values[1])*4+0.5)
+		x1, y1 := int((values[0]+values[2])*4+0.5), int((float64(height)-values[1]-values[3])*4+0.5)
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				img.SetGray(x, y, color.Gray{})
+			}
+		}
+		drawn = drawn.Union(image.Rect(x0, y0, x1, y1))
+	}
+	return img.SubImage(drawn.Inset(-40))
+}
+
+func TestTrackingCode(t *testing.T) {
+	form := &models.ContentNode{ElementType: models.FormElementType, Name: "job_application", Metadata: map[string]string{"Version": "2"}}
+	submission := &models.ContentSubmission{"id": " A-1042 "}
+
+	tests := []struct {
+		name       string
+		field      string
+		submission *models.ContentSubmission
+		expected   string
+	}{
+		{"id field", "id", submission, "A-1042"},
+		{"missing id field", "reference", submission, submissionHash(submission)[:submissionIDLength]},
+		{"submission hash", "", submission, submissionHash(submission)[:submissionIDLength]},
+		{"no submission", "id", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{Stamp: config.StampOptions{SubmissionIDField: tt.field}})
+
+			code := renderer.trackingCode(form, tt.submission)
+
+			assert.Equal(t, tt.expected, code.SubmissionID)
+			assert.Equal(t, "job_application", code.FormID)
+			assert.Equal(t, "2", code.FormVersion)
+		})
+	}
+}
+
+func TestReserveStampSpace(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  config.StampOptions
+		top      float64
+		bottom   float64
+		expected string
+	}{
+		{"no stamp", config.StampOptions{Type: models.NoStampType, Position: models.TopRightStampPosition}, 10, 20, ""},
+		{"top qr", config.StampOptions{Type: models.QRStampType, Position: models.TopLeftStampPosition, Size: 20}, 33, 20, ""},
+		{"bottom qr", config.StampOptions{Type: models.QRStampType, Position: models.BottomRightStampPosition, Size: 25}, 10, 38, ""},
+		{"top barcode", config.StampOptions{Type: models.Code128StampType, Position: models.TopRightStampPosition, Size: 10}, 26, 20, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{Stamp: tt.options})
+
+			renderer.newDocument()
+
+			_, top, _, _ := renderer.pdf.GetMargins()
+			_, bottom := renderer.pdf.GetAutoPageBreak()
+			assert.InDelta(t, tt.top, top, 0.01)
+			assert.InDelta(t, tt.bottom, bottom, 0.01)
+		})
+	}
+}
+
+func TestApplyStamps_QRCodeRoundTrip(t *testing.T) {
+	// Arrange
+	form := &models.ContentNode{ElementType: models.FormElementType, Name: "job_application", Metadata: map[string]string{"Version": "2"}}
+	options := config.StampOptions{Type: models.QRStampType, Position: models.BottomLeftStampPosition, Size: 30, SubmissionIDField: "id"}
+
+	// Act
+	document := stampPages(t, options, form, 1)
+	text, err := barcode.DecodeQRImage(rasterize(document))
+	require.NoError(t, err)
+	code, err := models.ParseTrackingCode(string(text))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.TrackingCode{SubmissionID: "A-1042", FormID: "job_application", FormVersion: "2", Page: 1, Pages: 1}, code)
+}
+
+func TestApplyStamps_BarcodeRoundTrip(t *testing.T) {
+	// Arrange
+	form := &models.ContentNode{ElementType: models.FormElementType, Name: "job_application", Metadata: map[string]string{"Version": "2"}}
+	options := config.StampOptions{Type: models.Code128StampType, Position: models.TopRightStampPosition, Size: 15, SubmissionIDField: "id"}
+
+	// Act
+	document := stampPages(t, options, form, 1)
+	text, err := barcode.DecodeCode128Image(rasterize(document))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "sub=A-1042;form=job_application;ver=2;page=1/1", text)
+	assert.Contains(t, string(document), "(sub=A-1042;form=job_application;ver=2;page=1/1) Tj")
+}
+
+func TestApplyStamps_EveryPage(t *testing.T) {
+	// Arrange
+	form := &models.ContentNode{ElementType: models.FormElementType, Name: "job_application"}
+	options := config.StampOptions{Type: models.Code128StampType, Position: models.BottomRightStampPosition, SubmissionIDField: "id"}
+
+	// Act
+	document := string(stampPages(t, options, form, 3))
+
+	// Assert
+	assert.Contains(t, document, "page=1/3")
+	assert.Contains(t, document, "page=2/3")
+	assert.Contains(t, document, "page=3/3")
+}
+
+func TestRender_StampTooLong(t *testing.T) {
+	// Arrange
+	form := &models.ContentNode{ElementType: models.FormElementType, Name: string(bytes.Repeat([]byte("x"), 300))}
+	renderer := NewPDFRenderer("output.pdf", t.TempDir(), config.RenderOptions{Stamp: config.StampOptions{Type: models.QRStampType, Position: models.TopRightStampPosition}})
+
+	// Act
+	err := renderer.Render(form, &models.ContentSubmission{})
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrDataTooLong)
+}
@@ -0,0 +1,104 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var summaryTitleTextValue = "Submission summary"
+var missingRequiredTitleTextValue = "Missing required fields"
+var summaryTimeFormat = "2006-01-02 15:04:05 MST"
+var summaryLabelWidth float64 = 45
+
+// fieldLink - where a field listed on the summary page is rendered
+type fieldLink struct {
+	link      int
+	pageAlias string
+}
+
+/* The summary page is the first page of the document, so reviewers see the completion at a glance:
+   * the form title, the submission ID (the same as in the tracking code) and the time the document was generated
+   * the number of answered, missing required and missing optional fields
+   * the missing required fields, linked to where they appear
+
+   The pages of the missing fields are not known yet, so aliases are used - resolved when the fields are rendered.
+*/
+
+// renderSummary - renders the summary page, the rest of the document starts on the next one
+func (r *PDFRenderer) renderSummary(submission *models.ContentSubmission) {
+	if r.Options.Blank {
+		logging.Log.Info("(skip)Summary page of a blank form")
+		return
+	}
+
+	r.pdf.Bookmark(summaryTitleTextValue, 0, -1)
+
+	r.useBoldFont(titleFontSize)
+	r.writeCellLn(titleLineHeight, titleCellHeight, summaryTitleTextValue)
+
+	total := len(r.completion.answered) + len(r.completion.missingRequired) + len(r.completion.missingOptional)
+	r.writeSummaryLine("Form", r.metadata.Title)
+	r.writeSummaryLine("Submission ID", r.submissionID(submission))
+	r.writeSummaryLine("Generated", r.createdAt.Format(summaryTimeFormat))
+	r.pdf.Ln(answerSpacing)
+	r.writeSummaryLine("Answered", fmt.Sprintf("%d of %d", len(r.completion.answered), total))
+	r.writeSummaryLine("Missing required", strconv.Itoa(len(r.completion.missingRequired)))
+	r.writeSummaryLine("Missing optional", strconv.Itoa(len(r.completion.missingOptional)))
+
+	if len(r.completion.missingRequired) > 0 {
+		r.pdf.Ln(answerSpacing)
+		r.useBoldFont(defaultFontSize)
+		r.writeCellLn(captionLineHeight, captionLineHeight, missingRequiredTitleTextValue)
+		r.renderMissingRequired()
+	}
+
+	r.useNormalFont(defaultFontSize)
+	r.pdf.AddPage()
+}
+
+// renderMissingRequired - lists the missing required fields with a link and the page number of each
+func (r *PDFRenderer) renderMissingRequired() {
+	r.useNormalFont(defaultFontSize)
+	pageWidth, _ := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+
+	for i, node := range r.completion.missingRequired {
+		text := fmt.Sprintf("%s (%s)", findCaption(node), node.Name)
+
+		// A hidden field is not in the document - there is nothing to link to
+		if r.isHidden(node) {
+			r.writeCellLn(answerLineHeight, answerLineHeight, text)
+			continue
+		}
+
+		link := &fieldLink{link: r.pdf.AddLink(), pageAlias: "{m" + strconv.Itoa(i+1) + "}"}
+		r.fieldLinks[node] = link
+
+		r.pdf.SetTextColor(0, 0, 200)
+		r.pdf.CellFormat(pageWidth-left-right-tocPageNumberWidth, answerLineHeight, text, "", 0, "L", false, link.link, "")
+		r.pdf.SetTextColor(0, 0, 0)
+		r.pdf.CellFormat(tocPageNumberWidth, answerLineHeight, link.pageAlias, "", 1, "L", false, link.link, "")
+	}
+}
+
+// writeSummaryLine - a bold label followed by its value
+func (r *PDFRenderer) writeSummaryLine(label, value string) {
+	r.useBoldFont(defaultFontSize)
+	r.pdf.CellFormat(summaryLabelWidth, answerLineHeight, label+":", "", 0, "L", false, 0, "")
+	r.useNormalFont(defaultFontSize)
+	r.writeCellLn(answerLineHeight, answerLineHeight, value)
+}
+
+// linkField - resolves the summary link and page number of the field, if it is listed on the summary page
+func (r *PDFRenderer) linkField(node *models.ContentNode) {
+	link, ok := r.fieldLinks[node]
+	if !ok {
+		return
+	}
+
+	r.pdf.SetLink(link.link, -1, -1)
+	r.pdf.RegisterAlias(link.pageAlias, strconv.Itoa(r.pdf.PageNo()))
+}
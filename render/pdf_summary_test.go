@@ -0,0 +1,86 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderSummaryDocument - an uncompressed document with the summary page and the fields of the form
+func renderSummaryDocument(t *testing.T, options config.RenderOptions, root *models.ContentNode, submission *models.ContentSubmission) (*PDFRenderer, string) {
+	options.Summary = true
+	options.Reproducible = true
+	options.SourceDate = time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	renderer := NewPDFRenderer("output.pdf", "output", options)
+	renderer.newDocument()
+	renderer.pdf.SetCompression(false)
+	renderer.completion = checkCompletion(root, submission)
+	renderer.metadata = &documentMetadata{Title: "Job application"}
+
+	renderer.renderSummary(submission)
+	require.NoError(t, renderer.renderNode(root, submission, &renderScope{fields: new(int)}))
+
+	var buffer bytes.Buffer
+	require.NoError(t, renderer.pdf.Output(&buffer))
+	return renderer, buffer.String()
+}
+
+func TestRenderSummary(t *testing.T) {
+	// Arrange
+	street := withOptional(newTextField("street", "Text([0,200])"), "False")
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		withOptional(newSelectField("language", "A", "B"), "False"),
+		newFileField("code_repos"),
+		street,
+	}}
+	submission := &models.ContentSubmission{"language": "A", "reference": "A-1042"}
+	options := config.RenderOptions{Stamp: config.StampOptions{SubmissionIDField: "reference"}}
+
+	// Act
+	renderer, document := renderSummaryDocument(t, options, root, submission)
+
+	// Assert
+	assert.Equal(t, 2, renderer.pdf.PageCount())
+	assert.Contains(t, document, "(Job application)")
+	assert.Contains(t, document, "(A-1042)")
+	assert.Contains(t, document, "(2024-05-01 10:30:00 UTC)")
+	assert.Contains(t, document, "(1 of 3)")
+	assert.Contains(t, document, "(Street Address \\(street\\))")
+	assert.Contains(t, document, "/Subtype /Link")
+
+	// The page number alias is replaced when the field is rendered
+	require.Contains(t, renderer.fieldLinks, street)
+	assert.NotContains(t, document, renderer.fieldLinks[street].pageAlias)
+}
+
+func TestRenderSummary_HiddenFieldNotLinked(t *testing.T) {
+	// Arrange
+	street := withSensitive(withOptional(newTextField("street", "Text([0,200])"), "False"))
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{street}}
+	options := config.RenderOptions{Redaction: config.RedactionProfile{Action: models.HideRedactionAction}}
+
+	// Act
+	renderer, document := renderSummaryDocument(t, options, root, &models.ContentSubmission{})
+
+	// Assert
+	assert.NotContains(t, renderer.fieldLinks, street)
+	assert.Contains(t, document, "(Street Address \\(street\\))")
+}
+
+func TestRenderSummary_SkippedForBlankForm(t *testing.T) {
+	// Arrange
+	renderer := newBlankTestRenderer()
+	renderer.completion = &completion{}
+
+	// Act
+	renderer.renderSummary(nil)
+
+	// Assert
+	assert.Equal(t, 1, renderer.pdf.PageCount())
+}
@@ -0,0 +1,150 @@
+package render
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alex-pricope/form-parser/config"
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderToBytes(t *testing.T, options config.RenderOptions, root *models.ContentNode, submission *models.ContentSubmission) []byte {
+	dir := t.TempDir()
+	renderer := NewPDFRenderer(filepath.Join(dir, "form.xml"), dir, options)
+
+	require.NoError(t, renderer.Render(root, submission))
+
+	content, err := os.ReadFile(filepath.Join(dir, "form.pdf"))
+	require.NoError(t, err)
+	return content
+}
+
+func TestRender_PasswordProtection(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{newSelectField("language", "A", "B")}}
+	options := config.RenderOptions{
+		Protection: config.ProtectionOptions{OwnerPassword: "owner", UserPassword: "user", AllowPrint: true},
+	}
+
+	// Act
+	content := renderToBytes(t, options, root, &models.ContentSubmission{"language": "A"})
+
+	// Assert
+	assert.Contains(t, string(content), "/Encrypt")
+}
+
+func TestRender_NoPasswordNoProtection(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{newSelectField("language", "A", "B")}}
+
+	// Act
+	content := renderToBytes(t, config.RenderOptions{Protection: config.ProtectionOptions{AllowCopy: true}}, root, &models.ContentSubmission{})
+
+	// Assert
+	assert.NotContains(t, string(content), "/Encrypt")
+}
+
+// writeSigningFiles - a self-signed certificate and its key as PEM files
+func writeSigningFiles(t *testing.T) config.SigningOptions {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Reviewer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	keyContent, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	options := config.SigningOptions{CertificatePath: filepath.Join(dir, "cert.pem"), KeyPath: filepath.Join(dir, "key.pem")}
+	require.NoError(t, os.WriteFile(options.CertificatePath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600))
+	require.NoError(t, os.WriteFile(options.KeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyContent}), 0600))
+	return options
+}
+
+func TestRender_SignedOutput(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{newSelectField("language", "A", "B")}}
+
+	signing := writeSigningFiles(t)
+	certificate, err := os.ReadFile(signing.CertificatePath)
+	require.NoError(t, err)
+	roots, err := signature.ParseTrustedCertificates(certificate)
+	require.NoError(t, err)
+
+	// Act
+	content := renderToBytes(t, config.RenderOptions{Signing: signing}, root, &models.ContentSubmission{"language": "A"})
+	verification, err := signature.Verify(content, roots)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "CN=Reviewer", verification.Signer)
+}
+
+func TestRender_SignProtectedOutput(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	options := config.RenderOptions{
+		Protection: config.ProtectionOptions{UserPassword: "user"},
+		Signing:    writeSigningFiles(t),
+	}
+	renderer := NewPDFRenderer(filepath.Join(dir, "form.xml"), dir, options)
+
+	// Act
+	err := renderer.Render(&models.ContentNode{ElementType: models.FormElementType}, &models.ContentSubmission{})
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrSignProtectedFile)
+	assert.NoFileExists(t, filepath.Join(dir, "form.pdf"))
+}
+
+func TestRender_ReproducibleProtectionNeedsOwnerPassword(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	options := config.RenderOptions{
+		Protection:   config.ProtectionOptions{UserPassword: "user"},
+		Reproducible: true,
+	}
+	renderer := NewPDFRenderer(filepath.Join(dir, "form.xml"), dir, options)
+
+	// Act
+	err := renderer.Render(&models.ContentNode{ElementType: models.FormElementType}, &models.ContentSubmission{})
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrReproducibleOwnerPassword)
+}
+
+func TestRender_ReproducibleSignedOutput(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{newSelectField("language", "A", "B")}}
+	options := config.RenderOptions{
+		Signing:      writeSigningFiles(t),
+		Reproducible: true,
+		SourceDate:   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	// Act
+	first := renderToBytes(t, options, root, &models.ContentSubmission{"language": "A"})
+	second := renderToBytes(t, options, root, &models.ContentSubmission{"language": "A"})
+
+	// Assert
+	assert.Equal(t, first, second)
+	assert.Contains(t, string(first), "/CreationDate (D:20240501100000)")
+}
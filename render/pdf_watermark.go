@@ -0,0 +1,65 @@
+package render
+
+import (
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var draftWatermarkText, incompleteWatermarkText = "DRAFT", "INCOMPLETE"
+var watermarkFontSize float64 = 80
+var watermarkAngle float64 = 45
+var defaultWatermarkOpacity = 0.15
+
+// watermarkText - the text printed across every page, or empty when the output gets no watermark
+func (r *PDFRenderer) watermarkText() string {
+	watermark := r.Options.Watermark
+
+	switch watermark.Policy {
+	case models.AlwaysWatermarkPolicy:
+	case models.OnInvalidWatermarkPolicy:
+		if r.completion.complete() {
+			return ""
+		}
+	default:
+		return ""
+	}
+
+	if watermark.Text != "" {
+		return watermark.Text
+	}
+	if !r.completion.complete() {
+		return incompleteWatermarkText
+	}
+	return draftWatermarkText
+}
+
+// applyWatermark - prints the watermark when every page is closed, on top of the content
+func (r *PDFRenderer) applyWatermark() {
+	text := r.watermarkText()
+	if text == "" {
+		return
+	}
+
+	opacity := r.Options.Watermark.Opacity
+	if opacity <= 0 || opacity > 1 {
+		opacity = defaultWatermarkOpacity
+	}
+
+	r.pdf.SetFooterFunc(func() {
+		// The page restores the font and colors of the content when the next one is added
+		width, height := r.pdf.GetPageSize()
+
+		r.pdf.SetFont(font, "B", watermarkFontSize)
+		r.pdf.SetTextColor(200, 0, 0)
+		r.pdf.SetAlpha(opacity, "Normal")
+
+		r.pdf.TransformBegin()
+		r.pdf.TransformRotate(watermarkAngle, width/2, height/2)
+		textWidth := r.pdf.GetStringWidth(text)
+		_, fontHeight := r.pdf.GetFontSize()
+		r.pdf.Text(width/2-textWidth/2, height/2+fontHeight/3, text)
+		r.pdf.TransformEnd()
+
+		r.pdf.SetAlpha(1, "Normal")
+		r.pdf.SetTextColor(0, 0, 0)
+	})
+}
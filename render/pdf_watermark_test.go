@@ -0,0 +1,54 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatermarkText(t *testing.T) {
+	incomplete := &completion{missingRequired: []*models.ContentNode{{}}}
+	complete := &completion{}
+
+	tests := []struct {
+		name       string
+		options    config.WatermarkOptions
+		completion *completion
+		expected   string
+	}{
+		{"always complete", config.WatermarkOptions{Policy: models.AlwaysWatermarkPolicy}, complete, draftWatermarkText},
+		{"always incomplete", config.WatermarkOptions{Policy: models.AlwaysWatermarkPolicy}, incomplete, incompleteWatermarkText},
+		{"on-invalid complete", config.WatermarkOptions{Policy: models.OnInvalidWatermarkPolicy}, complete, ""},
+		{"on-invalid incomplete", config.WatermarkOptions{Policy: models.OnInvalidWatermarkPolicy}, incomplete, incompleteWatermarkText},
+		{"never", config.WatermarkOptions{Policy: models.NeverWatermarkPolicy}, incomplete, ""},
+		{"not set", config.WatermarkOptions{}, incomplete, ""},
+		{"custom text", config.WatermarkOptions{Policy: models.AlwaysWatermarkPolicy, Text: "COPY"}, complete, "COPY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := NewPDFRenderer("output.pdf", "output", config.RenderOptions{Watermark: tt.options})
+			renderer.completion = tt.completion
+
+			assert.Equal(t, tt.expected, renderer.watermarkText())
+		})
+	}
+}
+
+func TestRender_WatermarkOnIncompleteSubmission(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		withOptional(newSelectField("language", "A", "B"), "False"),
+	}}
+	options := config.RenderOptions{Watermark: config.WatermarkOptions{Policy: models.OnInvalidWatermarkPolicy, Opacity: 0.3}}
+
+	// Act
+	incomplete := renderToBytes(t, options, root, &models.ContentSubmission{})
+	complete := renderToBytes(t, options, root, &models.ContentSubmission{"language": "A"})
+
+	// Assert - the transparency of the watermark is only in the incomplete output
+	assert.Contains(t, string(incomplete), "/ca 0.3")
+	assert.NotContains(t, string(complete), "/ca 0.3")
+}
@@ -0,0 +1,111 @@
+package render
+
+import (
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/diff"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var maskTextValue = "****"
+
+/* The sensitive answers are redacted before anything is rendered: the renderers only see a copy of the submission
+   where the answers are masked, replaced with a placeholder or removed. This way no renderer (and none of the
+   warnings written to the logs) can leak a sensitive answer by mistake.
+
+   The completion of the submission is still checked against the original answers - a hidden answer is not missing.
+*/
+
+// redactSubmission - copy of the submission with the answers of the sensitive fields redacted by the profile
+func redactSubmission(content *models.ContentNode, submission *models.ContentSubmission, profile config.RedactionProfile) *models.ContentSubmission {
+	if submission == nil || !profile.Enabled() {
+		return submission
+	}
+
+	redacted := make(models.ContentSubmission, len(*submission))
+	for name, value := range *submission {
+		redacted[name] = value
+	}
+
+	visitFields(content, func(field *models.ContentNode) {
+		value, ok := redacted[field.Name]
+		if !ok || !field.IsSensitive() {
+			return
+		}
+
+		if profile.Action == models.HideRedactionAction {
+			delete(redacted, field.Name)
+			return
+		}
+		redacted[field.Name] = redactValue(value, profile)
+	})
+
+	return &redacted
+}
+
+// redactChanges - the changes with the answers of the sensitive fields redacted. Hidden fields are left out.
+// The changes are found on the original answers, so a change stays visible even when both masks look the same.
+func redactChanges(changes []diff.FieldChange, profile config.RedactionProfile) []diff.FieldChange {
+	if !profile.Enabled() {
+		return changes
+	}
+
+	var redacted []diff.FieldChange
+	for _, change := range changes {
+		if change.Field.IsSensitive() {
+			if profile.Action == models.HideRedactionAction {
+				continue
+			}
+			change.Old = redactValue(change.Old, profile)
+			change.New = redactValue(change.New, profile)
+		}
+		redacted = append(redacted, change)
+	}
+	return redacted
+}
+
+// redactValue - the text shown instead of a sensitive answer
+func redactValue(value string, profile config.RedactionProfile) string {
+	// A missing answer stays missing
+	if value == "" {
+		return ""
+	}
+
+	switch profile.Action {
+	case models.MaskRedactionAction:
+		// Short answers are masked completely - the visible part would give away most of them
+		runes := []rune(value)
+		if len(runes) <= 2*profile.VisibleChars {
+			return maskTextValue
+		}
+		return maskTextValue + string(runes[len(runes)-profile.VisibleChars:])
+
+	case models.PlaceholderRedactionAction:
+		return profile.Placeholder
+
+	case models.HideRedactionAction:
+		return ""
+
+	default:
+		return value
+	}
+}
+
+// visitFields - calls visit for every field of the graph
+func visitFields(node *models.ContentNode, visit func(field *models.ContentNode)) {
+	if node.ElementType == models.FieldElementType {
+		visit(node)
+	}
+	for _, child := range node.Children {
+		visitFields(child, visit)
+	}
+}
+
+// isRedacted - the answer of the field is not shown as submitted. A blank form has no answers to redact.
+func (r *PDFRenderer) isRedacted(node *models.ContentNode) bool {
+	return r.Options.Redaction.Enabled() && node.IsSensitive() && !r.Options.Blank
+}
+
+// isHidden - the field is left out of the output
+func (r *PDFRenderer) isHidden(node *models.ContentNode) bool {
+	return r.isRedacted(node) && r.Options.Redaction.Action == models.HideRedactionAction
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withSensitive(field *models.ContentNode) *models.ContentNode {
+	field.Metadata["Sensitive"] = "True"
+	return field
+}
+
+func TestRedactValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  string
+		value    string
+		expected string
+	}{
+		{"mask", "external", "4111111111111234", "****1234"},
+		{"mask short", "external", "12345678", maskTextValue},
+		{"mask unicode", "external", "Zoë Straße 12", "****e 12"},
+		{"placeholder", "restricted", "4111111111111234", "[REDACTED]"},
+		{"hide", "public", "4111111111111234", ""},
+		{"none", "internal", "4111111111111234", "4111111111111234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := config.ReadRedactionProfile(tt.profile)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, redactValue(tt.value, profile))
+		})
+	}
+}
+
+func TestRedactSubmission(t *testing.T) {
+	// Arrange
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		newSection("Applicant", withSensitive(newFileField("passport")), newFileField("code_repos")),
+	}}
+	submission := &models.ContentSubmission{"passport": "passport-0042.png", "code_repos": "repo.zip"}
+
+	tests := []struct {
+		profile  string
+		expected models.ContentSubmission
+	}{
+		{"internal", models.ContentSubmission{"passport": "passport-0042.png", "code_repos": "repo.zip"}},
+		{"external", models.ContentSubmission{"passport": "****.png", "code_repos": "repo.zip"}},
+		{"restricted", models.ContentSubmission{"passport": "[REDACTED]", "code_repos": "repo.zip"}},
+		{"public", models.ContentSubmission{"code_repos": "repo.zip"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			profile, err := config.ReadRedactionProfile(tt.profile)
+			require.NoError(t, err)
+
+			redacted := redactSubmission(root, submission, profile)
+
+			assert.Equal(t, tt.expected, *redacted)
+		})
+	}
+
+	// The original answers are kept
+	assert.Equal(t, "passport-0042.png", (*submission)["passport"])
+}
+
+func TestRender_RedactedAttachmentNotEmbedded(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "passport.zip"), []byte("abc"), 0o600))
+	profile, err := config.ReadRedactionProfile("external")
+	require.NoError(t, err)
+	renderer := NewPDFRenderer(filepath.Join(dir, "form.xml"), dir, config.RenderOptions{AttachmentsDir: dir, Redaction: profile})
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{withSensitive(newFileField("passport"))}}
+
+	// Act
+	err = renderer.Render(root, &models.ContentSubmission{"passport": "passport.zip"})
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "form.pdf"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "/Type /EmbeddedFile")
+	assert.NotContains(t, string(content), "/FileAttachment")
+}
+
+func TestRender_HiddenFieldNotMissing(t *testing.T) {
+	// Arrange
+	profile, err := config.ReadRedactionProfile("public")
+	require.NoError(t, err)
+	renderer := NewPDFRenderer("output.pdf", t.TempDir(), config.RenderOptions{Redaction: profile})
+	root := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		withSensitive(withOptional(newSelectField("language", "A", "B"), "False")),
+	}}
+
+	// Act
+	err = renderer.Render(root, &models.ContentSubmission{"language": "A"})
+
+	// Assert - the completion is checked on the original answers
+	require.NoError(t, err)
+	assert.True(t, renderer.completion.complete())
+	assert.True(t, renderer.isHidden(root.Children[0]))
+}
@@ -2,6 +2,7 @@ package render
 
 import (
 	"fmt"
+	"github.com/alex-pricope/form-parser/config"
 	"github.com/alex-pricope/form-parser/models"
 )
 
@@ -12,10 +13,10 @@ type Renderer interface {
 }
 
 // GetRenderer - Factory method that creates the renderer based on file type
-func GetRenderer(fileType models.FileType, fileName string, dir string) (Renderer, error) {
+func GetRenderer(fileType models.FileType, fileName string, dir string, options config.RenderOptions) (Renderer, error) {
 	switch fileType {
 	case models.PDFFileType:
-		return NewPDFRenderer(fileName, dir), nil
+		return NewPDFRenderer(fileName, dir, options), nil
 
 	default:
 		return nil, fmt.Errorf("unimplemented renderer type: %s", fileType)
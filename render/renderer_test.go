@@ -1,16 +1,27 @@
 package render
 
 import (
+	"os"
 	"testing"
 
+	"github.com/alex-pricope/form-parser/config"
+	"github.com/alex-pricope/form-parser/logging"
 	"github.com/alex-pricope/form-parser/models"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestMain(m *testing.M) {
+	logging.Log = logrus.New()
+	logging.Log.SetLevel(logrus.FatalLevel)
+
+	os.Exit(m.Run())
+}
+
 func TestGetRenderer_PDFFileType(t *testing.T) {
 	// Arrange
-	renderer, err := GetRenderer(models.PDFFileType, "output.pdf", "output")
+	renderer, err := GetRenderer(models.PDFFileType, "output.pdf", "output", config.RenderOptions{})
 	require.NoError(t, err)
 	assert.NotNil(t, renderer)
 
@@ -23,7 +34,7 @@ func TestGetRenderer_PDFFileType(t *testing.T) {
 
 func TestGetRenderer_UnknownFileType(t *testing.T) {
 	// Arrange Act Assert
-	renderer, err := GetRenderer(models.UnknownFileType, "output.pdf", "output")
+	renderer, err := GetRenderer(models.UnknownFileType, "output.pdf", "output", config.RenderOptions{})
 	require.Error(t, err)
 	assert.Nil(t, renderer)
 	assert.Contains(t, err.Error(), "unimplemented renderer type")
@@ -0,0 +1,273 @@
+package revision
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+var (
+	sizePattern      = regexp.MustCompile(`/Size (\d+)`)
+	rootPattern      = regexp.MustCompile(`/Root (\d+) 0 R`)
+	infoPattern      = regexp.MustCompile(`/Info (\d+) 0 R`)
+	encryptPattern   = regexp.MustCompile(`/Encrypt \d+ 0 R`)
+	idPattern        = regexp.MustCompile(`/ID\s*\[[^\]]*\]`)
+	startXrefPattern = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF\s*$`)
+)
+
+/* A PDF can be changed without touching its bytes with an incremental update - new objects, or new versions
+   of existing objects, are appended after the end of the file, followed by their own cross-reference section
+   and a trailer that points (/Prev) to the previous one. The readers use the last version of every object.
+
+   This keeps the earlier revisions intact, which is what a signature needs, and avoids rewriting
+   the cross-reference table that gofpdf wrote.
+*/
+
+// Revision - the objects appended to a document in one incremental update
+type Revision struct {
+	document  []byte
+	trailer   []byte
+	size      int
+	root      int
+	info      int
+	startXref int
+	// encrypt and id are copied to the new trailer as they are, the readers need them in every trailer
+	encrypt []byte
+	id      []byte
+
+	objects map[int][]byte
+}
+
+// New - reads the last trailer of the document. Only documents with a classic cross-reference table are supported.
+func New(document []byte) (*Revision, error) {
+	index := bytes.LastIndex(document, []byte("trailer"))
+	startXref := startXrefPattern.FindSubmatch(document)
+	if index < 0 || startXref == nil {
+		return nil, errors.New("PDF trailer not found")
+	}
+
+	r := &Revision{document: document, trailer: document[index:], objects: make(map[int][]byte)}
+	r.startXref, _ = strconv.Atoi(string(startXref[1]))
+
+	size := sizePattern.FindSubmatch(r.trailer)
+	root := rootPattern.FindSubmatch(r.trailer)
+	if size == nil || root == nil {
+		return nil, errors.New("PDF trailer without /Size or /Root")
+	}
+	r.size, _ = strconv.Atoi(string(size[1]))
+	r.root, _ = strconv.Atoi(string(root[1]))
+
+	if info := infoPattern.FindSubmatch(r.trailer); info != nil {
+		r.info, _ = strconv.Atoi(string(info[1]))
+	}
+	r.encrypt = encryptPattern.Find(r.trailer)
+	r.id = idPattern.Find(r.trailer)
+
+	return r, nil
+}
+
+// Root - the object number of the catalog
+func (r *Revision) Root() int {
+	return r.root
+}
+
+// Info - the object number of the document information dictionary, 0 when there is none
+func (r *Revision) Info() int {
+	return r.info
+}
+
+// Encrypted - the strings and streams of new objects of an encrypted document would have to be encrypted too,
+// which is not supported. New objects can only hold numbers, names and references.
+func (r *Revision) Encrypted() bool {
+	return r.encrypt != nil
+}
+
+// Dictionary - the dictionary of the last version of an object. E.g. << /Type /Catalog ... >>
+func (r *Revision) Dictionary(number int) ([]byte, error) {
+	if content, ok := r.objects[number]; ok {
+		return content, nil
+	}
+
+	header := []byte(fmt.Sprintf("\n%d 0 obj", number))
+	index := bytes.LastIndex(r.document, header)
+	if index < 0 {
+		return nil, fmt.Errorf("PDF object %d not found", number)
+	}
+
+	content := bytes.TrimLeft(r.document[index+len(header):], " \r\n")
+	length := dictionaryLength(content)
+	if length < 0 {
+		return nil, fmt.Errorf("PDF object %d is not a dictionary", number)
+	}
+
+	return content[:length], nil
+}
+
+// Add - appends a new object and returns its number
+func (r *Revision) Add(content []byte) int {
+	number := r.size
+	r.size++
+	r.objects[number] = content
+	return number
+}
+
+// Replace - appends a new version of an existing object
+func (r *Revision) Replace(number int, content []byte) {
+	r.objects[number] = content
+}
+
+// SetInfo - points the trailer to another document information dictionary
+func (r *Revision) SetInfo(number int) {
+	r.info = number
+}
+
+// Bytes - the document followed by the update
+func (r *Revision) Bytes() []byte {
+	var buffer bytes.Buffer
+	buffer.Write(r.document)
+	if !bytes.HasSuffix(r.document, []byte("\n")) {
+		buffer.WriteString("\n")
+	}
+
+	numbers := make([]int, 0, len(r.objects))
+	for number := 0; number < r.size; number++ {
+		if _, ok := r.objects[number]; ok {
+			numbers = append(numbers, number)
+		}
+	}
+
+	offsets := make(map[int]int, len(numbers))
+	for _, number := range numbers {
+		offsets[number] = buffer.Len()
+		fmt.Fprintf(&buffer, "%d 0 obj\n", number)
+		buffer.Write(r.objects[number])
+		buffer.WriteString("\nendobj\n")
+	}
+
+	// One subsection for every run of consecutive object numbers
+	xrefOffset := buffer.Len()
+	buffer.WriteString("xref\n")
+	for start := 0; start < len(numbers); {
+		end := start + 1
+		for end < len(numbers) && numbers[end] == numbers[end-1]+1 {
+			end++
+		}
+		fmt.Fprintf(&buffer, "%d %d\n", numbers[start], end-start)
+		for _, number := range numbers[start:end] {
+			fmt.Fprintf(&buffer, "%010d 00000 n \n", offsets[number])
+		}
+		start = end
+	}
+
+	fmt.Fprintf(&buffer, "trailer\n<<\n/Size %d\n/Root %d 0 R\n", r.size, r.root)
+	if r.info > 0 {
+		fmt.Fprintf(&buffer, "/Info %d 0 R\n", r.info)
+	}
+	for _, entry := range [][]byte{r.encrypt, r.id} {
+		if entry != nil {
+			buffer.Write(entry)
+			buffer.WriteString("\n")
+		}
+	}
+	fmt.Fprintf(&buffer, "/Prev %d\n>>\nstartxref\n%d\n%%%%EOF\n", r.startXref, xrefOffset)
+
+	return buffer.Bytes()
+}
+
+// AppendEntries - adds entries at the end of a dictionary. E.g. << /Type /Catalog >> + /Metadata 5 0 R
+func AppendEntries(dictionary []byte, entries string) []byte {
+	closing := bytes.LastIndex(dictionary, []byte(">>"))
+	if closing < 0 {
+		return dictionary
+	}
+
+	var result []byte
+	result = append(result, bytes.TrimRight(dictionary[:closing], " \r\n")...)
+	result = append(result, '\n')
+	result = append(result, strings.TrimSpace(entries)...)
+	result = append(result, "\n>>"...)
+	return result
+}
+
+// TextString - a PDF text string. ASCII is written as a literal string, anything else as UTF-16 with a byte order mark.
+func TextString(value string) string {
+	ascii := true
+	for _, c := range value {
+		if c > 126 || (c < 32 && c != '\n' && c != '\t') {
+			ascii = false
+			break
+		}
+	}
+
+	if !ascii {
+		var buffer bytes.Buffer
+		buffer.WriteString("<FEFF")
+		for _, unit := range utf16.Encode([]rune(value)) {
+			fmt.Fprintf(&buffer, "%04X", unit)
+		}
+		buffer.WriteString(">")
+		return buffer.String()
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("(")
+	for _, c := range []byte(value) {
+		if c == '(' || c == ')' || c == '\\' {
+			buffer.WriteByte('\\')
+		}
+		buffer.WriteByte(c)
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+// dictionaryLength - the length of the dictionary at the start of the content, -1 when it is not one.
+// Literal and hex strings are skipped, so a >> inside a title does not end the dictionary.
+func dictionaryLength(content []byte) int {
+	if !bytes.HasPrefix(content, []byte("<<")) {
+		return -1
+	}
+
+	depth := 0
+	for i := 0; i < len(content); i++ {
+		switch {
+		case bytes.HasPrefix(content[i:], []byte("<<")):
+			depth++
+			i++
+		case bytes.HasPrefix(content[i:], []byte(">>")):
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		case content[i] == '<':
+			end := bytes.IndexByte(content[i:], '>')
+			if end < 0 {
+				return -1
+			}
+			i += end
+		case content[i] == '(':
+			nesting := 0
+			for ; i < len(content); i++ {
+				if content[i] == '\\' {
+					i++
+					continue
+				}
+				if content[i] == '(' {
+					nesting++
+				} else if content[i] == ')' {
+					nesting--
+					if nesting == 0 {
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return -1
+}
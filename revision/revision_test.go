@@ -0,0 +1,111 @@
+package revision
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDocument(t *testing.T) []byte {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Job (application) >>", false)
+	pdf.AddPage()
+
+	var buffer bytes.Buffer
+	require.NoError(t, pdf.Output(&buffer))
+	return buffer.Bytes()
+}
+
+func TestNew_ReadsTrailer(t *testing.T) {
+	// Act
+	update, err := New(newTestDocument(t))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Positive(t, update.Root())
+	assert.Positive(t, update.Info())
+	assert.False(t, update.Encrypted())
+}
+
+func TestNew_NotAPDF(t *testing.T) {
+	// Act
+	_, err := New([]byte("not a pdf"))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestDictionary_SkipsStrings(t *testing.T) {
+	// Arrange
+	update, err := New(newTestDocument(t))
+	require.NoError(t, err)
+
+	// Act
+	info, err := update.Dictionary(update.Info())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(info, []byte("<<")))
+	assert.True(t, bytes.HasSuffix(info, []byte(">>")))
+	assert.Contains(t, string(info), "/Title (Job \\(application\\) >>)")
+	assert.Contains(t, string(info), "/CreationDate")
+}
+
+func TestBytes_AppendsUpdate(t *testing.T) {
+	// Arrange
+	document := newTestDocument(t)
+	update, err := New(document)
+	require.NoError(t, err)
+	catalog, err := update.Dictionary(update.Root())
+	require.NoError(t, err)
+
+	// Act
+	number := update.Add([]byte("<< /Type /Test >>"))
+	update.Replace(update.Root(), AppendEntries(catalog, "/Test "+"1 0 R"))
+	updated := update.Bytes()
+
+	// Assert - the original bytes are kept and the new versions are the ones read
+	assert.True(t, bytes.HasPrefix(updated, document))
+
+	next, err := New(updated)
+	require.NoError(t, err)
+	added, err := next.Dictionary(number)
+	require.NoError(t, err)
+	assert.Equal(t, "<< /Type /Test >>", string(added))
+	root, err := next.Dictionary(next.Root())
+	require.NoError(t, err)
+	assert.Contains(t, string(root), "/Test 1 0 R")
+	assert.Equal(t, update.Info(), next.Info())
+}
+
+func TestTextString(t *testing.T) {
+	assert.Equal(t, "(Form \\(v2\\))", TextString("Form (v2)"))
+	assert.Equal(t, "<FEFF004D00FC006C006C00650072>", TextString("Müller"))
+}
+
+func TestBytes_KeepsEncryption(t *testing.T) {
+	// Arrange
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetProtection(gofpdf.CnProtectPrint, "user", "owner")
+	pdf.AddPage()
+	var buffer bytes.Buffer
+	require.NoError(t, pdf.Output(&buffer))
+	update, err := New(buffer.Bytes())
+	require.NoError(t, err)
+	catalog, err := update.Dictionary(update.Root())
+	require.NoError(t, err)
+
+	// Act
+	update.Replace(update.Root(), AppendEntries(catalog, "/Test 1 0 R"))
+	next, err := New(update.Bytes())
+
+	// Assert - the last trailer still points to the encryption dictionary
+	require.NoError(t, err)
+	assert.True(t, update.Encrypted())
+	assert.True(t, next.Encrypted())
+	assert.Equal(t, string(update.encrypt), string(next.encrypt))
+	assert.Equal(t, string(update.id), string(next.id))
+}
@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema - the subset of a JSON Schema (draft 2020-12) that describes a submission
+type Schema struct {
+	Schema               string              `json:"$schema,omitempty"`
+	Title                string              `json:"title,omitempty"`
+	Type                 string              `json:"type,omitempty"`
+	Properties           map[string]*Schema  `json:"properties,omitempty"`
+	Required             []string            `json:"required,omitempty"`
+	DependentRequired    map[string][]string `json:"dependentRequired,omitempty"`
+	AdditionalProperties *bool               `json:"additionalProperties,omitempty"`
+	Enum                 []string            `json:"enum,omitempty"`
+	MinLength            *int                `json:"minLength,omitempty"`
+	MaxLength            *int                `json:"maxLength,omitempty"`
+	Format               string              `json:"format,omitempty"`
+}
+
+/* Every field is a string property, named like the field:
+   * Select - enum of the Label Names (or of the Enumeration members when there are no labels)
+   * Text([min,max]) - minLength (only when more than 0) and maxLength
+   * Date - format date. Like in the drafts, the format is an annotation - it is not validated.
+   * Optional="False" - required
+
+   The required fields of an Optional="True" section are required only when the section was started.
+   * flat layout - dependentRequired: answering any field of the section requires its required fields
+   * nested layout - the section object is not required, its fields are required inside it
+
+   Unknown answers are not allowed (additionalProperties is false). Sections without a Name are not objects in the nested layout.
+*/
+
+// Generate - the JSON Schema of the submissions of the form
+func Generate(form *models.ContentNode, title string, layout models.SchemaLayout) *Schema {
+	root := newObject()
+	root.Schema = draft
+	root.Title = title
+
+	if form != nil {
+		if layout == models.NestedSchemaLayout {
+			addNested(root, form)
+		} else {
+			addFlat(root, form)
+		}
+	}
+	return root
+}
+
+func newObject() *Schema {
+	closed := false
+	return &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: &closed}
+}
+
+// optionalSection - the fields of an optional section and the ones that are required when it was started
+type optionalSection struct {
+	fields   []string
+	required []string
+}
+
+func addFlat(root *Schema, form *models.ContentNode) {
+	var sections []*optionalSection
+
+	var visit func(node *models.ContentNode, stack []*optionalSection)
+	visit = func(node *models.ContentNode, stack []*optionalSection) {
+		switch node.ElementType {
+		case models.FieldElementType:
+			if node.Name == "" {
+				return
+			}
+			root.Properties[node.Name] = fieldSchema(node)
+			for _, section := range stack {
+				section.fields = append(section.fields, node.Name)
+			}
+
+			switch {
+			case !node.IsRequired():
+			case len(stack) == 0:
+				root.Required = append(root.Required, node.Name)
+			default:
+				innermost := stack[len(stack)-1]
+				innermost.required = append(innermost.required, node.Name)
+			}
+			return
+
+		case models.SectionElementType:
+			if node.BoolMetadata("Optional") {
+				section := &optionalSection{}
+				sections = append(sections, section)
+				stack = append(stack[:len(stack):len(stack)], section)
+			}
+		}
+
+		for _, child := range node.Children {
+			visit(child, stack)
+		}
+	}
+	visit(form, nil)
+
+	for _, section := range sections {
+		for _, field := range section.fields {
+			for _, required := range section.required {
+				if required != field && !slices.Contains(root.DependentRequired[field], required) {
+					if root.DependentRequired == nil {
+						root.DependentRequired = make(map[string][]string)
+					}
+					root.DependentRequired[field] = append(root.DependentRequired[field], required)
+				}
+			}
+		}
+	}
+}
+
+// addNested - the fields and sections of the node as properties of the object. Returns true when one of them is required.
+func addNested(object *Schema, node *models.ContentNode) bool {
+	hasRequired := false
+	for _, child := range node.Children {
+		switch child.ElementType {
+		case models.FieldElementType:
+			if child.Name == "" {
+				continue
+			}
+			object.Properties[child.Name] = fieldSchema(child)
+			if child.IsRequired() {
+				object.Required = append(object.Required, child.Name)
+				hasRequired = true
+			}
+
+		case models.SectionElementType:
+			if child.Name == "" {
+				hasRequired = addNested(object, child) || hasRequired
+				continue
+			}
+
+			section := newObject()
+			section.Title = sectionTitle(child)
+			object.Properties[child.Name] = section
+			if addNested(section, child) && !child.BoolMetadata("Optional") {
+				object.Required = append(object.Required, child.Name)
+				hasRequired = true
+			}
+
+		default:
+			hasRequired = addNested(object, child) || hasRequired
+		}
+	}
+	return hasRequired
+}
+
+func fieldSchema(field *models.ContentNode) *Schema {
+	property := &Schema{Type: "string"}
+	for _, child := range field.Children {
+		if child.ElementType == models.CaptionElementType {
+			property.Title = child.Value
+		}
+	}
+
+	definition := models.ParseTypeDefinition(field.Metadata["Type"])
+	switch strings.ToLower(definition.Name) {
+	case "text":
+		if minimum, maximum, ok := definition.LengthRange(); ok {
+			if minimum > 0 {
+				property.MinLength = &minimum
+			}
+			property.MaxLength = &maximum
+		}
+	case "date":
+		property.Format = "date"
+	case "enumeration":
+		property.Enum = definition.Arguments
+	}
+
+	if options := field.Options(); len(options) > 0 {
+		property.Enum = nil
+		for _, option := range options {
+			property.Enum = append(property.Enum, option.Name)
+		}
+	}
+	return property
+}
+
+func sectionTitle(section *models.ContentNode) string {
+	for _, child := range section.Children {
+		if child.ElementType == models.TitleElementType {
+			return child.Value
+		}
+	}
+	return ""
+}
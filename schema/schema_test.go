@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readForm(t *testing.T, fileName string) *models.ContentNode {
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	form, err := (&parsers.XMLParser{}).Parse(content)
+	require.NoError(t, err)
+	return form
+}
+
+func TestGenerate_Flat(t *testing.T) {
+	// Act
+	schema := Generate(readForm(t, "../tests/payload/complex_valid_xml"), "Application", models.FlatSchemaLayout)
+
+	// Assert
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema.Schema)
+	assert.Equal(t, "Application", schema.Title)
+	assert.Equal(t, "object", schema.Type)
+	assert.False(t, *schema.AdditionalProperties)
+	assert.Len(t, schema.Properties, 7)
+	assert.Equal(t, []string{"user_name", "birth_date"}, schema.Required)
+	assert.Equal(t, map[string][]string{"country": {"street"}, "region": {"street", "country"}}, schema.DependentRequired)
+
+	assert.Equal(t, []string{"M", "F", "O"}, schema.Properties["gender"].Enum)
+	assert.Equal(t, "Gender", schema.Properties["gender"].Title)
+	assert.Equal(t, "date", schema.Properties["birth_date"].Format)
+	assert.Equal(t, 100, *schema.Properties["user_name"].MaxLength)
+	assert.Nil(t, schema.Properties["user_name"].MinLength)
+}
+
+func TestGenerate_Nested(t *testing.T) {
+	// Act
+	schema := Generate(readForm(t, "../tests/payload/complex_valid_xml"), "Application", models.NestedSchemaLayout)
+
+	// Assert
+	assert.Len(t, schema.Properties, 4)
+	assert.Equal(t, []string{"user_name", "personal_info"}, schema.Required)
+
+	personal := schema.Properties["personal_info"]
+	assert.Equal(t, "object", personal.Type)
+	assert.Equal(t, "Personal Information", personal.Title)
+	assert.Equal(t, []string{"birth_date"}, personal.Required)
+
+	address := schema.Properties["address"]
+	assert.Equal(t, []string{"street"}, address.Required)
+	assert.Equal(t, []string{"country"}, address.Properties["country_region"].Required)
+	assert.Nil(t, address.DependentRequired)
+}
+
+func TestGenerate_FieldTypes(t *testing.T) {
+	// Arrange
+	form := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		{ElementType: models.FieldElementType, Name: "code", Metadata: map[string]string{"Type": "Text([2,10])"}},
+		{ElementType: models.FieldElementType, Name: "level", Metadata: map[string]string{"Type": "Enumeration(A,B)", "FieldType": "Select"}},
+		{ElementType: models.FieldElementType, Metadata: map[string]string{"Type": "Text"}},
+	}}
+
+	// Act
+	schema := Generate(form, "", models.FlatSchemaLayout)
+
+	// Assert - the Enumeration members when there are no labels, fields without a Name are left out
+	assert.Len(t, schema.Properties, 2)
+	assert.Equal(t, 2, *schema.Properties["code"].MinLength)
+	assert.Equal(t, 10, *schema.Properties["code"].MaxLength)
+	assert.Equal(t, []string{"A", "B"}, schema.Properties["level"].Enum)
+}
+
+func TestGenerate_JSON(t *testing.T) {
+	// Arrange
+	schema := Generate(readForm(t, "../tests/payload/valid_xml"), "", models.FlatSchemaLayout)
+
+	// Act
+	content, err := json.Marshal(schema)
+	require.NoError(t, err)
+	var read Schema
+	require.NoError(t, json.Unmarshal(content, &read))
+
+	// Assert - the written schema can be read back and used to validate
+	assert.Contains(t, string(content), `"$schema":"https://json-schema.org/draft/2020-12/schema"`)
+	assert.Contains(t, string(content), `"maxLength":200`)
+	assert.Contains(t, string(content), `"additionalProperties":false`)
+	assert.Equal(t, schema, &read)
+}
@@ -0,0 +1,210 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+)
+
+// Instance - the submission as the JSON value the schema of the layout describes. Blank answers are left out, they are missing answers.
+func Instance(form *models.ContentNode, submission *models.ContentSubmission, layout models.SchemaLayout) map[string]any {
+	instance := make(map[string]any)
+	if submission == nil {
+		return instance
+	}
+
+	answers := make(map[string]string)
+	for name, value := range *submission {
+		if strings.TrimSpace(value) != "" {
+			answers[name] = value
+		}
+	}
+
+	if layout == models.NestedSchemaLayout && form != nil {
+		nest(instance, form, answers)
+	}
+	for name, value := range answers {
+		instance[name] = value
+	}
+	return instance
+}
+
+// nest - moves the answers of the fields under the node to the objects of their sections.
+// The object of an optional section is only added when the section was started.
+func nest(object map[string]any, node *models.ContentNode, answers map[string]string) {
+	for _, child := range node.Children {
+		switch child.ElementType {
+		case models.FieldElementType:
+			if value, ok := answers[child.Name]; ok && child.Name != "" {
+				object[child.Name] = value
+				delete(answers, child.Name)
+			}
+
+		case models.SectionElementType:
+			if child.Name == "" {
+				nest(object, child, answers)
+				continue
+			}
+
+			section := make(map[string]any)
+			nest(section, child, answers)
+			if len(section) > 0 || !child.BoolMetadata("Optional") {
+				object[child.Name] = section
+			}
+
+		default:
+			nest(object, child, answers)
+		}
+	}
+}
+
+/* The schema is the only validator of the submissions - migrate, serve and fill check the answers with
+   the flat schema of the form (see ValidateSubmission and ValidateProperty), and the PDF highlights the
+   missing answers that IsRequired reports.
+
+   Every problem is ErrSchemaViolation and the error of its rule, so the callers can tell them apart:
+   ErrMissingAnswer, ErrInvalidOption, ErrInvalidLength or ErrUnknownField.
+*/
+
+// violation - a problem of the value at the path
+type violation struct {
+	rule    error
+	path    string
+	message string
+}
+
+func (v *violation) Error() string {
+	return fmt.Sprintf("%v: %s: %s", myerrors.ErrSchemaViolation, displayPath(v.path), v.message)
+}
+
+func (v *violation) Unwrap() []error {
+	if v.rule == nil {
+		return []error{myerrors.ErrSchemaViolation}
+	}
+	return []error{myerrors.ErrSchemaViolation, v.rule}
+}
+
+// ValidateSubmission - checks the answers against the flat schema of the form. All the problems are reported at once.
+func ValidateSubmission(form *models.ContentNode, submission *models.ContentSubmission) error {
+	return Validate(Generate(form, "", models.FlatSchemaLayout), Instance(form, submission, models.FlatSchemaLayout))
+}
+
+// Validate - checks the value against the schema. All the problems are reported at once, with the path of the value.
+func Validate(schema *Schema, value any) error {
+	return errors.Join(validate(schema, value, "")...)
+}
+
+// ValidateProperty - the problems of one property of the object: its value, or its absence when the object requires it
+func ValidateProperty(schema *Schema, object map[string]any, name string) error {
+	value, ok := object[name]
+	if !ok {
+		if IsRequired(schema, object, name) {
+			return &violation{rule: myerrors.ErrMissingAnswer, message: fmt.Sprintf("missing required property %s", name)}
+		}
+		return nil
+	}
+
+	property, ok := schema.Properties[name]
+	if !ok {
+		if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+			return &violation{rule: myerrors.ErrUnknownField, message: fmt.Sprintf("unknown property %s", name)}
+		}
+		return nil
+	}
+	return errors.Join(validate(property, value, "/"+name)...)
+}
+
+// IsRequired - the object needs the property: it is required, or one of the properties the object has depends on it
+func IsRequired(schema *Schema, object map[string]any, name string) bool {
+	if slices.Contains(schema.Required, name) {
+		return true
+	}
+	for present := range object {
+		if slices.Contains(schema.DependentRequired[present], name) {
+			return true
+		}
+	}
+	return false
+}
+
+func validate(schema *Schema, value any, path string) []error {
+	report := func(rule error, format string, args ...any) error {
+		return &violation{rule: rule, path: path, message: fmt.Sprintf(format, args...)}
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []error{report(nil, "expected an object")}
+		}
+		return validateObject(schema, object, path, report)
+
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return []error{report(nil, "expected a string")}
+		}
+
+		var errs []error
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, text) {
+			errs = append(errs, report(myerrors.ErrInvalidOption, "%q is not one of %s", text, strings.Join(schema.Enum, ", ")))
+		}
+		length := utf8.RuneCountInString(text)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			errs = append(errs, report(myerrors.ErrInvalidLength, "%d characters, the minimum is %d", length, *schema.MinLength))
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			errs = append(errs, report(myerrors.ErrInvalidLength, "%d characters, the maximum is %d", length, *schema.MaxLength))
+		}
+		return errs
+	}
+
+	return nil
+}
+
+func validateObject(schema *Schema, object map[string]any, path string, report func(error, string, ...any) error) []error {
+	var errs []error
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			errs = append(errs, report(myerrors.ErrMissingAnswer, "missing required property %s", name))
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, dependent := range schema.DependentRequired[name] {
+			if _, ok := object[dependent]; !ok {
+				errs = append(errs, report(myerrors.ErrMissingAnswer, "property %s requires property %s", name, dependent))
+			}
+		}
+
+		property, ok := schema.Properties[name]
+		if !ok {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				errs = append(errs, report(myerrors.ErrUnknownField, "unknown property %s", name))
+			}
+			continue
+		}
+		errs = append(errs, validate(property, object[name], path+"/"+name)...)
+	}
+	return errs
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,184 @@
+package schema
+
+import (
+	"testing"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/reader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_Payload(t *testing.T) {
+	form := readForm(t, "../tests/payload/complex_valid_xml")
+	submission, err := (&reader.FileReader{}).ReadSubmissionFile("../tests/payload/complex_valid_submission")
+	require.NoError(t, err)
+
+	for _, layout := range []models.SchemaLayout{models.FlatSchemaLayout, models.NestedSchemaLayout} {
+		t.Run(string(layout), func(t *testing.T) {
+			// Act
+			err := Validate(Generate(form, "", layout), Instance(form, submission, layout))
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidate_Problems(t *testing.T) {
+	form := readForm(t, "../tests/payload/complex_valid_xml")
+	submission := &models.ContentSubmission{
+		"user_name": "   ",
+		"gender":    "X",
+		"region":    "North Holland",
+		"email":     string(make([]rune, 101)),
+		"phone":     "123",
+	}
+
+	tests := []struct {
+		layout   models.SchemaLayout
+		expected []string
+	}{
+		{models.FlatSchemaLayout, []string{
+			"/: missing required property user_name",
+			"/: missing required property birth_date",
+			"/: property region requires property street",
+			"/: property region requires property country",
+			"/email: 101 characters, the maximum is 100",
+			`/gender: "X" is not one of M, F, O`,
+			"/: unknown property phone",
+		}},
+		{models.NestedSchemaLayout, []string{
+			"/: missing required property user_name",
+			"/personal_info: missing required property birth_date",
+			"/address: missing required property street",
+			"/address/country_region: missing required property country",
+			"/email: 101 characters, the maximum is 100",
+			`/personal_info/gender: "X" is not one of M, F, O`,
+			"/: unknown property phone",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.layout), func(t *testing.T) {
+			// Act
+			err := Validate(Generate(form, "", tt.layout), Instance(form, submission, tt.layout))
+
+			// Assert
+			assert.ErrorIs(t, err, myerrors.ErrSchemaViolation)
+			for _, message := range tt.expected {
+				assert.ErrorContains(t, err, message)
+			}
+		})
+	}
+}
+
+func TestInstance_Nested(t *testing.T) {
+	// Arrange
+	form := readForm(t, "../tests/payload/complex_valid_xml")
+	submission := &models.ContentSubmission{"user_name": "Jane", "country": "NL", "region": ""}
+
+	// Act
+	instance := Instance(form, submission, models.NestedSchemaLayout)
+
+	// Assert - the required sections are always there, the optional ones only when they were started
+	expected := map[string]any{
+		"user_name":     "Jane",
+		"personal_info": map[string]any{},
+		"address":       map[string]any{"country_region": map[string]any{"country": "NL"}},
+	}
+	assert.Equal(t, expected, instance)
+}
+
+func TestValidate_Types(t *testing.T) {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{"a": {Type: "string"}}}
+
+	assert.ErrorContains(t, Validate(schema, "text"), "/: expected an object")
+	assert.ErrorContains(t, Validate(schema, map[string]any{"a": 1.0}), "/a: expected a string")
+	assert.NoError(t, Validate(schema, map[string]any{"b": "no additionalProperties"}))
+}
+
+func TestValidateSubmission_Payloads(t *testing.T) {
+	tests := []struct {
+		form       string
+		submission string
+	}{
+		{"../tests/payload/valid_xml", "../tests/payload/valid_submission"},
+		{"../tests/payload/complex_valid_xml", "../tests/payload/complex_valid_submission"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.submission, func(t *testing.T) {
+			// Arrange
+			submission, err := (&reader.FileReader{}).ReadSubmissionFile(tt.submission)
+			require.NoError(t, err)
+
+			// Act
+			err = ValidateSubmission(readForm(t, tt.form), submission)
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSubmission_Rules(t *testing.T) {
+	// Arrange
+	form := readForm(t, "../tests/payload/valid_xml")
+	submission := &models.ContentSubmission{
+		"program_language": "D",
+		"other":            string(make([]rune, 201)),
+		"language":         "Go",
+	}
+
+	// Act
+	err := ValidateSubmission(form, submission)
+
+	// Assert - every problem matches the error of its rule
+	assert.ErrorIs(t, err, myerrors.ErrSchemaViolation)
+	assert.ErrorIs(t, err, myerrors.ErrInvalidOption)
+	assert.ErrorIs(t, err, myerrors.ErrInvalidLength)
+	assert.ErrorIs(t, err, myerrors.ErrUnknownField)
+	assert.NotErrorIs(t, err, myerrors.ErrMissingAnswer)
+}
+
+func TestValidateProperty(t *testing.T) {
+	form := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		{ElementType: models.FieldElementType, Name: "user_name", Metadata: map[string]string{"Optional": "False", "FieldType": "TextBox", "Type": "Text([2,10])"}},
+		{ElementType: models.SectionElementType, Name: "address", Metadata: map[string]string{"Optional": "True"}, Children: []*models.ContentNode{
+			{ElementType: models.FieldElementType, Name: "street", Metadata: map[string]string{"Optional": "False", "FieldType": "TextBox"}},
+			{ElementType: models.FieldElementType, Name: "city", Metadata: map[string]string{"Optional": "False", "FieldType": "TextBox"}},
+		}},
+	}}
+	submissionSchema := Generate(form, "", models.FlatSchemaLayout)
+
+	tests := []struct {
+		name     string
+		object   map[string]any
+		property string
+		expected error
+	}{
+		{"missing required", map[string]any{}, "user_name", myerrors.ErrMissingAnswer},
+		{"too short", map[string]any{"user_name": "J"}, "user_name", myerrors.ErrInvalidLength},
+		{"valid", map[string]any{"user_name": "Jane"}, "user_name", nil},
+		{"untouched optional section", map[string]any{}, "city", nil},
+		{"started optional section", map[string]any{"street": "Main Street 1"}, "city", myerrors.ErrMissingAnswer},
+		{"unknown", map[string]any{"phone": "123"}, "phone", myerrors.ErrUnknownField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := ValidateProperty(submissionSchema, tt.object, tt.property)
+
+			// Assert
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expected)
+			assert.ErrorIs(t, err, myerrors.ErrSchemaViolation)
+		})
+	}
+}
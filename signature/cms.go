@@ -0,0 +1,264 @@
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"time"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+)
+
+/* The signature embedded in the PDF is a detached PKCS#7 (CMS) SignedData - adbe.pkcs7.detached.
+   "Detached" means the signed content is not inside the structure, only its SHA-256 digest (messageDigest).
+
+   ContentInfo
+     SignedData
+       digestAlgorithms: sha256
+       encapContentInfo: data (no content)
+       certificates: signer certificate + chain
+       signerInfos:
+         SignerInfo
+           sid: issuer + serial number of the signer certificate
+           signedAttrs: contentType, signingTime, messageDigest
+           signature: signature of the DER encoded signedAttrs
+*/
+
+var (
+	oidData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidSHA256        = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidRSA           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA  = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+)
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	// [0] EXPLICIT - the tag is set on the RawValue, encoding/asn1 ignores the field tags of a raw value
+	Content asn1.RawValue
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type encapsulatedContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+}
+
+type signerInfo struct {
+	Version            int
+	IssuerAndSerial    issuerAndSerial
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+}
+
+type issuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// createSignedData - signs the digest of the document and returns the DER encoded ContentInfo
+func createSignedData(signer *Signer, digest []byte, signingTime time.Time) ([]byte, error) {
+	signedAttributes, err := marshalAttributes([]attributeValue{
+		{oid: oidContentType, value: oidData},
+		{oid: oidSigningTime, value: signingTime.UTC()},
+		{oid: oidMessageDigest, value: digest},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature is computed over the attributes encoded as a SET, not as the [0] tag they are stored with
+	signedContent, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: signedAttributes})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a random source, the signature only depends on the key and the content
+	var random io.Reader = rand.Reader
+	if signer.Deterministic {
+		random = nil
+	}
+
+	hash := sha256.Sum256(signedContent)
+	signatureValue, err := signer.Key.Sign(random, hash[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureAlgorithm := oidRSA
+	if _, ok := signer.Key.(*ecdsa.PrivateKey); ok {
+		signatureAlgorithm = oidECDSAWithSHA
+	}
+
+	var certificates []byte
+	for _, certificate := range append([]*x509.Certificate{signer.Certificate}, signer.Chain...) {
+		certificates = append(certificates, certificate.Raw...)
+	}
+
+	data := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: oidSHA256}},
+		ContentInfo:      encapsulatedContentInfo{ContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certificates},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			IssuerAndSerial: issuerAndSerial{
+				Issuer:       asn1.RawValue{FullBytes: signer.Certificate.RawIssuer},
+				SerialNumber: signer.Certificate.SerialNumber,
+			},
+			DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			SignedAttributes:   asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedAttributes},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: signatureAlgorithm},
+			Signature:          signatureValue,
+		}},
+	}
+
+	content, err := asn1.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(contentInfo{ContentType: oidSignedData, Content: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: content}})
+}
+
+// attributeValue - a signed attribute before it is encoded
+type attributeValue struct {
+	oid   asn1.ObjectIdentifier
+	value any
+}
+
+// marshalAttributes - encodes the attributes as the content of a DER SET OF - the elements must be sorted
+func marshalAttributes(values []attributeValue) ([]byte, error) {
+	var encoded [][]byte
+
+	for _, value := range values {
+		valueBytes, err := asn1.Marshal(value.value)
+		if err != nil {
+			return nil, err
+		}
+
+		attributeBytes, err := asn1.Marshal(attribute{Type: value.oid, Values: []asn1.RawValue{{FullBytes: valueBytes}}})
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, attributeBytes)
+	}
+
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	return bytes.Join(encoded, nil), nil
+}
+
+// signedDataInfo - what the verification needs from a parsed signature
+type signedDataInfo struct {
+	certificate   *x509.Certificate
+	intermediates []*x509.Certificate
+	messageDigest []byte
+	signingTime   time.Time
+}
+
+// verifySignedData - parses the DER encoded ContentInfo and checks the signature of the signed attributes
+func verifySignedData(content []byte) (*signedDataInfo, error) {
+	var info contentInfo
+	if _, err := asn1.Unmarshal(content, &info); err != nil {
+		return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidSignature, err)
+	}
+	if !info.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("%w: not a SignedData", myerrors.ErrInvalidSignature)
+	}
+
+	var data signedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &data); err != nil {
+		return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidSignature, err)
+	}
+	if len(data.SignerInfos) != 1 {
+		return nil, fmt.Errorf("%w: expected one signer, found %d", myerrors.ErrInvalidSignature, len(data.SignerInfos))
+	}
+
+	certificates, err := x509.ParseCertificates(data.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidSignature, err)
+	}
+
+	signer := data.SignerInfos[0]
+	result := &signedDataInfo{}
+	for _, certificate := range certificates {
+		if bytes.Equal(certificate.RawIssuer, signer.IssuerAndSerial.Issuer.FullBytes) && certificate.SerialNumber.Cmp(signer.IssuerAndSerial.SerialNumber) == 0 {
+			result.certificate = certificate
+		} else {
+			result.intermediates = append(result.intermediates, certificate)
+		}
+	}
+	if result.certificate == nil {
+		return nil, fmt.Errorf("%w: signer certificate not found", myerrors.ErrInvalidSignature)
+	}
+
+	// Read the signed attributes
+	for rest := signer.SignedAttributes.Bytes; len(rest) > 0; {
+		var attr attribute
+		rest, err = asn1.Unmarshal(rest, &attr)
+		if err != nil || len(attr.Values) == 0 {
+			return nil, fmt.Errorf("%w: invalid signed attributes", myerrors.ErrInvalidSignature)
+		}
+
+		switch {
+		case attr.Type.Equal(oidMessageDigest):
+			_, err = asn1.Unmarshal(attr.Values[0].FullBytes, &result.messageDigest)
+		case attr.Type.Equal(oidSigningTime):
+			_, err = asn1.Unmarshal(attr.Values[0].FullBytes, &result.signingTime)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidSignature, err)
+		}
+	}
+	if result.messageDigest == nil {
+		return nil, fmt.Errorf("%w: missing message digest", myerrors.ErrInvalidSignature)
+	}
+
+	signedContent, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: signer.SignedAttributes.Bytes})
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(signedContent)
+	switch publicKey := result.certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signer.Signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(publicKey, hash[:], signer.Signature) {
+			err = fmt.Errorf("ecdsa verification failed")
+		}
+	default:
+		err = fmt.Errorf("%w: %T", myerrors.ErrUnsupportedKey, publicKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidSignature, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,210 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/alex-pricope/form-parser/revision"
+)
+
+// The space reserved for the signature in the file - big enough for a certificate chain
+var signatureMaxLength = 16384
+var byteRangePlaceholder = "/ByteRange [0 0000000000 0000000000 0000000000]"
+
+var byteRangePattern = regexp.MustCompile(`/ByteRange\s*\[\s*(\d+)\s+(\d+)\s+(\d+)\s+(\d+)\s*\]`)
+var pagesPattern = regexp.MustCompile(`/Pages\s+(\d+)\s+0\s+R`)
+var firstKidPattern = regexp.MustCompile(`/Kids\s*\[\s*(\d+)\s+0\s+R`)
+var pageTreePattern = regexp.MustCompile(`/Type\s*/Pages\b`)
+var annotsPattern = regexp.MustCompile(`/Annots\s*\[`)
+var annotsReferencePattern = regexp.MustCompile(`/Annots\s+\d+\s+0\s+R`)
+
+/* The PDF is signed with an incremental update (see the revision package) - the original bytes stay untouched:
+   * the signature dictionary (/Type /Sig) with the /ByteRange and the /Contents placeholder
+   * an invisible signature field that points to the signature
+   * a new version of the first page that lists the field in its /Annots - a widget must be on a page
+   * a new version of the catalog that lists the field in its /AcroForm
+
+   The /ByteRange covers the whole file except the /Contents hex string:
+   [0 start_of_contents end_of_contents rest_of_the_file]
+   The SHA-256 of these bytes is signed and the PKCS#7 signature is written in the placeholder.
+*/
+
+// Sign - signs the PDF document and returns the signed document
+func Sign(document []byte, signer *Signer, signingTime time.Time) ([]byte, error) {
+	update, err := revision.New(document)
+	if err != nil {
+		return nil, err
+	}
+	if update.Encrypted() {
+		return nil, myerrors.ErrSignProtectedFile
+	}
+
+	catalog, err := update.Dictionary(update.Root())
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(catalog, []byte("/AcroForm")) {
+		return nil, errors.New("documents with forms are not supported")
+	}
+
+	// Signature dictionary - the placeholders are filled in at the end
+	var dictionary bytes.Buffer
+	dictionary.WriteString("<<\n/Type /Sig\n/Filter /Adobe.PPKLite\n/SubFilter /adbe.pkcs7.detached\n")
+	fmt.Fprintf(&dictionary, "/Name %s\n/M (D:%s)\n", revision.TextString(signer.Certificate.Subject.CommonName), signingTime.UTC().Format("20060102150405Z"))
+	fmt.Fprintf(&dictionary, "%s\n/Contents <%s>\n>>", byteRangePlaceholder, strings.Repeat("0", 2*signatureMaxLength))
+	signatureNumber := update.Add(dictionary.Bytes())
+
+	// Invisible signature field on the first page and a new version of the catalog with the form
+	pageNumber, page, err := firstPage(update, catalog)
+	if err != nil {
+		return nil, err
+	}
+	field := fmt.Sprintf("<<\n/Type /Annot\n/Subtype /Widget\n/FT /Sig\n/T (Signature1)\n/F 132\n/Rect [0 0 0 0]\n/P %d 0 R\n/V %d 0 R\n>>", pageNumber, signatureNumber)
+	fieldNumber := update.Add([]byte(field))
+	page, err = addAnnotation(page, fieldNumber)
+	if err != nil {
+		return nil, err
+	}
+	update.Replace(pageNumber, page)
+	update.Replace(update.Root(), revision.AppendEntries(catalog, fmt.Sprintf("/AcroForm << /Fields [%d 0 R] /SigFlags 3 >>", fieldNumber)))
+
+	signed := update.Bytes()
+	byteRangeOffset := bytes.LastIndex(signed, []byte(byteRangePlaceholder))
+	contentsStart := byteRangeOffset + bytes.IndexByte(signed[byteRangeOffset:], '<')
+	contentsEnd := contentsStart + 2*signatureMaxLength + 2
+
+	byteRange := fmt.Sprintf("/ByteRange [0 %010d %010d %010d]", contentsStart, contentsEnd, len(signed)-contentsEnd)
+	copy(signed[byteRangeOffset:], byteRange)
+
+	digest := sha256.New()
+	digest.Write(signed[:contentsStart])
+	digest.Write(signed[contentsEnd:])
+
+	signatureValue, err := createSignedData(signer, digest.Sum(nil), signingTime)
+	if err != nil {
+		return nil, err
+	}
+	if len(signatureValue) > signatureMaxLength {
+		return nil, fmt.Errorf("signature is too big: %d bytes", len(signatureValue))
+	}
+	copy(signed[contentsStart+1:], hex.EncodeToString(signatureValue))
+
+	return signed, nil
+}
+
+// firstPage - the object number and the dictionary of the first page of the document
+func firstPage(update *revision.Revision, catalog []byte) (int, []byte, error) {
+	match := pagesPattern.FindSubmatch(catalog)
+	if match == nil {
+		return 0, nil, errors.New("PDF catalog without /Pages")
+	}
+
+	// The page tree can be nested, the first page is the first leaf
+	number, _ := strconv.Atoi(string(match[1]))
+	for {
+		node, err := update.Dictionary(number)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !pageTreePattern.Match(node) {
+			return number, node, nil
+		}
+
+		kid := firstKidPattern.FindSubmatch(node)
+		if kid == nil {
+			return 0, nil, errors.New("PDF without pages")
+		}
+		number, _ = strconv.Atoi(string(kid[1]))
+	}
+}
+
+// addAnnotation - adds the annotation to the /Annots of the page, the links of the page are kept
+func addAnnotation(page []byte, annotation int) ([]byte, error) {
+	reference := fmt.Sprintf("%d 0 R", annotation)
+	if location := annotsPattern.FindIndex(page); location != nil {
+		var result []byte
+		result = append(result, page[:location[1]]...)
+		result = append(result, reference+" "...)
+		return append(result, page[location[1]:]...), nil
+	}
+	if annotsReferencePattern.Match(page) {
+		return nil, errors.New("pages with an indirect /Annots array are not supported")
+	}
+	return revision.AppendEntries(page, "/Annots ["+reference+"]"), nil
+}
+
+// Verification - the result of a successful verification
+type Verification struct {
+	Signer      string
+	Issuer      string
+	SigningTime time.Time
+}
+
+// Verify - checks that the signature covers the whole document, that the document was not changed after signing
+// and that the certificate chains up to the roots. The roots of the system are used when roots is nil.
+func Verify(document []byte, roots *x509.CertPool) (*Verification, error) {
+	matches := byteRangePattern.FindAllSubmatch(document, -1)
+	if len(matches) == 0 {
+		return nil, myerrors.ErrNoSignature
+	}
+
+	// The last signature must cover the whole file - anything appended after it was not signed
+	var byteRange [4]int
+	for i := range byteRange {
+		byteRange[i], _ = strconv.Atoi(string(matches[len(matches)-1][i+1]))
+	}
+	start, length, contentsEnd, rest := byteRange[0], byteRange[1], byteRange[2], byteRange[3]
+	if start != 0 || length <= 0 || contentsEnd <= length || contentsEnd+rest != len(document) {
+		return nil, fmt.Errorf("%w: %v does not cover the %d bytes of the document", myerrors.ErrInvalidByteRange, byteRange, len(document))
+	}
+	if document[length] != '<' || document[contentsEnd-1] != '>' {
+		return nil, fmt.Errorf("%w: the excluded bytes are not the signature", myerrors.ErrInvalidByteRange)
+	}
+
+	signatureValue, err := hex.DecodeString(string(document[length+1 : contentsEnd-1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", myerrors.ErrInvalidSignature, err)
+	}
+
+	info, err := verifySignedData(signatureValue)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.New()
+	digest.Write(document[:length])
+	digest.Write(document[contentsEnd:])
+	if !bytes.Equal(digest.Sum(nil), info.messageDigest) {
+		return nil, myerrors.ErrDigestMismatch
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range info.intermediates {
+		intermediates.AddCert(certificate)
+	}
+	_, err = info.certificate.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		CurrentTime:   info.signingTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: certificate of %s issued by %s is not trusted: %v", myerrors.ErrInvalidSignature,
+			info.certificate.Subject, info.certificate.Issuer, err)
+	}
+
+	return &Verification{
+		Signer:      info.certificate.Subject.String(),
+		Issuer:      info.certificate.Issuer.String(),
+		SigningTime: info.signingTime,
+	}, nil
+}
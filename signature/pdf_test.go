@@ -0,0 +1,175 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDocument(t *testing.T) []byte {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "", 12)
+	pdf.Cell(40, 10, "Signed submission")
+
+	var buffer bytes.Buffer
+	require.NoError(t, pdf.Output(&buffer))
+	return buffer.Bytes()
+}
+
+// trustedRoots - the certificate of the signer as the only root
+func trustedRoots(signer *Signer) *x509.CertPool {
+	roots := x509.NewCertPool()
+	roots.AddCert(signer.Certificate)
+	return roots
+}
+
+func TestSign_RoundTrip(t *testing.T) {
+	// Arrange
+	document := newTestDocument(t)
+	signer := newTestSigner(t)
+	signingTime := time.Now().Truncate(time.Second)
+
+	// Act
+	signed, err := Sign(document, signer, signingTime)
+	require.NoError(t, err)
+	verification, err := Verify(signed, trustedRoots(signer))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(signed, document), "the original bytes must stay untouched")
+	assert.Contains(t, string(signed), "/SubFilter /adbe.pkcs7.detached")
+	assert.Contains(t, verification.Signer, "Form Parser Test")
+	assert.True(t, signingTime.Equal(verification.SigningTime))
+}
+
+func TestSign_AddsWidgetToFirstPage(t *testing.T) {
+	// Arrange
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetCompression(false)
+	pdf.AddPage()
+	pdf.SetFont("Arial", "", 12)
+	pdf.CellFormat(40, 10, "Link", "", 1, "", false, 0, "https://example.com")
+	var buffer bytes.Buffer
+	require.NoError(t, pdf.Output(&buffer))
+
+	// Act
+	signed, err := Sign(buffer.Bytes(), newTestSigner(t), time.Now())
+
+	// Assert - the new version of the page lists the widget before the link it already had
+	require.NoError(t, err)
+	update := string(signed[buffer.Len():])
+	assert.Regexp(t, `/Annots \[\d+ 0 R <</Type /Annot /Subtype /Link`, update)
+	assert.Regexp(t, `/Subtype /Widget\n/FT /Sig\n/T \(Signature1\)\n/F 132\n/Rect \[0 0 0 0\]\n/P \d+ 0 R`, update)
+}
+
+func TestVerify_UntrustedCertificate(t *testing.T) {
+	// Arrange
+	signed, err := Sign(newTestDocument(t), newTestSigner(t), time.Now())
+	require.NoError(t, err)
+
+	// Act - the self-signed certificate is not one of the roots
+	_, err = Verify(signed, trustedRoots(newTestSigner(t)))
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrInvalidSignature)
+}
+
+func TestSign_ECDSARoundTrip(t *testing.T) {
+	// Arrange
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer := &Signer{Certificate: newTestCertificate(t, key), Key: key}
+
+	// Act
+	signed, err := Sign(newTestDocument(t), signer, time.Now())
+	require.NoError(t, err)
+	_, err = Verify(signed, trustedRoots(signer))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestSign_ProtectedDocument(t *testing.T) {
+	// Arrange
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetProtection(gofpdf.CnProtectPrint, "user", "owner")
+	pdf.AddPage()
+	var buffer bytes.Buffer
+	require.NoError(t, pdf.Output(&buffer))
+
+	// Act
+	_, err := Sign(buffer.Bytes(), newTestSigner(t), time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrSignProtectedFile)
+}
+
+func TestVerify_Unsigned(t *testing.T) {
+	// Act
+	_, err := Verify(newTestDocument(t), nil)
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrNoSignature)
+}
+
+func TestVerify_ModifiedContent(t *testing.T) {
+	// Arrange
+	signed, err := Sign(newTestDocument(t), newTestSigner(t), time.Now())
+	require.NoError(t, err)
+
+	// Change one byte of the signed text
+	index := bytes.Index(signed, []byte("/Producer"))
+	require.Positive(t, index)
+	signed[index+1] = 'p'
+
+	// Act
+	_, err = Verify(signed, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrDigestMismatch)
+}
+
+func TestVerify_AppendedContent(t *testing.T) {
+	// Arrange
+	signed, err := Sign(newTestDocument(t), newTestSigner(t), time.Now())
+	require.NoError(t, err)
+
+	// Act
+	_, err = Verify(append(signed, []byte("1 0 obj\n<<>>\nendobj\n")...), nil)
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrInvalidByteRange)
+}
+
+func TestVerify_ModifiedSignature(t *testing.T) {
+	// Arrange
+	signed, err := Sign(newTestDocument(t), newTestSigner(t), time.Now())
+	require.NoError(t, err)
+
+	// Flip a hex digit of the signature value at the end of the PKCS#7 structure
+	end := bytes.LastIndex(signed, []byte("0000>"))
+	contents := bytes.LastIndex(signed[:end], []byte("/Contents <"))
+	value := bytes.TrimRight(signed[contents+len("/Contents <"):end], "0")
+	position := contents + len("/Contents <") + len(value) - 4
+	if signed[position] == 'a' {
+		signed[position] = 'b'
+	} else {
+		signed[position] = 'a'
+	}
+
+	// Act
+	_, err = Verify(signed, nil)
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Signer - the certificate and the private key used to sign the output
+type Signer struct {
+	Certificate *x509.Certificate
+	Chain       []*x509.Certificate
+	Key         crypto.Signer
+
+	// Deterministic signs ECDSA with RFC 6979 nonces, so the same document gives the same signature (RSA always does)
+	Deterministic bool
+}
+
+/* The signing material can come in two shapes:
+   * PKCS#12 (.p12, .pfx) - one file with the certificate, the chain and the key, protected by a password
+   * PEM - the certificate (and optionally the chain) in one file, the key in the same or another file
+
+   When the certificate file is not PEM, it is read as PKCS#12.
+*/
+
+// LoadSigner - loads the certificate and the key. keyPath can be empty when the key is in the certificate file.
+func LoadSigner(certificatePath, keyPath, password string) (*Signer, error) {
+	content, err := os.ReadFile(certificatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return loadPKCS12(content, password)
+	}
+
+	return loadPEM(content, keyPath)
+}
+
+func loadPKCS12(content []byte, password string) (*Signer, error) {
+	key, certificate, chain, err := pkcs12.DecodeChain(content, password)
+	if err != nil {
+		return nil, fmt.Errorf("reading PKCS#12: %w", err)
+	}
+
+	return newSigner(key, certificate, chain)
+}
+
+func loadPEM(content []byte, keyPath string) (*Signer, error) {
+	// The key can be in the certificate file
+	keyContent := content
+	if keyPath != "" {
+		var err error
+		keyContent, err = os.ReadFile(keyPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var certificates []*x509.Certificate
+	for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificate found in PEM file")
+	}
+
+	var key any
+	for block, rest := pem.Decode(keyContent); block != nil && key == nil; block, rest = pem.Decode(rest) {
+		var err error
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if key == nil {
+		return nil, errors.New("no private key found in PEM file")
+	}
+
+	return newSigner(key, certificates[0], certificates[1:])
+}
+
+// newSigner - only RSA and ECDSA keys are supported by the signature format
+func newSigner(key any, certificate *x509.Certificate, chain []*x509.Certificate) (*Signer, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+	default:
+		return nil, fmt.Errorf("%w: %T", myerrors.ErrUnsupportedKey, key)
+	}
+
+	signer := key.(crypto.Signer)
+	publicKey, ok := certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(signer.Public()) {
+		return nil, errors.New("the private key does not match the certificate")
+	}
+
+	return &Signer{Certificate: certificate, Chain: chain, Key: signer}, nil
+}
+
+// ParseTrustedCertificates - the roots a signature is verified against, from PEM certificates. E.g. the CA of the organisation.
+func ParseTrustedCertificates(content []byte) (*x509.CertPool, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(content) {
+		return nil, errors.New("no certificate found in PEM file")
+	}
+	return roots, nil
+}
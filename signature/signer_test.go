@@ -0,0 +1,149 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	myerrors "github.com/alex-pricope/form-parser/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// newTestCertificate - a self-signed certificate for the key
+func newTestCertificate(t *testing.T, key crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "Form Parser Test", Organization: []string{"Test"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate
+}
+
+func newTestSigner(t *testing.T) *Signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return &Signer{Certificate: newTestCertificate(t, key), Key: key}
+}
+
+func writePEM(t *testing.T, path, blockType string, content []byte) {
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: content}), 0600)
+	require.NoError(t, err)
+}
+
+func TestLoadSigner_PEMWithSeparateKey(t *testing.T) {
+	// Arrange
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	certificate := newTestCertificate(t, key)
+	keyContent, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writePEM(t, filepath.Join(dir, "cert.pem"), "CERTIFICATE", certificate.Raw)
+	writePEM(t, filepath.Join(dir, "key.pem"), "PRIVATE KEY", keyContent)
+
+	// Act
+	signer, err := LoadSigner(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, certificate.Raw, signer.Certificate.Raw)
+	assert.Empty(t, signer.Chain)
+}
+
+func TestLoadSigner_PEMWithKeyInCertificateFile(t *testing.T) {
+	// Arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	certificate := newTestCertificate(t, key)
+
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	content := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+	content = append(content, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})...)
+	require.NoError(t, os.WriteFile(path, content, 0600))
+
+	// Act
+	signer, err := LoadSigner(path, "", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, certificate.Raw, signer.Certificate.Raw)
+}
+
+func TestLoadSigner_PKCS12(t *testing.T) {
+	// Arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	certificate := newTestCertificate(t, key)
+	content, err := pkcs12.Encode(rand.Reader, key, certificate, nil, "secret")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "signer.p12")
+	require.NoError(t, os.WriteFile(path, content, 0600))
+
+	// Act
+	signer, err := LoadSigner(path, "", "secret")
+	_, wrongPasswordErr := LoadSigner(path, "", "wrong")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, certificate.Raw, signer.Certificate.Raw)
+	assert.Error(t, wrongPasswordErr)
+}
+
+func TestLoadSigner_KeyDoesNotMatchCertificate(t *testing.T) {
+	// Arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writePEM(t, filepath.Join(dir, "cert.pem"), "CERTIFICATE", newTestCertificate(t, key).Raw)
+	writePEM(t, filepath.Join(dir, "key.pem"), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(otherKey))
+
+	// Act
+	_, err = LoadSigner(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), "")
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestLoadSigner_UnsupportedKey(t *testing.T) {
+	// Arrange
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyContent, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writePEM(t, filepath.Join(dir, "cert.pem"), "CERTIFICATE", newTestCertificate(t, key).Raw)
+	writePEM(t, filepath.Join(dir, "key.pem"), "PRIVATE KEY", keyContent)
+
+	// Act
+	_, err = LoadSigner(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), "")
+
+	// Assert
+	assert.ErrorIs(t, err, myerrors.ErrUnsupportedKey)
+}
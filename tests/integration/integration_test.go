@@ -4,13 +4,16 @@ import (
 	"github.com/alex-pricope/form-parser/config"
 	"github.com/alex-pricope/form-parser/handlers"
 	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
 	"github.com/alex-pricope/form-parser/parsers"
 	"github.com/alex-pricope/form-parser/reader"
 	"github.com/alex-pricope/form-parser/render"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestMain(m *testing.M) {
@@ -25,6 +28,8 @@ func TestParseXMLForm_CreatePDF(t *testing.T) {
 		name            string
 		options         *config.CommandOptions
 		expectedPDFPath string
+		// formInTempDir copies the form to a temporary folder first, the PDF is written next to it
+		formInTempDir bool
 	}{
 		{
 			name: "DirSpecified",
@@ -46,7 +51,8 @@ func TestParseXMLForm_CreatePDF(t *testing.T) {
 				FromType:           "xml",
 				ToType:             "pdf",
 			},
-			expectedPDFPath: "../../tests/payload/valid_xml_tag.pdf",
+			expectedPDFPath: "valid_xml_tag.pdf",
+			formInTempDir:   true,
 		},
 		{
 			name: "DirSpecifiedStripExtension",
@@ -70,18 +76,85 @@ func TestParseXMLForm_CreatePDF(t *testing.T) {
 			},
 			expectedPDFPath: "./out/valid_xml.pdf",
 		},
+		{
+			name: "ComplexStructureXMLWithTableOfContents",
+			options: &config.CommandOptions{
+				Filename:           "../../tests/payload/complex_valid_xml",
+				SubmissionFileName: "../../tests/payload/complex_valid_submission",
+				OutputDir:          "./out",
+				FromType:           "xml",
+				ToType:             "pdf",
+				Render: config.RenderOptions{
+					TableOfContents: true,
+				},
+			},
+			expectedPDFPath: "./out/complex_valid_xml.pdf",
+		},
+		{
+			name: "ComplexStructureXMLWithNumberingAndIndentation",
+			options: &config.CommandOptions{
+				Filename:           "../../tests/payload/complex_valid_xml",
+				SubmissionFileName: "../../tests/payload/complex_valid_submission",
+				OutputDir:          "./out",
+				FromType:           "xml",
+				ToType:             "pdf",
+				Render: config.RenderOptions{
+					SectionNumbering: models.AlphaRomanNumberingScheme,
+					NumberFields:     true,
+					IndentPerDepth:   5,
+					FontSizeStep:     1,
+				},
+			},
+			expectedPDFPath: "./out/complex_valid_xml.pdf",
+		},
+		{
+			name: "PageBreaks",
+			options: &config.CommandOptions{
+				Filename:           "../../tests/payload/page_break_xml",
+				SubmissionFileName: "../../tests/payload/complex_valid_submission",
+				OutputDir:          "./out",
+				FromType:           "xml",
+				ToType:             "pdf",
+			},
+			expectedPDFPath: "./out/page_break_xml.pdf",
+		},
+		{
+			name: "EmbeddedAttachments",
+			options: &config.CommandOptions{
+				Filename:           "../../tests/payload/valid_xml.xml",
+				SubmissionFileName: "../../tests/payload/valid_submission",
+				OutputDir:          "./out",
+				FromType:           "xml",
+				ToType:             "pdf",
+				Render: config.RenderOptions{
+					AttachmentsDir: "../../tests/payload/attachments",
+				},
+			},
+			expectedPDFPath: "./out/valid_xml.pdf",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
+			options := *tt.options
+			expectedPDFPath := tt.expectedPDFPath
+			if tt.formInTempDir {
+				dir := t.TempDir()
+				content, err := os.ReadFile(options.Filename)
+				require.NoError(t, err)
+				options.Filename = filepath.Join(dir, filepath.Base(options.Filename))
+				require.NoError(t, os.WriteFile(options.Filename, content, 0644))
+				expectedPDFPath = filepath.Join(dir, expectedPDFPath)
+			}
+
 			aReader := &reader.FileReader{}
-			aParser, err := parsers.GetParser(tt.options.FromType)
+			aParser, err := parsers.GetParser(options.FromType)
 			require.NoError(t, err)
-			aRenderer, err := render.GetRenderer(tt.options.ToType, tt.options.Filename, tt.options.OutputDir)
+			aRenderer, err := render.GetRenderer(options.ToType, options.Filename, options.OutputDir, options.Render)
 			require.NoError(t, err)
 
-			commandHandler := handlers.NewParseFormCommandHandler(aReader, aParser, aRenderer, tt.options)
+			commandHandler := handlers.NewParseFormCommandHandler(aReader, aParser, aRenderer, &options)
 			require.NotNil(t, commandHandler)
 
 			// Act
@@ -90,10 +163,47 @@ func TestParseXMLForm_CreatePDF(t *testing.T) {
 			// Assert
 			require.NoError(t, err)
 
-			info, err := os.Stat(tt.expectedPDFPath)
+			info, err := os.Stat(expectedPDFPath)
 			require.NoError(t, err)
 			require.False(t, info.IsDir())
 			require.Greater(t, info.Size(), int64(0))
 		})
 	}
 }
+
+func TestParseXMLForm_ReproduciblePDF(t *testing.T) {
+	// Arrange
+	renderPDF := func(outputDir string) []byte {
+		options := &config.CommandOptions{
+			Filename:           "../../tests/payload/complex_valid_xml",
+			SubmissionFileName: "../../tests/payload/complex_valid_submission",
+			OutputDir:          outputDir,
+			FromType:           "xml",
+			ToType:             "pdf",
+			Render: config.RenderOptions{
+				TableOfContents: true,
+				Watermark:       config.WatermarkOptions{Policy: models.AlwaysWatermarkPolicy},
+				Reproducible:    true,
+				SourceDate:      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			},
+		}
+		aParser, err := parsers.GetParser(options.FromType)
+		require.NoError(t, err)
+		aRenderer, err := render.GetRenderer(options.ToType, options.Filename, options.OutputDir, options.Render)
+		require.NoError(t, err)
+
+		err = handlers.NewParseFormCommandHandler(&reader.FileReader{}, aParser, aRenderer, options).Handle()
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(outputDir, "complex_valid_xml.pdf"))
+		require.NoError(t, err)
+		return content
+	}
+
+	// Act
+	first := renderPDF(t.TempDir())
+	second := renderPDF(t.TempDir())
+
+	// Assert
+	require.Equal(t, first, second)
+}
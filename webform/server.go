@@ -0,0 +1,301 @@
+package webform
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/render"
+	"github.com/alex-pricope/form-parser/schema"
+)
+
+var DefaultMaxUploadSize int64 = 32 << 20 // 32 MB
+var attachmentsFolder = "attachments"
+var submissionPrefix = "submission-"
+var submissionIDLength = 12
+
+/* A posted form becomes a submission like the ones in the repo: the field names and the answers.
+   For a File field the answer is the name of the uploaded file.
+
+   The submission is validated against the form. When it is valid:
+   * the uploaded files are saved to <Dir>/attachments/<id>/<field>/, two fields can get files with the same name
+   * the PDF is rendered to <Dir>/<id>.pdf, with the uploaded files embedded
+   * the answers are saved to <Dir>/<id>.json, last, so a saved submission always has its PDF
+
+   When one of the steps fails the files written before are removed.
+
+   The id is a hash of the answers, so posting the same answers twice gives the same files.
+   When the submission is not valid, nothing is saved and the page lists the problems.
+*/
+
+// RendererFactory - creates the renderer of a submission, the name decides the name of the output file
+type RendererFactory func(name, dir, attachmentsDir string) (render.Renderer, error)
+
+// Server - serves the HTML form and turns the posted forms into submission files and PDFs
+type Server struct {
+	Form *models.ContentNode
+	// Page is the generated HTML form
+	Page []byte
+	// Dir is where the submissions are saved
+	Dir string
+	// NewRenderer renders the PDF of a valid submission
+	NewRenderer   RendererFactory
+	MaxUploadSize int64
+}
+
+var resultTemplate = template.Must(template.New("result").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .ID}}
+<p>Submission <strong>{{.ID}}</strong> was saved.</p>
+{{- end}}
+{{- if .Problems}}
+<ul>
+{{- range .Problems}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+<p><a href="/">Back to the form</a></p>
+{{- end}}
+</body>
+</html>
+`))
+
+// NewServer - a server of the form. The page is generated once, with DefaultAction.
+func NewServer(form *models.ContentNode, title, dir string, newRenderer RendererFactory) (*Server, error) {
+	var page bytes.Buffer
+	if err := Generate(form, Options{Title: title}, &page); err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		Form:          form,
+		Page:          page.Bytes(),
+		Dir:           dir,
+		NewRenderer:   newRenderer,
+		MaxUploadSize: DefaultMaxUploadSize,
+	}, nil
+}
+
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	switch {
+	case request.URL.Path == "/" && request.Method == http.MethodGet:
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = writer.Write(s.Page)
+
+	case request.URL.Path == DefaultAction && request.Method == http.MethodPost:
+		s.submit(writer, request)
+
+	case request.URL.Path == "/" || request.URL.Path == DefaultAction:
+		http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+	default:
+		http.NotFound(writer, request)
+	}
+}
+
+// submit - validates the posted form and saves the submission, the attachments and the PDF
+func (s *Server) submit(writer http.ResponseWriter, request *http.Request) {
+	request.Body = http.MaxBytesReader(writer, request.Body, s.MaxUploadSize)
+	if err := request.ParseMultipartForm(s.MaxUploadSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		s.respond(writer, http.StatusBadRequest, "The form could not be read", "", []string{err.Error()})
+		return
+	}
+	if request.MultipartForm == nil {
+		if err := request.ParseForm(); err != nil {
+			s.respond(writer, http.StatusBadRequest, "The form could not be read", "", []string{err.Error()})
+			return
+		}
+	}
+
+	submission, uploads, err := s.readSubmission(request)
+	if err != nil {
+		s.respond(writer, http.StatusBadRequest, "The form could not be read", "", []string{err.Error()})
+		return
+	}
+	if err = schema.ValidateSubmission(s.Form, &submission); err != nil {
+		s.respond(writer, http.StatusUnprocessableEntity, "The submission is not valid", "", problems(err))
+		return
+	}
+
+	id, err := s.save(submission, uploads)
+	if err != nil {
+		logging.Log.Errorf("Error saving submission: %v", err)
+		s.respond(writer, http.StatusInternalServerError, "The submission could not be saved", "", []string{err.Error()})
+		return
+	}
+
+	logging.Log.Infof("Saved submission %s", id)
+	s.respond(writer, http.StatusCreated, "Thank you", id, nil)
+}
+
+// readSubmission - the answers of the fields of the form and the uploaded files, by field name
+func (s *Server) readSubmission(request *http.Request) (models.ContentSubmission, map[string]*uploadedFile, error) {
+	submission := models.ContentSubmission{}
+	uploads := map[string]*uploadedFile{}
+
+	for _, node := range fields(s.Form) {
+		if models.SafeReadFieldType(node.Metadata["FieldType"]) == models.FileFieldType {
+			upload, err := readUpload(request, node.Name)
+			if err != nil {
+				return nil, nil, fmt.Errorf("the file of field %s could not be read: %w", node.Name, err)
+			}
+			if upload != nil {
+				submission[node.Name] = upload.Name
+				uploads[node.Name] = upload
+			}
+			continue
+		}
+
+		// Browsers send the line breaks of a textarea as \r\n
+		value := strings.ReplaceAll(request.PostFormValue(node.Name), "\r\n", "\n")
+		if strings.TrimSpace(value) != "" {
+			submission[node.Name] = value
+		}
+	}
+
+	return submission, uploads, nil
+}
+
+// save - writes the attachments, the PDF and the submission. Returns the id of the submission.
+func (s *Server) save(submission models.ContentSubmission, uploads map[string]*uploadedFile) (id string, err error) {
+	content, err := json.MarshalIndent(submission, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	id = submissionID(content, uploads)
+	attachmentsDir := filepath.Join(s.Dir, attachmentsFolder, id)
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(attachmentsDir)
+			_ = os.Remove(filepath.Join(s.Dir, id+".pdf"))
+		}
+	}()
+
+	for field, upload := range uploads {
+		fieldDir := filepath.Join(attachmentsDir, filepath.Base(field))
+		if err = os.MkdirAll(fieldDir, 0755); err != nil {
+			return "", err
+		}
+		if err = os.WriteFile(filepath.Join(fieldDir, upload.Name), upload.Content, 0644); err != nil {
+			return "", err
+		}
+	}
+
+	if s.NewRenderer != nil {
+		var renderer render.Renderer
+		renderer, err = s.NewRenderer(id, s.Dir, attachmentsDir)
+		if err != nil {
+			return "", err
+		}
+		if err = renderer.Render(s.Form, &submission); err != nil {
+			return "", err
+		}
+	}
+
+	if err = os.WriteFile(filepath.Join(s.Dir, id+".json"), append(content, '\n'), 0644); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (s *Server) respond(writer http.ResponseWriter, status int, title, id string, lines []string) {
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.WriteHeader(status)
+	err := resultTemplate.Execute(writer, map[string]any{"Title": title, "ID": id, "Problems": lines})
+	if err != nil {
+		logging.Log.Errorf("Error writing the response: %v", err)
+	}
+}
+
+// uploadedFile - a file posted for a File field
+type uploadedFile struct {
+	Name    string
+	Content []byte
+}
+
+// readUpload - the file posted for the field, nil when none was posted
+func readUpload(request *http.Request, name string) (*uploadedFile, error) {
+	file, header, err := request.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	// Only the base name is kept, the browser should not decide where the file is saved
+	fileName := filepath.Base(filepath.FromSlash(strings.ReplaceAll(header.Filename, `\`, "/")))
+	if fileName == "." || fileName == ".." || fileName == string(filepath.Separator) {
+		return nil, fmt.Errorf("invalid file name %q", header.Filename)
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return &uploadedFile{Name: fileName, Content: content}, nil
+}
+
+// submissionID - a hash of the answers and the uploaded files
+func submissionID(content []byte, uploads map[string]*uploadedFile) string {
+	hash := sha256.New()
+	hash.Write(content)
+	for _, name := range slices.Sorted(maps.Keys(uploads)) {
+		hash.Write([]byte(name))
+		hash.Write(uploads[name].Content)
+	}
+	return submissionPrefix + hex.EncodeToString(hash.Sum(nil))[:submissionIDLength]
+}
+
+// fields - the named fields of the form in document order
+func fields(node *models.ContentNode) []*models.ContentNode {
+	if node == nil {
+		return nil
+	}
+	if node.ElementType == models.FieldElementType {
+		if node.Name == "" {
+			return nil
+		}
+		return []*models.ContentNode{node}
+	}
+
+	var result []*models.ContentNode
+	for _, child := range node.Children {
+		result = append(result, fields(child)...)
+	}
+	return result
+}
+
+// problems - the lines of the joined validation errors
+func problems(err error) []string {
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		var lines []string
+		for _, problem := range joined.Unwrap() {
+			lines = append(lines, problem.Error())
+		}
+		return lines
+	}
+	return []string{err.Error()}
+}
@@ -0,0 +1,190 @@
+package webform
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/logging"
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/render"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logging.Log = logrus.New()
+	logging.Log.SetLevel(logrus.FatalLevel)
+
+	os.Exit(m.Run())
+}
+
+type fakeRenderer struct {
+	submission *models.ContentSubmission
+	err        error
+}
+
+func (r *fakeRenderer) Render(_ *models.ContentNode, submission *models.ContentSubmission) error {
+	r.submission = submission
+	return r.err
+}
+
+func newTestServer(t *testing.T) (*Server, *fakeRenderer) {
+	renderer := &fakeRenderer{}
+	server, err := NewServer(readForm(t, "../tests/payload/valid_xml"), "form", t.TempDir(),
+		func(_, _, _ string) (render.Renderer, error) { return renderer, nil })
+	require.NoError(t, err)
+	return server, renderer
+}
+
+func TestServer_Page(t *testing.T) {
+	// Arrange
+	server, _ := newTestServer(t)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, string(server.Page), recorder.Body.String())
+	assert.Contains(t, recorder.Body.String(), `name="program_language"`)
+}
+
+func TestServer_Submit(t *testing.T) {
+	// Arrange
+	server, renderer := newTestServer(t)
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	require.NoError(t, form.WriteField("program_language", "B"))
+	require.NoError(t, form.WriteField("other", "Rust,\r\nPython"))
+	file, err := form.CreateFormFile("code_repos", `C:\Users\ann\repo.zip`)
+	require.NoError(t, err)
+	_, err = file.Write([]byte("zip content"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	request := httptest.NewRequest(http.MethodPost, "/submit", &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	recorder := httptest.NewRecorder()
+
+	// Act
+	server.ServeHTTP(recorder, request)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+	expected := models.ContentSubmission{"program_language": "B", "other": "Rust,\nPython", "code_repos": "repo.zip"}
+	assert.Equal(t, &expected, renderer.submission)
+
+	jsonFiles, err := filepath.Glob(filepath.Join(server.Dir, "submission-*.json"))
+	require.NoError(t, err)
+	require.Len(t, jsonFiles, 1)
+	id := strings.TrimSuffix(filepath.Base(jsonFiles[0]), ".json")
+	assert.Contains(t, recorder.Body.String(), id)
+
+	attachment, err := os.ReadFile(filepath.Join(server.Dir, "attachments", id, "code_repos", "repo.zip"))
+	require.NoError(t, err)
+	assert.Equal(t, "zip content", string(attachment))
+}
+
+// newUploadRequest - a posted form with a file for the code_repos field
+func newUploadRequest(t *testing.T, fileName string) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	require.NoError(t, form.WriteField("program_language", "B"))
+	file, err := form.CreateFormFile("code_repos", fileName)
+	require.NoError(t, err)
+	_, err = file.Write([]byte("zip content"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	request := httptest.NewRequest(http.MethodPost, "/submit", &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	return request
+}
+
+func TestServer_InvalidUpload(t *testing.T) {
+	// Arrange
+	server, renderer := newTestServer(t)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	server.ServeHTTP(recorder, newUploadRequest(t, ".."))
+
+	// Assert - the submission is not saved without its file
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "the file of field code_repos could not be read")
+	assert.Nil(t, renderer.submission)
+	entries, err := os.ReadDir(server.Dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestServer_RenderFailure(t *testing.T) {
+	// Arrange
+	server, renderer := newTestServer(t)
+	renderer.err = errors.New("render failed")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	server.ServeHTTP(recorder, newUploadRequest(t, "repo.zip"))
+
+	// Assert - neither the answers nor the attachments are left behind
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	jsonFiles, err := filepath.Glob(filepath.Join(server.Dir, "submission-*.json"))
+	require.NoError(t, err)
+	assert.Empty(t, jsonFiles)
+	attachments, err := os.ReadDir(filepath.Join(server.Dir, "attachments"))
+	require.NoError(t, err)
+	assert.Empty(t, attachments)
+}
+
+func TestServer_InvalidSubmission(t *testing.T) {
+	// Arrange
+	server, renderer := newTestServer(t)
+	request := httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(url.Values{"program_language": {"Z"}}.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	server.ServeHTTP(recorder, request)
+
+	// Assert - nothing is saved
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `schema violation: /program_language: &#34;Z&#34; is not one of A, B, C`)
+	assert.Nil(t, renderer.submission)
+	entries, err := os.ReadDir(server.Dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestServer_Routes(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+	}{
+		{http.MethodPost, "/", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/submit", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/other", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+tt.path, func(t *testing.T) {
+			server, _ := newTestServer(t)
+			recorder := httptest.NewRecorder()
+
+			server.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expected, recorder.Code)
+		})
+	}
+}
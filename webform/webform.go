@@ -0,0 +1,194 @@
+package webform
+
+import (
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alex-pricope/form-parser/models"
+)
+
+var DefaultAction = "/submit"
+var linesOption = "Lines"
+
+/* Every element of the form gets its HTML counterpart:
+   * Section - a fieldset, with the Title as legend. Sections can be nested.
+   * TextBox - a text input, a date input for Date, a textarea with rows from Lines:N (N > 1)
+   * Select - a select with the labels as options, an empty option first
+   * File - a file input. File(Images) only accepts images.
+
+   Text([min,max]) becomes minlength/maxlength. Optional="False" becomes required, except inside an
+   optional section - HTML cannot require a field only when its section was started, the server checks that.
+*/
+
+// Options - how the HTML form is generated
+type Options struct {
+	// Action is where the form is posted, DefaultAction when empty
+	Action string
+	// Title of the page, the name of the form file
+	Title string
+}
+
+// item - a section or a field of the page, in document order
+type item struct {
+	Section *section
+	Field   *field
+}
+
+type section struct {
+	Title string
+	Items []item
+}
+
+type field struct {
+	Name      string
+	Caption   string
+	Input     string
+	Rows      int
+	Required  bool
+	MinLength int
+	MaxLength int
+	HasLength bool
+	Accept    string
+	Options   []models.Option
+}
+
+// The page has no scripts, the browser checks what it can and the server checks the rest
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Arial, Helvetica, sans-serif; font-size: 14px; margin: 2em; max-width: 50em; }
+fieldset { border: 1px solid #999; margin: 1em 0; }
+label { display: block; margin: 0.8em 0 0.2em; }
+input[type=text], textarea, select { width: 100%; box-sizing: border-box; }
+.required::after { content: " *"; color: #a00; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<form method="post" action="{{.Action}}" enctype="multipart/form-data">
+{{- template "items" .Items}}
+<p><button type="submit">Submit</button></p>
+</form>
+</body>
+</html>
+{{define "items"}}
+{{- range .}}
+{{- if .Section}}
+<fieldset>
+<legend>{{.Section.Title}}</legend>
+{{- template "items" .Section.Items}}
+</fieldset>
+{{- else}}
+{{- with .Field}}
+<label for="{{.Name}}"{{if .Required}} class="required"{{end}}>{{.Caption}}</label>
+{{- if eq .Input "textarea"}}
+<textarea id="{{.Name}}" name="{{.Name}}" rows="{{.Rows}}"{{template "attributes" .}}></textarea>
+{{- else if eq .Input "select"}}
+<select id="{{.Name}}" name="{{.Name}}"{{if .Required}} required{{end}}>
+<option value=""></option>
+{{- range .Options}}
+<option value="{{.Name}}">{{.Text}}</option>
+{{- end}}
+</select>
+{{- else}}
+<input type="{{.Input}}" id="{{.Name}}" name="{{.Name}}"{{if .Accept}} accept="{{.Accept}}"{{end}}{{template "attributes" .}}>
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{define "attributes"}}{{if .HasLength}}{{if .MinLength}} minlength="{{.MinLength}}"{{end}} maxlength="{{.MaxLength}}"{{end}}{{if .Required}} required{{end}}{{end}}
+`))
+
+// Generate - writes the HTML page with the form
+func Generate(form *models.ContentNode, options Options, writer io.Writer) error {
+	if options.Action == "" {
+		options.Action = DefaultAction
+	}
+
+	var items []item
+	if form != nil {
+		items = collectItems(form, true)
+	}
+
+	return pageTemplate.Execute(writer, map[string]any{
+		"Title":  options.Title,
+		"Action": options.Action,
+		"Items":  items,
+	})
+}
+
+// collectItems - the sections and named fields under the node. Enforced is false inside an optional section.
+func collectItems(node *models.ContentNode, enforced bool) []item {
+	var items []item
+	for _, child := range node.Children {
+		switch child.ElementType {
+		case models.FieldElementType:
+			if child.Name != "" {
+				items = append(items, item{Field: newField(child, enforced)})
+			}
+
+		case models.SectionElementType:
+			childEnforced := enforced && !child.BoolMetadata("Optional")
+			items = append(items, item{Section: &section{Title: sectionTitle(child), Items: collectItems(child, childEnforced)}})
+
+		default:
+			items = append(items, collectItems(child, enforced)...)
+		}
+	}
+	return items
+}
+
+func newField(node *models.ContentNode, enforced bool) *field {
+	definition := models.ParseTypeDefinition(node.Metadata["Type"])
+	result := &field{
+		Name:     node.Name,
+		Caption:  childValue(node, models.CaptionElementType, node.Name),
+		Input:    "text",
+		Required: enforced && node.IsRequired(),
+	}
+	result.MinLength, result.MaxLength, result.HasLength = definition.LengthRange()
+
+	switch models.SafeReadFieldType(node.Metadata["FieldType"]) {
+	case models.SelectFieldType:
+		result.Input = "select"
+		result.Options = node.Options()
+
+	case models.FileFieldType:
+		result.Input = "file"
+		if definition.HasArgument("Images") {
+			result.Accept = "image/png,image/jpeg"
+		}
+
+	case models.TextboxFieldType:
+		if strings.EqualFold(definition.Name, "Date") {
+			result.Input = "date"
+		}
+		if rows, err := strconv.Atoi(definition.Options[linesOption]); err == nil && rows > 1 {
+			result.Input, result.Rows = "textarea", rows
+		}
+	}
+
+	return result
+}
+
+func sectionTitle(node *models.ContentNode) string {
+	return childValue(node, models.TitleElementType, node.Name)
+}
+
+// childValue - the value of the first child of the type, the fallback when there is none
+func childValue(node *models.ContentNode, elementType models.ElementType, fallback string) string {
+	for _, child := range node.Children {
+		if child.ElementType == elementType {
+			if value := strings.TrimSpace(child.Value); value != "" {
+				return value
+			}
+		}
+	}
+	return fallback
+}
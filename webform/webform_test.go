@@ -0,0 +1,59 @@
+package webform
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/alex-pricope/form-parser/models"
+	"github.com/alex-pricope/form-parser/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readForm(t *testing.T, fileName string) *models.ContentNode {
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	form, err := (&parsers.XMLParser{}).Parse(content)
+	require.NoError(t, err)
+	return form
+}
+
+func TestGenerate(t *testing.T) {
+	// Arrange
+	var page bytes.Buffer
+
+	// Act
+	err := Generate(readForm(t, "../tests/payload/complex_valid_xml"), Options{Title: "Application"}, &page)
+
+	// Assert
+	require.NoError(t, err)
+	html := page.String()
+	assert.Contains(t, html, `<form method="post" action="/submit" enctype="multipart/form-data">`)
+	assert.Contains(t, html, `<input type="text" id="user_name" name="user_name" maxlength="100" required>`)
+	assert.Contains(t, html, `<input type="date" id="birth_date" name="birth_date" required>`)
+	assert.Contains(t, html, "<select id=\"gender\" name=\"gender\">\n<option value=\"\"></option>\n<option value=\"M\">Male</option>")
+	assert.Contains(t, html, "<legend>Country and Region</legend>")
+
+	// The required street is inside the optional address section
+	assert.Contains(t, html, `<textarea id="street" name="street" rows="2" maxlength="200"></textarea>`)
+}
+
+func TestGenerate_FileAndAction(t *testing.T) {
+	// Arrange
+	var page bytes.Buffer
+	form := &models.ContentNode{ElementType: models.FormElementType, Children: []*models.ContentNode{
+		{ElementType: models.FieldElementType, Name: "photo", Metadata: map[string]string{"FieldType": "File", "Type": "File(Images)", "Optional": "False"}, Children: []*models.ContentNode{
+			{ElementType: models.CaptionElementType, Value: "Photo <ID>"},
+		}},
+	}}
+
+	// Act
+	err := Generate(form, Options{Action: "https://intake.example.com/post"}, &page)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, page.String(), `action="https://intake.example.com/post"`)
+	assert.Contains(t, page.String(), `<label for="photo" class="required">Photo &lt;ID&gt;</label>`)
+	assert.Contains(t, page.String(), `<input type="file" id="photo" name="photo" accept="image/png,image/jpeg" required>`)
+}